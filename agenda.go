@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/calendar"
+	"kindle-tenki-dashboard/internal/ical"
+)
+
+// agendaDayLabels は予定を表示する日のラベル (今日から順に)
+var agendaDayLabels = []string{"今日", "明日"}
+
+// AgendaDay は予定の1日分
+type AgendaDay struct {
+	Label  string        `json:"label"`  // ラベル (今日/明日)
+	Date   string        `json:"date"`   // 日付 (例: 10/18(日))
+	Events []AgendaEvent `json:"events"` // 予定 (開始時刻の順)
+}
+
+// AgendaEvent は予定の表示内容
+type AgendaEvent struct {
+	Time     string `json:"time"`     // 時間 (例: 09:00-10:00、終日)
+	Title    string `json:"title"`    // 予定の名前
+	Location string `json:"location"` // 場所
+	AllDay   bool   `json:"allDay"`   // 終日の予定かどうか
+}
+
+// agendaRange は ICS から予定を読み込む期間を返す
+// 月間カレンダーの印と今日・明日の予定の両方に使うため、今月全体と明日までを含める
+func agendaRange(now time.Time) (time.Time, time.Time) {
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 1, 0)
+	dayAfterTomorrow := time.Date(now.Year(), now.Month(), now.Day()+len(agendaDayLabels), 0, 0, 0, 0, now.Location())
+	if dayAfterTomorrow.After(to) {
+		to = dayAfterTomorrow
+	}
+	return from, to
+}
+
+// fetchCalendarOccurrences はカンマ区切りの ICS (URL またはファイルのパス) から期間内の予定を読み込む
+// 取得やパースに失敗した ICS はスキップする
+func fetchCalendarOccurrences(sources string, from, to time.Time) []ical.Occurrence {
+	var occurrences []ical.Occurrence
+	for _, source := range strings.Split(sources, ",") {
+		source = strings.TrimSpace(source)
+		if source == "" {
+			continue
+		}
+
+		body, err := fetchSource("予定 (ICS)", source)
+		if err != nil {
+			log.Printf("⚠️  %v", err)
+			continue
+		}
+		parsed, err := ical.Parse(body, from.Location())
+		if err != nil {
+			log.Printf("⚠️  ICSのパースに失敗しました: %v", err)
+			continue
+		}
+		occurrences = append(occurrences, parsed.Occurrences(from, to)...)
+	}
+	return occurrences
+}
+
+// buildAgenda は今日・明日の予定を日ごとにまとめる
+// 日ごとに終日の予定を先に、時刻のある予定を開始日時の順に並べる
+func buildAgenda(occurrences []ical.Occurrence, now time.Time) []AgendaDay {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// 複数の ICS の予定はファイルごとに並んでいるため、開始日時の順に並べ直す
+	sorted := append([]ical.Occurrence(nil), occurrences...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	var days []AgendaDay
+	for i, label := range agendaDayLabels {
+		dayStart := today.AddDate(0, 0, i)
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		day := AgendaDay{
+			Label: label,
+			Date:  fmt.Sprintf("%d/%d(%s)", dayStart.Month(), dayStart.Day(), weekdayNames[dayStart.Weekday()]),
+		}
+
+		var allDay, timed []AgendaEvent
+		for _, occurrence := range sorted {
+			if !overlapsDay(occurrence, dayStart, dayEnd) {
+				continue
+			}
+			event := AgendaEvent{
+				Time:     agendaTime(occurrence, dayStart, dayEnd),
+				Title:    occurrence.Summary,
+				Location: occurrence.Location,
+				AllDay:   occurrence.AllDay,
+			}
+			// 終日の予定を先に並べる
+			if occurrence.AllDay {
+				allDay = append(allDay, event)
+			} else {
+				timed = append(timed, event)
+			}
+		}
+		day.Events = append(allDay, timed...)
+		days = append(days, day)
+	}
+	return days
+}
+
+// overlapsDay は予定が [dayStart, dayEnd) の日に重なるかどうかを返す
+func overlapsDay(occurrence ical.Occurrence, dayStart, dayEnd time.Time) bool {
+	if occurrence.AllDay {
+		// 終日の予定は日付で判定する (ICS と表示でタイムゾーンが異なっても日付がずれないようにする)
+		start := time.Date(occurrence.Start.Year(), occurrence.Start.Month(), occurrence.Start.Day(), 0, 0, 0, 0, dayStart.Location())
+		end := time.Date(occurrence.End.Year(), occurrence.End.Month(), occurrence.End.Day(), 0, 0, 0, 0, dayStart.Location())
+		return start.Before(dayEnd) && end.After(dayStart)
+	}
+	if !occurrence.End.After(occurrence.Start) {
+		return !occurrence.Start.Before(dayStart) && occurrence.Start.Before(dayEnd)
+	}
+	return occurrence.Start.Before(dayEnd) && occurrence.End.After(dayStart)
+}
+
+// agendaTime は予定の時間の表示を返す
+// 前日から続く予定や翌日まで続く予定は、その日の分だけを「〜」で表す
+func agendaTime(occurrence ical.Occurrence, dayStart, dayEnd time.Time) string {
+	if occurrence.AllDay {
+		return "終日"
+	}
+	start := occurrence.Start.In(dayStart.Location())
+	end := occurrence.End.In(dayStart.Location())
+
+	startsBefore := start.Before(dayStart)
+	endsAfter := end.After(dayEnd)
+	switch {
+	case startsBefore && endsAfter:
+		return "終日"
+	case startsBefore:
+		return "〜" + end.Format("15:04")
+	case endsAfter:
+		return start.Format("15:04") + "〜"
+	case !end.After(start):
+		return start.Format("15:04")
+	default:
+		return start.Format("15:04") + "-" + end.Format("15:04")
+	}
+}
+
+// agendaCalendarEvents は予定を月間カレンダーの印に変換する
+// 複数日にまたがる予定は、重なるすべての日に印を付ける
+func agendaCalendarEvents(occurrences []ical.Occurrence, loc *time.Location) []calendar.Event {
+	var events []calendar.Event
+	for _, occurrence := range occurrences {
+		start := occurrence.Start.In(loc)
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+		if occurrence.AllDay {
+			day = time.Date(occurrence.Start.Year(), occurrence.Start.Month(), occurrence.Start.Day(), 0, 0, 0, 0, loc)
+		}
+		for {
+			events = append(events, calendar.Event{Date: day, Title: occurrence.Summary})
+			day = day.AddDate(0, 0, 1)
+			if !overlapsDay(occurrence, day, day.AddDate(0, 0, 1)) {
+				break
+			}
+		}
+	}
+	return events
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/ical"
+)
+
+// 予定の例 (calendar.example.ics) を読み込んで今日・明日の予定を生成するテスト
+func TestBuildAgendaFromExample(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 19, 7, 0, 0, 0, jst)
+
+	from, to := agendaRange(now)
+	occurrences := fetchCalendarOccurrences("calendar.example.ics, missing.ics", from, to)
+	agenda := buildAgenda(occurrences, now)
+
+	expected := []string{
+		"今日 10/19(月): 終日 燃えるごみ / 10:00-10:30 歯医者 (さくら歯科)",
+		"明日 10/20(火): 17:00-18:00 スイミング (市民プール)",
+	}
+	if len(agenda) != len(expected) {
+		t.Fatalf("日数: 期待=%d, 実際=%d", len(expected), len(agenda))
+	}
+	for i, day := range agenda {
+		if actual := agendaDayText(day); actual != expected[i] {
+			t.Errorf("期待: %s, 実際: %s", expected[i], actual)
+		}
+	}
+}
+
+// 複数の ICS の予定を開始日時の順に並べるテスト
+func TestBuildAgendaFromMultipleSources(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 19, 7, 0, 0, 0, jst)
+
+	dir := t.TempDir()
+	write := func(name string, events ...string) string {
+		body := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"
+		for i, event := range events {
+			fields := strings.Split(event, "|")
+			body += "BEGIN:VEVENT\r\nUID:" + name + string(rune('a'+i)) + "@example.com\r\nSUMMARY:" + fields[0] +
+				"\r\nDTSTART;TZID=Asia/Tokyo:" + fields[1] + "\r\nDTEND;TZID=Asia/Tokyo:" + fields[2] + "\r\nEND:VEVENT\r\n"
+		}
+		body += "END:VCALENDAR\r\n"
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	// 1つ目の ICS の予定の間に2つ目の ICS の予定が入る
+	work := write("work.ics", "朝会|20261019T080000|20261019T083000", "打ち合わせ|20261019T180000|20261019T190000")
+	family := write("family.ics", "病院|20261019T090000|20261019T100000", "昼食|20261019T120000|20261019T130000")
+
+	from, to := agendaRange(now)
+	occurrences := fetchCalendarOccurrences(work+","+family, from, to)
+	agenda := buildAgenda(occurrences, now)
+
+	expected := "今日 10/19(月): 08:00-08:30 朝会 / 09:00-10:00 病院 / 12:00-13:00 昼食 / 18:00-19:00 打ち合わせ"
+	if len(agenda) == 0 {
+		t.Fatal("期待: 予定あり, 実際: なし")
+	}
+	if actual := agendaDayText(agenda[0]); actual != expected {
+		t.Errorf("期待: %s, 実際: %s", expected, actual)
+	}
+}
+
+// agendaDayText は1日の予定を「今日 10/19(月): 10:00-10:30 歯医者 (さくら歯科)」の形式にする
+func agendaDayText(day AgendaDay) string {
+	var events []string
+	for _, event := range day.Events {
+		text := event.Time + " " + event.Title
+		if event.Location != "" {
+			text += " (" + event.Location + ")"
+		}
+		events = append(events, text)
+	}
+	return day.Label + " " + day.Date + ": " + strings.Join(events, " / ")
+}
+
+// agendaRange のテスト
+func TestAgendaRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		now          time.Time
+		expectedFrom string
+		expectedTo   string
+	}{
+		{"月の途中", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), "2026-10-01", "2026-11-01"},
+		{"月末は翌月の1日まで", time.Date(2026, 10, 31, 12, 0, 0, 0, time.UTC), "2026-10-01", "2026-11-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := agendaRange(tt.now)
+			if from.Format("2006-01-02") != tt.expectedFrom || to.Format("2006-01-02") != tt.expectedTo {
+				t.Errorf("期待: %s〜%s, 実際: %s〜%s", tt.expectedFrom, tt.expectedTo, from.Format("2006-01-02"), to.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+// agendaTime のテスト
+func TestAgendaTime(t *testing.T) {
+	dayStart := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		occurrence ical.Occurrence
+		expected   string
+	}{
+		{"時間指定", ical.Occurrence{Start: at(18, 9), End: at(18, 10)}, "09:00-10:00"},
+		{"終了日時なし", ical.Occurrence{Start: at(18, 9), End: at(18, 9)}, "09:00"},
+		{"終日", ical.Occurrence{Start: at(18, 0), End: at(19, 0), AllDay: true}, "終日"},
+		{"前日から続く", ical.Occurrence{Start: at(17, 22), End: at(18, 2)}, "〜02:00"},
+		{"翌日まで続く", ical.Occurrence{Start: at(18, 22), End: at(19, 2)}, "22:00〜"},
+		{"前日から翌日まで続く", ical.Occurrence{Start: at(17, 22), End: at(19, 2)}, "終日"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := agendaTime(tt.occurrence, dayStart, dayEnd); actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// agendaCalendarEvents のテスト
+func TestAgendaCalendarEvents(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		occurrence ical.Occurrence
+		expected   []int
+	}{
+		{"時間指定", ical.Occurrence{Start: at(18, 9), End: at(18, 10)}, []int{18}},
+		{"0時に終わる", ical.Occurrence{Start: at(18, 22), End: at(19, 0)}, []int{18}},
+		{"日をまたぐ", ical.Occurrence{Start: at(18, 22), End: at(19, 2)}, []int{18, 19}},
+		{"複数日の終日", ical.Occurrence{Start: at(18, 0), End: at(21, 0), AllDay: true}, []int{18, 19, 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := agendaCalendarEvents([]ical.Occurrence{tt.occurrence}, time.UTC)
+			var actual []int
+			for _, event := range events {
+				actual = append(actual, event.Date.Day())
+			}
+			if len(actual) != len(tt.expected) {
+				t.Fatalf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+			for i := range actual {
+				if actual[i] != tt.expected[i] {
+					t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"kindle-tenki-dashboard/internal/holiday"
+)
+
+// weekdayNames は曜日の表示名 (time.Weekday の順)
+var weekdayNames = [7]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// CalendarHeader はページ上部に表示する日付と祝日の情報
+type CalendarHeader struct {
+	Date                 string `json:"date"`                 // 日付 (例: 2026年10月18日)
+	Weekday              string `json:"weekday"`              // 曜日 (例: 日)
+	IsSaturday           bool   `json:"isSaturday"`           // 土曜日かどうか
+	IsHoliday            bool   `json:"isHoliday"`            // 日曜日または祝日・休日かどうか
+	HolidayName          string `json:"holidayName"`          // 祝日・休日の名前
+	Rokuyo               string `json:"rokuyo"`               // 六曜 (SHOW_ROKUYO=true の場合のみ)
+	HasNextHoliday       bool   `json:"hasNextHoliday"`       // 次の祝日が計算できたかどうか
+	NextHolidayName      string `json:"nextHolidayName"`      // 次の祝日の名前
+	NextHolidayDate      string `json:"nextHolidayDate"`      // 次の祝日の日付 (例: 11/3(火))
+	DaysUntilNextHoliday int    `json:"daysUntilNextHoliday"` // 次の祝日までの日数
+}
+
+// buildCalendarHeader は指定した日時の日付・曜日・祝日の情報を生成する
+func buildCalendarHeader(now time.Time, showRokuyo bool) CalendarHeader {
+	header := CalendarHeader{
+		Date:       now.Format("2006年1月2日"),
+		Weekday:    weekdayNames[now.Weekday()],
+		IsSaturday: now.Weekday() == time.Saturday,
+		IsHoliday:  now.Weekday() == time.Sunday,
+	}
+
+	if name, ok := holiday.Lookup(now); ok {
+		header.HolidayName = name
+		header.IsHoliday = true
+	}
+
+	if showRokuyo {
+		header.Rokuyo = holiday.Rokuyo(now)
+	}
+
+	if next, ok := holiday.Next(now); ok {
+		header.HasNextHoliday = true
+		header.NextHolidayName = next.Name
+		header.NextHolidayDate = fmt.Sprintf("%d/%d(%s)", next.Date.Month(), next.Date.Day(), weekdayNames[next.Date.Weekday()])
+		header.DaysUntilNextHoliday = holiday.DaysUntil(now, next.Date)
+	}
+
+	return header
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// buildCalendarHeader のテスト
+func TestBuildCalendarHeader(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		showRokuyo bool
+		expected   CalendarHeader
+	}{
+		{
+			name: "平日",
+			now:  time.Date(2026, 10, 20, 7, 30, 0, 0, jst),
+			expected: CalendarHeader{
+				Date:                 "2026年10月20日",
+				Weekday:              "火",
+				HasNextHoliday:       true,
+				NextHolidayName:      "文化の日",
+				NextHolidayDate:      "11/3(火)",
+				DaysUntilNextHoliday: 14,
+			},
+		},
+		{
+			name: "日曜日",
+			now:  time.Date(2026, 10, 18, 7, 30, 0, 0, jst),
+			expected: CalendarHeader{
+				Date:                 "2026年10月18日",
+				Weekday:              "日",
+				IsHoliday:            true,
+				HasNextHoliday:       true,
+				NextHolidayName:      "文化の日",
+				NextHolidayDate:      "11/3(火)",
+				DaysUntilNextHoliday: 16,
+			},
+		},
+		{
+			name: "土曜日",
+			now:  time.Date(2026, 10, 17, 7, 30, 0, 0, jst),
+			expected: CalendarHeader{
+				Date:                 "2026年10月17日",
+				Weekday:              "土",
+				IsSaturday:           true,
+				HasNextHoliday:       true,
+				NextHolidayName:      "文化の日",
+				NextHolidayDate:      "11/3(火)",
+				DaysUntilNextHoliday: 17,
+			},
+		},
+		{
+			name:       "祝日と六曜",
+			now:        time.Date(2026, 9, 22, 23, 59, 0, 0, jst),
+			showRokuyo: true,
+			expected: CalendarHeader{
+				Date:                 "2026年9月22日",
+				Weekday:              "火",
+				IsHoliday:            true,
+				HolidayName:          "国民の休日",
+				Rokuyo:               "先勝",
+				HasNextHoliday:       true,
+				NextHolidayName:      "秋分の日",
+				NextHolidayDate:      "9/23(水)",
+				DaysUntilNextHoliday: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildCalendarHeader(tt.now, tt.showRokuyo)
+			if result != tt.expected {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/city"
+	"kindle-tenki-dashboard/internal/history"
+)
+
+// runCommand はサブコマンドを実行する
+func runCommand(args []string, out io.Writer) error {
+	switch args[0] {
+	case "cities":
+		return runCitiesCommand(args[1:], out)
+	case "stats":
+		return runStatsCommand(args[1:], out)
+	default:
+		return fmt.Errorf("不明なコマンドです: %s (使用可能なコマンド: cities, stats)", args[0])
+	}
+}
+
+// runCitiesCommand は都市コードを検索して表示する
+func runCitiesCommand(args []string, out io.Writer) error {
+	if len(args) < 2 || args[0] != "search" {
+		return fmt.Errorf("使い方: cities search <地点名またはローマ字>")
+	}
+
+	query := strings.Join(args[1:], " ")
+	results := city.Search(query)
+	if len(results) == 0 {
+		fmt.Fprintf(out, "「%s」に一致する地点は見つかりませんでした\n", query)
+		return nil
+	}
+
+	for _, area := range results {
+		fmt.Fprintf(out, "%s  %s %s (%s)\n", area.Code, area.Prefecture, area.Name, area.Romaji)
+	}
+	return nil
+}
+
+// AccuracyReport は stats accuracy -json で出力する予報の精度のレポート
+type AccuracyReport struct {
+	CityCode      string             `json:"cityCode"`      // 地点の都市コード
+	GeneratedAt   time.Time          `json:"generatedAt"`   // レポートを作成した時刻
+	RainThreshold int                `json:"rainThreshold"` // 雨の予報とみなす降水確率 (%)
+	Reference     string             `json:"reference"`     // 前日の予報と比べる基準
+	Providers     []history.Accuracy `json:"providers"`     // 取得元ごとの精度
+}
+
+// runStatsCommand は天気の記録から統計を表示する
+// 記録のファイルと地点は天気ページの生成と同じ設定 (CONFIG_PATH・CACHE_DIR・CITY_CODE) を使う
+func runStatsCommand(args []string, out io.Writer) error {
+	usage := fmt.Errorf("使い方: stats accuracy [-json]")
+	if len(args) == 0 || args[0] != "accuracy" {
+		return usage
+	}
+	asJSON := false
+	for _, arg := range args[1:] {
+		if arg != "-json" && arg != "--json" {
+			return usage
+		}
+		asJSON = true
+	}
+
+	config, err := loadConfig(getEnv("CONFIG_PATH", DefaultConfigPath))
+	if err != nil {
+		return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+	}
+	store, _, err := config.historySettings(getEnv("CACHE_DIR", DefaultCacheDir))
+	if err != nil {
+		return fmt.Errorf("天気の記録の設定が不正です: %w", err)
+	}
+	location, err := time.LoadLocation(getEnv("TIMEZONE", DefaultTimeZone))
+	if err != nil {
+		return fmt.Errorf("タイムゾーンの設定が不正です: %w", err)
+	}
+
+	records, err := store.Load()
+	if err != nil {
+		return err
+	}
+	cityCode := getEnv("CITY_CODE", "130010")
+	accuracies := history.Accuracies(records, cityCode)
+
+	if asJSON {
+		report := AccuracyReport{
+			CityCode:      cityCode,
+			GeneratedAt:   time.Now().In(location),
+			RainThreshold: history.RainThreshold,
+			Reference:     history.Reference,
+			Providers:     accuracies,
+		}
+		if report.Providers == nil {
+			report.Providers = []history.Accuracy{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	writeAccuracies(out, cityCode, accuracies)
+	return nil
+}
+
+// writeAccuracies は取得元ごとの予報の精度を表示する
+func writeAccuracies(out io.Writer, cityCode string, accuracies []history.Accuracy) {
+	name := cityCode
+	if area, ok := city.Lookup(cityCode); ok {
+		name = fmt.Sprintf("%s %s", cityCode, area.Name)
+	}
+	fmt.Fprintf(out, "前日の予報の精度 (%s)\n", name)
+	fmt.Fprintf(out, "基準: %s\n", history.Reference)
+	if len(accuracies) == 0 {
+		fmt.Fprintln(out, "比べられる記録がありません (前日の予報と、その日のアメダスの観測値の記録が必要です)")
+		return
+	}
+
+	for _, accuracy := range accuracies {
+		fmt.Fprintf(out, "\n%s (%s〜%s、%d日)\n", accuracy.Provider, accuracy.From, accuracy.To, accuracy.Days)
+		fmt.Fprintf(out, "  最高気温: %s\n", formatErrorStats(accuracy.MaxTemp))
+		fmt.Fprintf(out, "  最低気温: %s\n", formatErrorStats(accuracy.MinTemp))
+		if accuracy.Rain.Count == 0 {
+			fmt.Fprintln(out, "  雨の有無: 記録なし")
+		} else {
+			fmt.Fprintf(out, "  雨の有無: 的中率 %.0f%% (%d/%d日、降水確率%d%%以上を雨の予報とする)\n",
+				accuracy.Rain.HitRate*100, accuracy.Rain.Hits, accuracy.Rain.Count, history.RainThreshold)
+		}
+	}
+}
+
+// formatErrorStats は気温の誤差を「平均誤差 +0.5℃ / 平均絶対誤差 1.5℃ (2日)」の形式で返す
+func formatErrorStats(stats history.ErrorStats) string {
+	if stats.Count == 0 {
+		return "記録なし"
+	}
+	bias := fmt.Sprintf("%+.1f", stats.Bias)
+	if bias == "+0.0" || bias == "-0.0" {
+		bias = "±0.0"
+	}
+	return fmt.Sprintf("平均誤差 %s℃ / 平均絶対誤差 %.1f℃ (%d日)", bias, stats.MAE, stats.Count)
+}
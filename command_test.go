@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/history"
+)
+
+// runCommand のテスト
+func TestRunCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		hasError bool
+	}{
+		{
+			name:     "日本語で検索",
+			args:     []string{"cities", "search", "大阪"},
+			expected: "270000  大阪府 大阪 (osaka)\n",
+		},
+		{
+			name:     "ローマ字で検索",
+			args:     []string{"cities", "search", "Sapporo"},
+			expected: "016010  北海道 札幌 (sapporo)\n",
+		},
+		{
+			name:     "該当なし",
+			args:     []string{"cities", "search", "London"},
+			expected: "「London」に一致する地点は見つかりませんでした\n",
+		},
+		{
+			name:     "検索語がない",
+			args:     []string{"cities", "search"},
+			hasError: true,
+		},
+		{
+			name:     "不明なコマンド",
+			args:     []string{"weather"},
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runCommand(tt.args, &out)
+
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("期待: エラー, 実際: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("期待: エラーなし, 実際: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("期待: %q, 実際: %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+// validateCityCodes のテスト
+func TestValidateCityCodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		primary   string
+		secondary string
+		hasError  bool
+		contains  string
+	}{
+		{name: "正しい都市コード", primary: "130010", secondary: ""},
+		{name: "他の地点も正しい", primary: "130010", secondary: "270000, 400010"},
+		{name: "存在しない都市コード", primary: "999999", hasError: true, contains: "cities search"},
+		{name: "他の地点が不正", primary: "130010", secondary: "270000,123456", hasError: true, contains: "123456"},
+		{name: "地点名が指定された場合は候補を提示", primary: "大阪", hasError: true, contains: "270000 (大阪)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCityCodes(tt.primary, tt.secondary)
+			if !tt.hasError {
+				if err != nil {
+					t.Errorf("期待: エラーなし, 実際: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("期待: エラー, 実際: nil")
+			}
+			if !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("エラーメッセージに %q が含まれていません: %v", tt.contains, err)
+			}
+		})
+	}
+}
+
+// runCommand のテスト (stats accuracy)
+func TestRunStatsCommand(t *testing.T) {
+	dir := t.TempDir()
+	historyPath := filepath.Join(dir, "history.jsonl")
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"history": {"path": "`+filepath.ToSlash(historyPath)+`"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_PATH", configPath)
+	t.Setenv("CITY_CODE", "130010")
+
+	t.Run("記録がない場合", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := runCommand([]string{"stats", "accuracy"}, &out); err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if !strings.Contains(out.String(), "比べられる記録がありません") {
+			t.Errorf("記録がないことが表示されていません: %s", out.String())
+		}
+	})
+
+	jst := time.FixedZone("JST", 9*60*60)
+	store := history.Store{Path: historyPath}
+	records := []history.Record{
+		{
+			Date: "2026-10-17", RecordedAt: time.Date(2026, 10, 17, 17, 0, 0, 0, jst), CityCode: "130010",
+			Forecasts: []history.Forecast{{Date: "2026-10-18", MaxTemp: 18, HasMaxTemp: true, RainChance: 70, HasRainChance: true}},
+		},
+		{
+			Date: "2026-10-18", RecordedAt: time.Date(2026, 10, 18, 17, 0, 0, 0, jst), CityCode: "130010",
+			MaxTemp: 17, HasMaxTemp: true, Precipitation: true,
+			ObservedMaxTemp: 17, ObservedMinTemp: 11, HasObservedTemp: true, ObservedPrecipitation: true, HasObservedPrecipitation: true,
+		},
+		// 最後の記録の日は観測が途中のため比べない
+		{Date: "2026-10-19", RecordedAt: time.Date(2026, 10, 19, 7, 0, 0, 0, jst), CityCode: "130010"},
+	}
+	for _, record := range records {
+		if err := store.Append(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("表で表示", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := runCommand([]string{"stats", "accuracy"}, &out); err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		for _, expected := range []string{
+			"130010 東京",
+			"基準: アメダスの観測値",
+			"tsukumijima (2026-10-18〜2026-10-18、1日)",
+			"最高気温: 平均誤差 +1.0℃ / 平均絶対誤差 1.0℃ (1日)",
+			"最低気温: 記録なし",
+			"雨の有無: 的中率 100% (1/1日",
+		} {
+			if !strings.Contains(out.String(), expected) {
+				t.Errorf("%q が含まれていません:\n%s", expected, out.String())
+			}
+		}
+	})
+
+	t.Run("JSONで出力", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := runCommand([]string{"stats", "accuracy", "-json"}, &out); err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		var report AccuracyReport
+		if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+			t.Fatalf("JSONとして読み込めません: %v\n%s", err, out.String())
+		}
+		if report.CityCode != "130010" || report.RainThreshold != 50 || report.Reference != history.Reference || len(report.Providers) != 1 {
+			t.Fatalf("レポートの内容が異なります: %+v", report)
+		}
+		if mae := report.Providers[0].MaxTemp.MAE; mae != 1 {
+			t.Errorf("最高気温の平均絶対誤差 期待: 1, 実際: %v", mae)
+		}
+	})
+
+	t.Run("不明なオプション", func(t *testing.T) {
+		if err := runCommand([]string{"stats", "accuracy", "-csv"}, &bytes.Buffer{}); err == nil {
+			t.Error("期待: エラー, 実際: nil")
+		}
+		if err := runCommand([]string{"stats"}, &bytes.Buffer{}); err == nil {
+			t.Error("期待: エラー, 実際: nil")
+		}
+	})
+}
@@ -0,0 +1,511 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/amedas"
+	"kindle-tenki-dashboard/internal/calendar"
+	"kindle-tenki-dashboard/internal/city"
+	"kindle-tenki-dashboard/internal/forecast"
+	"kindle-tenki-dashboard/internal/garbage"
+	"kindle-tenki-dashboard/internal/health"
+	"kindle-tenki-dashboard/internal/history"
+	"kindle-tenki-dashboard/internal/pressure"
+	"kindle-tenki-dashboard/internal/quake"
+	"kindle-tenki-dashboard/internal/transit"
+)
+
+// DefaultConfigPath は設定ファイルのデフォルトパス
+const DefaultConfigPath = "config.json"
+
+// Config は設定ファイル(config.json)の内容
+// 環境変数で表現しにくい構造化された設定をまとめる
+type Config struct {
+	Devices     []DeviceProfile   `json:"devices"`     // 端末プロファイル(組み込みプロファイルへの追加・上書き)
+	Events      []ConfigEvent     `json:"events"`      // 月間カレンダーに印を付ける予定
+	Garbage     []ConfigGarbage   `json:"garbage"`     // ゴミ出しの収集日
+	Transit     ConfigTransit     `json:"transit"`     // 電車の運行情報
+	Quake       ConfigQuake       `json:"quake"`       // 地震情報
+	Typhoon     ConfigTyphoon     `json:"typhoon"`     // 台風情報
+	WBGT        ConfigWBGT        `json:"wbgt"`        // 暑さ指数
+	Health      ConfigHealth      `json:"health"`      // 紫外線・花粉
+	Pressure    ConfigPressure    `json:"pressure"`    // 気圧・湿度
+	History     ConfigHistory     `json:"history"`     // 天気の記録と過去との比較
+	Weather     ConfigWeather     `json:"weather"`     // 天気予報の取得元
+	Observation ConfigObservation `json:"observation"` // 現在の観測値
+}
+
+// ConfigObservation は設定ファイルに書く現在の観測値 (アメダス) の設定
+type ConfigObservation struct {
+	Disabled bool   `json:"disabled"` // 現在の観測値を表示しない
+	Station  string `json:"station"`  // 観測所番号 (省略時は CITY_CODE の地点に最も近い観測所)
+}
+
+// ConfigWeather は設定ファイルに書く天気予報の設定
+type ConfigWeather struct {
+	Providers []string `json:"providers"` // 取得元 (優先する順。省略時は tsukumijima のみ。複数指定した場合はまとめて表示する)
+}
+
+// ConfigHistory は設定ファイルに書く天気の記録の設定
+type ConfigHistory struct {
+	Disabled bool   `json:"disabled"` // 記録と過去との比較をしない
+	Path     string `json:"path"`     // 記録のファイル (省略時は CACHE_DIR の history.jsonl)
+	Days     int    `json:"days"`     // 平均と推移のグラフに使う日数 (省略時は 7)
+}
+
+// 天気の記録の設定のデフォルト値と上限
+const (
+	DefaultHistoryFile = "history.jsonl"
+	DefaultHistoryDays = 7
+	MaxHistoryDays     = 31
+)
+
+// ConfigPressure は設定ファイルに書く気圧・湿度の設定
+type ConfigPressure struct {
+	Disabled  bool    `json:"disabled"`  // 気圧・湿度を表示しない
+	Provider  string  `json:"provider"`  // 取得元 (省略時は open-meteo)
+	DropHPa   float64 `json:"dropHPa"`   // この値以上の低下を急な低下として警告する (hPa、省略時は 6)
+	DropHours int     `json:"dropHours"` // 低下を求める時間の幅 (時間、省略時は 6)
+}
+
+// 急な気圧の低下の判定条件のデフォルト値
+const (
+	DefaultPressureDropHPa   = 6
+	DefaultPressureDropHours = 6
+)
+
+// PressureHorizon は気圧の変化を調べる期間 (現在から24時間先まで)
+const PressureHorizon = 24 * time.Hour
+
+// ConfigWBGT は設定ファイルに書く暑さ指数 (WBGT) の設定
+type ConfigWBGT struct {
+	Disabled bool   `json:"disabled"` // 暑さ指数を表示しない
+	Point    string `json:"point"`    // 地点番号 (アメダスの観測所番号。省略時は CITY_CODE の地点に最も近い観測所で、40kmより遠い場合は表示しない)
+	Months   []int  `json:"months"`   // 表示する月 (省略時は 5〜9月)
+}
+
+// DefaultWBGTMonths は暑さ指数を表示する月のデフォルト値
+var DefaultWBGTMonths = []int{5, 6, 7, 8, 9}
+
+// MaxStationDistanceKm は最寄りの観測所として使う距離の上限 (km)
+// 同梱の観測所は主な地点だけのため、これより遠い場合は別の地域の値を表示しないように観測所なしとする
+const MaxStationDistanceKm = 40
+
+// ConfigHealth は設定ファイルに書く健康に関する指標 (紫外線・花粉) の設定
+type ConfigHealth struct {
+	UV     ConfigHealthItem `json:"uv"`     // 紫外線指数
+	Pollen ConfigHealthItem `json:"pollen"` // 花粉の飛散数 (観測値のみ。花粉の予報は表示しない)
+}
+
+// ConfigHealthItem は1つの指標の設定
+type ConfigHealthItem struct {
+	Disabled bool   `json:"disabled"` // 表示しない
+	Provider string `json:"provider"` // 取得元 (紫外線は open-meteo、花粉は weathernews。省略時はそれぞれのデフォルト)
+	CityCode string `json:"cityCode"` // 花粉の観測値を取得する市区町村コード (全国地方公共団体コードの5桁。例: 13101)
+	Months   []int  `json:"months"`   // 表示する月 (省略時は紫外線が 3〜10月、花粉が 2〜5月)
+}
+
+// 健康に関する指標を表示する月のデフォルト値
+var (
+	DefaultUVMonths     = []int{3, 4, 5, 6, 7, 8, 9, 10}
+	DefaultPollenMonths = []int{2, 3, 4, 5}
+)
+
+// ConfigTyphoon は設定ファイルに書く台風情報の設定
+type ConfigTyphoon struct {
+	Disabled   bool    `json:"disabled"`   // 台風情報を表示しない
+	ApproachKm float64 `json:"approachKm"` // 予報進路の中心がこの距離以内に近づく台風を強調する (km、省略時は 300)
+}
+
+// DefaultTyphoonApproachKm は台風の接近を強調する距離のデフォルト値 (km)
+const DefaultTyphoonApproachKm = 300
+
+// ConfigQuake は設定ファイルに書く地震情報の表示条件
+type ConfigQuake struct {
+	Disabled      bool    `json:"disabled"`      // 地震情報を表示しない
+	MinIntensity  string  `json:"minIntensity"`  // この震度以上の地震のみ表示 (例: 3、5弱。省略時は 3)
+	MaxDistanceKm float64 `json:"maxDistanceKm"` // CITY_CODE の地点から震央までの距離の上限 (km、省略時は制限なし)
+	Hours         int     `json:"hours"`         // 過去何時間の地震を表示するか (省略時は 72)
+	MaxItems      int     `json:"maxItems"`      // 最大表示件数 (省略時は 3)
+}
+
+// 地震情報の表示条件のデフォルト値
+const (
+	DefaultQuakeMinIntensity = "3"
+	DefaultQuakeHours        = 72
+	DefaultQuakeMaxItems     = 3
+)
+
+// ConfigTransit は設定ファイルに書く運行情報の設定
+type ConfigTransit struct {
+	Provider string              `json:"provider"` // 取得元 (delaylist または odpt、省略時は delaylist)
+	Lines    []ConfigTransitLine `json:"lines"`    // 運行状況を表示する路線
+}
+
+// ConfigTransitLine は運行状況を表示する路線
+type ConfigTransitLine struct {
+	Name        string `json:"name"`        // 路線名 (例: 山手線)
+	Company     string `json:"company"`     // 事業者名 (例: JR東日本)。同じ名前の路線を区別する場合に指定
+	ODPTRailway string `json:"odptRailway"` // 取得元が odpt の場合の路線ID (例: JR-East.Yamanote)
+}
+
+// ConfigEvent は設定ファイルに書く日付指定の予定
+type ConfigEvent struct {
+	Date  string `json:"date"`  // 日付 (YYYY-MM-DD)
+	Title string `json:"title"` // 予定の名前
+}
+
+// ConfigGarbage は設定ファイルに書くゴミの種類ごとの収集日
+type ConfigGarbage struct {
+	Name         string   `json:"name"`         // ゴミの種類 (例: 燃えるゴミ)
+	Weekdays     []string `json:"weekdays"`     // 収集する曜日 (例: ["月", "木"])
+	Weeks        []int    `json:"weeks"`        // 月の何回目の曜日か (例: [2, 4]、最終は -1)。省略時は毎週
+	SkipHolidays bool     `json:"skipHolidays"` // 祝日・休日は収集しない
+	SkipDates    []string `json:"skipDates"`    // 収集しない日 (YYYY-MM-DD、毎年の場合は MM-DD)
+	ExtraDates   []string `json:"extraDates"`   // 規則とは別に収集する日 (YYYY-MM-DD、毎年の場合は MM-DD)
+}
+
+// calendarEvents は設定ファイルの予定をカレンダー用に変換する
+func (c *Config) calendarEvents() ([]calendar.Event, error) {
+	var events []calendar.Event
+	for _, event := range c.Events {
+		date, err := time.Parse("2006-01-02", event.Date)
+		if err != nil {
+			return nil, fmt.Errorf("予定の日付が不正です: %q (YYYY-MM-DD形式で指定してください)", event.Date)
+		}
+		events = append(events, calendar.Event{Date: date, Title: event.Title})
+	}
+	return events, nil
+}
+
+// garbageSchedules は設定ファイルのゴミ出しの収集日を判定用の規則に変換する
+func (c *Config) garbageSchedules() ([]garbage.Schedule, error) {
+	var schedules []garbage.Schedule
+	for _, item := range c.Garbage {
+		schedule := garbage.Schedule{
+			Name:         item.Name,
+			Weeks:        item.Weeks,
+			SkipHolidays: item.SkipHolidays,
+		}
+		for _, value := range item.Weekdays {
+			weekday, err := garbage.ParseWeekday(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", item.Name, err)
+			}
+			schedule.Weekdays = append(schedule.Weekdays, weekday)
+		}
+		for _, value := range item.SkipDates {
+			date, err := garbage.ParseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", item.Name, err)
+			}
+			schedule.SkipDates = append(schedule.SkipDates, date)
+		}
+		for _, value := range item.ExtraDates {
+			date, err := garbage.ParseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", item.Name, err)
+			}
+			schedule.ExtraDates = append(schedule.ExtraDates, date)
+		}
+		if err := schedule.Validate(); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+// transitLines は設定ファイルの路線を運行情報用に変換する
+func (c *Config) transitLines() ([]transit.Line, error) {
+	var lines []transit.Line
+	for _, line := range c.Transit.Lines {
+		if line.Name == "" {
+			return nil, fmt.Errorf("路線名が指定されていません")
+		}
+		if c.Transit.Provider == transit.ProviderODPT && line.ODPTRailway == "" {
+			return nil, fmt.Errorf("%s: 取得元が %s の場合は odptRailway を指定してください", line.Name, transit.ProviderODPT)
+		}
+		lines = append(lines, transit.Line{Name: line.Name, Company: line.Company, ODPTRailway: line.ODPTRailway})
+	}
+	return lines, nil
+}
+
+// quakeFilter は設定ファイルの地震情報の表示条件を絞り込みの条件に変換する
+// origin は距離の基準地点
+func (c *Config) quakeFilter(origin city.Area) (quake.Filter, error) {
+	settings := c.Quake
+	if settings.MinIntensity == "" {
+		settings.MinIntensity = DefaultQuakeMinIntensity
+	}
+	if settings.Hours == 0 {
+		settings.Hours = DefaultQuakeHours
+	}
+	if settings.MaxItems == 0 {
+		settings.MaxItems = DefaultQuakeMaxItems
+	}
+
+	minIntensity, err := quake.ParseIntensity(settings.MinIntensity)
+	if err != nil {
+		return quake.Filter{}, err
+	}
+	if settings.Hours < 0 || settings.MaxItems < 0 || settings.MaxDistanceKm < 0 {
+		return quake.Filter{}, fmt.Errorf("hours・maxItems・maxDistanceKm は0以上で指定してください")
+	}
+
+	return quake.Filter{
+		MinIntensity:  minIntensity,
+		MaxDistanceKm: settings.MaxDistanceKm,
+		Origin:        origin,
+		Period:        time.Duration(settings.Hours) * time.Hour,
+		MaxItems:      settings.MaxItems,
+	}, nil
+}
+
+// typhoonApproachKm は台風の接近を強調する距離 (km) を返す
+func (c *Config) typhoonApproachKm() (float64, error) {
+	if c.Typhoon.ApproachKm < 0 {
+		return 0, fmt.Errorf("approachKm は0以上で指定してください")
+	}
+	if c.Typhoon.ApproachKm == 0 {
+		return DefaultTyphoonApproachKm, nil
+	}
+	return c.Typhoon.ApproachKm, nil
+}
+
+// wbgtStation は暑さ指数の地点を返す
+// 地点番号の指定がない場合は origin に最も近い観測所を使い、MaxStationDistanceKm より遠い場合は false を返す
+func (c *Config) wbgtStation(origin city.Area) (amedas.Station, bool, error) {
+	return amedasStation(c.WBGT.Point, origin, MaxStationDistanceKm)
+}
+
+// observationStation は現在の観測値を表示する観測所を返す
+// 観測所番号の指定がない場合は origin に最も近い観測所を使い、MaxStationDistanceKm より遠い場合は false を返す
+// 同梱の一覧は主な観測所だけのため、一覧にない5桁の観測所番号は観測所名の代わりに番号を表示して使う
+func (c *Config) observationStation(origin city.Area) (amedas.Station, bool, error) {
+	code := c.Observation.Station
+	if _, ok := amedas.Lookup(code); code != "" && !ok {
+		if len(code) != 5 || strings.Trim(code, "0123456789") != "" {
+			return amedas.Station{}, false, fmt.Errorf("観測所番号は5桁の数字で指定してください: %q", code)
+		}
+		return amedas.Station{Code: code, Name: code}, true, nil
+	}
+	return amedasStation(code, origin, MaxStationDistanceKm)
+}
+
+// amedasStation は観測所番号の観測所を返す (番号が空の場合は origin に最も近い観測所)
+// 最も近い観測所が maxDistanceKm より遠い場合は false を返す (0 の場合は制限なし)
+func amedasStation(code string, origin city.Area, maxDistanceKm float64) (amedas.Station, bool, error) {
+	if code == "" {
+		station, distance := amedas.Nearest(origin.Latitude, origin.Longitude)
+		if maxDistanceKm > 0 && distance > maxDistanceKm {
+			return amedas.Station{}, false, nil
+		}
+		return station, true, nil
+	}
+	station, ok := amedas.Lookup(code)
+	if !ok {
+		return amedas.Station{}, false, fmt.Errorf("地点番号が見つかりません: %s", code)
+	}
+	return station, true, nil
+}
+
+// wbgtMonths は暑さ指数を表示する月を返す
+func (c *Config) wbgtMonths() ([]time.Month, error) {
+	return parseMonths(c.WBGT.Months, DefaultWBGTMonths)
+}
+
+// healthSource は健康に関する指標の取得元と表示する月
+type healthSource struct {
+	Provider health.Provider
+	Months   []time.Month
+}
+
+// healthSources は設定ファイルの健康に関する指標の設定を取得元に変換する
+// 紫外線指数は origin の緯度・経度で取得し、花粉は cityCode を指定した場合のみ表示する
+func (c *Config) healthSources(origin city.Area) ([]healthSource, error) {
+	var sources []healthSource
+
+	if uv := c.Health.UV; !uv.Disabled {
+		provider, err := health.NewUVProvider(uv.Provider, origin.Latitude, origin.Longitude)
+		if err != nil {
+			return nil, err
+		}
+		months, err := parseMonths(uv.Months, DefaultUVMonths)
+		if err != nil {
+			return nil, fmt.Errorf("uv: %w", err)
+		}
+		sources = append(sources, healthSource{Provider: provider, Months: months})
+	}
+
+	if pollen := c.Health.Pollen; !pollen.Disabled && (pollen.CityCode != "" || pollen.Provider != "") {
+		provider, err := health.NewPollenProvider(pollen.Provider, pollen.CityCode)
+		if err != nil {
+			return nil, err
+		}
+		months, err := parseMonths(pollen.Months, DefaultPollenMonths)
+		if err != nil {
+			return nil, fmt.Errorf("pollen: %w", err)
+		}
+		sources = append(sources, healthSource{Provider: provider, Months: months})
+	}
+
+	return sources, nil
+}
+
+// pressureSettings は設定ファイルの気圧の設定を取得元と急な低下の判定条件に変換する
+// 気圧・湿度は origin の緯度・経度で取得する
+func (c *Config) pressureSettings(origin city.Area) (pressure.Provider, pressure.Rule, error) {
+	settings := c.Pressure
+	if settings.DropHPa < 0 || settings.DropHours < 0 {
+		return nil, pressure.Rule{}, fmt.Errorf("dropHPa・dropHours は0以上で指定してください")
+	}
+	if settings.DropHPa == 0 {
+		settings.DropHPa = DefaultPressureDropHPa
+	}
+	if settings.DropHours == 0 {
+		settings.DropHours = DefaultPressureDropHours
+	}
+	if time.Duration(settings.DropHours)*time.Hour > PressureHorizon {
+		return nil, pressure.Rule{}, fmt.Errorf("dropHours は%d以下で指定してください", int(PressureHorizon.Hours()))
+	}
+
+	provider, err := pressure.NewProvider(settings.Provider, origin.Latitude, origin.Longitude)
+	if err != nil {
+		return nil, pressure.Rule{}, err
+	}
+	return provider, pressure.Rule{
+		DropHPa: settings.DropHPa,
+		Window:  time.Duration(settings.DropHours) * time.Hour,
+		Horizon: PressureHorizon,
+	}, nil
+}
+
+// weatherProviders は設定ファイルの天気予報の取得元を変換する
+// tsukumijima は cityCode の予報を、open-meteo は origin の緯度・経度の予報を取得する
+func (c *Config) weatherProviders(cityCode string, origin city.Area) ([]forecast.Provider, error) {
+	names := c.Weather.Providers
+	if len(names) == 0 {
+		names = []string{forecast.ProviderTsukumijima}
+	}
+
+	var providers []forecast.Provider
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			return nil, fmt.Errorf("取得元が重複しています: %q", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case forecast.ProviderTsukumijima:
+			providers = append(providers, tsukumijimaProvider{cityCode: cityCode})
+		case forecast.ProviderOpenMeteo:
+			providers = append(providers, forecast.OpenMeteoProvider{Latitude: origin.Latitude, Longitude: origin.Longitude, Location: origin.Name})
+		default:
+			return nil, fmt.Errorf("取得元が不正です: %q (%s, %s のいずれかを指定してください)", name, forecast.ProviderTsukumijima, forecast.ProviderOpenMeteo)
+		}
+	}
+	return providers, nil
+}
+
+// historySettings は設定ファイルの天気の記録の設定を記録のファイルと日数に変換する
+// ファイルの指定がない場合は cacheDir に保存する (GitHub Actions ではキャッシュとして実行間で引き継ぐ)
+func (c *Config) historySettings(cacheDir string) (history.Store, int, error) {
+	days := c.History.Days
+	if days < 0 || days > MaxHistoryDays {
+		return history.Store{}, 0, fmt.Errorf("days は0〜%dで指定してください", MaxHistoryDays)
+	}
+	if days == 0 {
+		days = DefaultHistoryDays
+	}
+
+	path := c.History.Path
+	if path == "" {
+		path = filepath.Join(cacheDir, DefaultHistoryFile)
+	}
+	return history.Store{Path: path}, days, nil
+}
+
+// parseMonths は設定ファイルの月 (1〜12) を変換する (空の場合は defaults を使う)
+func parseMonths(values []int, defaults []int) ([]time.Month, error) {
+	if len(values) == 0 {
+		values = defaults
+	}
+	months := make([]time.Month, 0, len(values))
+	for _, value := range values {
+		if value < 1 || value > 12 {
+			return nil, fmt.Errorf("months は1〜12で指定してください: %d", value)
+		}
+		months = append(months, time.Month(value))
+	}
+	return months, nil
+}
+
+// inSeason は now が表示する月に含まれるかどうかを返す
+func inSeason(months []time.Month, now time.Time) bool {
+	for _, month := range months {
+		if month == now.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+// loadConfig は設定ファイルを読み込む
+// ファイルが存在しない場合は空の設定を返す
+func loadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("設定ファイルのパースに失敗しました: %w", err)
+	}
+
+	return &config, nil
+}
+
+// validateCityCodes は設定された都市コードが一次細分区域の一覧に存在するかチェックする
+// secondary はカンマ区切りの都市コード
+func validateCityCodes(primary string, secondary string) error {
+	codes := []string{primary}
+	for _, code := range strings.Split(secondary, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+
+	for _, code := range codes {
+		if _, ok := city.Lookup(code); ok {
+			continue
+		}
+
+		// 地点名が指定された場合は候補を提示する
+		if candidates := city.Search(code); len(candidates) > 0 {
+			var suggestions []string
+			for i, area := range candidates {
+				if i >= 3 {
+					break
+				}
+				suggestions = append(suggestions, fmt.Sprintf("%s (%s)", area.Code, area.Name))
+			}
+			return fmt.Errorf("都市コードが不正です: %s (候補: %s)", code, strings.Join(suggestions, ", "))
+		}
+		return fmt.Errorf("都市コードが不正です: %s (`go run . cities search <地点名>` で検索できます)", code)
+	}
+
+	return nil
+}
@@ -0,0 +1,550 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/city"
+	"kindle-tenki-dashboard/internal/forecast"
+	"kindle-tenki-dashboard/internal/pressure"
+	"kindle-tenki-dashboard/internal/quake"
+)
+
+// loadConfig のテスト
+func TestLoadConfig(t *testing.T) {
+	t.Run("ファイルが存在しない場合は空の設定", func(t *testing.T) {
+		config, err := loadConfig(filepath.Join(t.TempDir(), "missing.json"))
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if len(config.Devices) != 0 {
+			t.Errorf("Devices: 期待=0件, 実際=%d件", len(config.Devices))
+		}
+	})
+
+	t.Run("設定ファイルの読み込み", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		content := `{"devices": [{"name": "oasis", "width": 1264, "height": 1680, "dpi": 300}]}`
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("設定ファイルの作成に失敗: %v", err)
+		}
+
+		config, err := loadConfig(path)
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if len(config.Devices) != 1 || config.Devices[0].Name != "oasis" {
+			t.Errorf("Devices が正しく読み込まれていません: %+v", config.Devices)
+		}
+	})
+
+	t.Run("不正なJSONはエラー", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		if err := os.WriteFile(path, []byte(`{"devices": [`), 0644); err != nil {
+			t.Fatalf("設定ファイルの作成に失敗: %v", err)
+		}
+
+		if _, err := loadConfig(path); err == nil {
+			t.Error("期待: エラー, 実際: nil")
+		}
+	})
+
+	t.Run("サンプル設定ファイルが読み込める", func(t *testing.T) {
+		config, err := loadConfig("config.example.json")
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if _, err := resolveDeviceProfiles(config, ""); err != nil {
+			t.Errorf("サンプル設定の端末プロファイルが不正です: %v", err)
+		}
+		if _, err := config.calendarEvents(); err != nil {
+			t.Errorf("サンプル設定の予定が不正です: %v", err)
+		}
+		if _, err := config.garbageSchedules(); err != nil {
+			t.Errorf("サンプル設定のゴミ出しの収集日が不正です: %v", err)
+		}
+		if _, err := config.transitLines(); err != nil {
+			t.Errorf("サンプル設定の運行情報が不正です: %v", err)
+		}
+		if _, err := config.quakeFilter(city.Area{}); err != nil {
+			t.Errorf("サンプル設定の地震情報が不正です: %v", err)
+		}
+		if _, err := config.typhoonApproachKm(); err != nil {
+			t.Errorf("サンプル設定の台風情報が不正です: %v", err)
+		}
+		if _, _, err := config.wbgtStation(city.Area{}); err != nil {
+			t.Errorf("サンプル設定の暑さ指数の地点が不正です: %v", err)
+		}
+		if _, err := config.wbgtMonths(); err != nil {
+			t.Errorf("サンプル設定の暑さ指数を表示する月が不正です: %v", err)
+		}
+		if sources, err := config.healthSources(city.Area{}); err != nil || len(sources) != 2 {
+			t.Errorf("サンプル設定の紫外線・花粉が不正です: %d件 (%v)", len(sources), err)
+		}
+		if _, _, err := config.pressureSettings(city.Area{}); err != nil {
+			t.Errorf("サンプル設定の気圧が不正です: %v", err)
+		}
+		if _, _, err := config.historySettings(DefaultCacheDir); err != nil {
+			t.Errorf("サンプル設定の天気の記録が不正です: %v", err)
+		}
+		if providers, err := config.weatherProviders("130010", city.Area{}); err != nil || len(providers) != 2 {
+			t.Errorf("サンプル設定の天気予報の取得元が不正です: %d件 (%v)", len(providers), err)
+		}
+		if station, _, err := config.observationStation(city.Area{}); err != nil || station.Name != "東京" {
+			t.Errorf("サンプル設定の観測所が不正です: %s (%v)", station.Name, err)
+		}
+	})
+}
+
+// calendarEvents のテスト
+func TestCalendarEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   []ConfigEvent
+		expected int
+		hasError bool
+	}{
+		{name: "予定なし", events: nil, expected: 0},
+		{name: "正常な日付", events: []ConfigEvent{{Date: "2026-11-14", Title: "清掃"}, {Date: "2026-12-25", Title: "忘年会"}}, expected: 2},
+		{name: "不正な日付", events: []ConfigEvent{{Date: "2026/11/14", Title: "清掃"}}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Events: tt.events}
+			events, err := config.calendarEvents()
+
+			if tt.hasError {
+				if err == nil {
+					t.Error("期待: エラー, 実際: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("期待: エラーなし, 実際: %v", err)
+			}
+			if len(events) != tt.expected {
+				t.Errorf("期待: %d件, 実際: %d件", tt.expected, len(events))
+			}
+		})
+	}
+}
+
+// garbageSchedules のテスト
+func TestGarbageSchedules(t *testing.T) {
+	tests := []struct {
+		name     string
+		garbage  []ConfigGarbage
+		expected int
+		hasError bool
+	}{
+		{name: "設定なし", garbage: nil, expected: 0},
+		{name: "正常な設定", garbage: []ConfigGarbage{
+			{Name: "燃えるゴミ", Weekdays: []string{"月", "木"}},
+			{Name: "資源ゴミ", Weekdays: []string{"水曜日"}, Weeks: []int{2, 4}, SkipHolidays: true, SkipDates: []string{"12-31", "01-01"}, ExtraDates: []string{"2026-12-30"}},
+		}, expected: 2},
+		{name: "不正な曜日", garbage: []ConfigGarbage{{Name: "燃えるゴミ", Weekdays: []string{"Mon"}}}, hasError: true},
+		{name: "不正な休止日", garbage: []ConfigGarbage{{Name: "燃えるゴミ", Weekdays: []string{"月"}, SkipDates: []string{"12/31"}}}, hasError: true},
+		{name: "曜日なし", garbage: []ConfigGarbage{{Name: "燃えるゴミ"}}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Garbage: tt.garbage}
+			schedules, err := config.garbageSchedules()
+
+			if tt.hasError {
+				if err == nil {
+					t.Error("期待: エラー, 実際: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("期待: エラーなし, 実際: %v", err)
+			}
+			if len(schedules) != tt.expected {
+				t.Errorf("期待: %d件, 実際: %d件", tt.expected, len(schedules))
+			}
+		})
+	}
+}
+
+// transitLines のテスト
+func TestTransitLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		transit  ConfigTransit
+		expected int
+		hasError bool
+	}{
+		{name: "設定なし", transit: ConfigTransit{}, expected: 0},
+		{name: "正常な設定", transit: ConfigTransit{Lines: []ConfigTransitLine{{Name: "山手線"}, {Name: "本線", Company: "京急電鉄"}}}, expected: 2},
+		{name: "路線名なし", transit: ConfigTransit{Lines: []ConfigTransitLine{{Company: "JR東日本"}}}, hasError: true},
+		{name: "odptで路線IDなし", transit: ConfigTransit{Provider: "odpt", Lines: []ConfigTransitLine{{Name: "山手線"}}}, hasError: true},
+		{name: "odptで路線IDあり", transit: ConfigTransit{Provider: "odpt", Lines: []ConfigTransitLine{{Name: "山手線", ODPTRailway: "JR-East.Yamanote"}}}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Transit: tt.transit}
+			lines, err := config.transitLines()
+
+			if tt.hasError {
+				if err == nil {
+					t.Error("期待: エラー, 実際: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("期待: エラーなし, 実際: %v", err)
+			}
+			if len(lines) != tt.expected {
+				t.Errorf("期待: %d件, 実際: %d件", tt.expected, len(lines))
+			}
+		})
+	}
+}
+
+// quakeFilter のテスト
+func TestQuakeFilter(t *testing.T) {
+	t.Run("省略時はデフォルト値", func(t *testing.T) {
+		filter, err := (&Config{}).quakeFilter(city.Area{})
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if filter.MinIntensity != quake.Intensity3 || filter.MaxItems != DefaultQuakeMaxItems || filter.MaxDistanceKm != 0 {
+			t.Errorf("デフォルト値が不正です: %+v", filter)
+		}
+		if filter.Period != DefaultQuakeHours*time.Hour {
+			t.Errorf("期間: 期待=%v, 実際=%v", DefaultQuakeHours*time.Hour, filter.Period)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		quake    ConfigQuake
+		hasError bool
+	}{
+		{name: "震度5弱以上", quake: ConfigQuake{MinIntensity: "5弱", MaxDistanceKm: 300, Hours: 24}},
+		{name: "不正な震度", quake: ConfigQuake{MinIntensity: "10"}, hasError: true},
+		{name: "負の時間", quake: ConfigQuake{Hours: -1}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (&Config{Quake: tt.quake}).quakeFilter(city.Area{})
+			if (err != nil) != tt.hasError {
+				t.Errorf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+		})
+	}
+}
+
+// typhoonApproachKm のテスト
+func TestTyphoonApproachKm(t *testing.T) {
+	tests := []struct {
+		name       string
+		approachKm float64
+		expected   float64
+		hasError   bool
+	}{
+		{name: "省略時はデフォルト値", approachKm: 0, expected: DefaultTyphoonApproachKm},
+		{name: "指定した距離", approachKm: 500, expected: 500},
+		{name: "負の距離", approachKm: -1, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := (&Config{Typhoon: ConfigTyphoon{ApproachKm: tt.approachKm}}).typhoonApproachKm()
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if actual != tt.expected {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// wbgtStation のテスト
+func TestWBGTStation(t *testing.T) {
+	osaka, _ := city.Lookup("270000")
+	chichijima, _ := city.Lookup("130040")
+
+	tests := []struct {
+		name     string
+		point    string
+		origin   city.Area
+		expected string
+		hasError bool
+	}{
+		{name: "省略時は最寄りの観測所", point: "", origin: osaka, expected: "大阪"},
+		{name: "最寄りの観測所が遠い場合は表示しない", point: "", origin: chichijima, expected: ""},
+		{name: "地点番号を指定", point: "43056", origin: osaka, expected: "熊谷"},
+		{name: "地点番号を指定した場合は遠くても使う", point: "44263", origin: chichijima, expected: "八丈島"},
+		{name: "存在しない地点番号", point: "99999", origin: osaka, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			station, ok, err := (&Config{WBGT: ConfigWBGT{Point: tt.point}}).wbgtStation(tt.origin)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if ok != (tt.expected != "") {
+				t.Errorf("観測所の有無: 期待=%v, 実際=%v", tt.expected != "", ok)
+			}
+			if station.Name != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, station.Name)
+			}
+		})
+	}
+}
+
+// observationStation のテスト
+func TestObservationStation(t *testing.T) {
+	sapporo, _ := city.Lookup("016010")
+	chichijima, _ := city.Lookup("130040")
+	minamidaito, _ := city.Lookup("472000")
+	naze, _ := city.Lookup("460040")
+
+	tests := []struct {
+		name     string
+		station  string
+		origin   city.Area
+		expected string
+		hasError bool
+	}{
+		{name: "省略時は最寄りの観測所", station: "", origin: sapporo, expected: "札幌"},
+		{name: "最寄りの観測所が遠い場合は観測値なし (父島)", station: "", origin: chichijima, expected: ""},
+		{name: "最寄りの観測所が遠い場合は観測値なし (南大東)", station: "", origin: minamidaito, expected: ""},
+		{name: "最寄りの観測所が遠い場合は観測値なし (名瀬)", station: "", origin: naze, expected: ""},
+		{name: "観測所番号を指定", station: "44132", origin: sapporo, expected: "東京"},
+		{name: "同梱の一覧にない観測所番号", station: "11001", origin: sapporo, expected: "11001"},
+		{name: "5桁でない観測所番号", station: "4413", origin: sapporo, hasError: true},
+		{name: "数字でない観測所番号", station: "tokyo", origin: sapporo, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			station, ok, err := (&Config{Observation: ConfigObservation{Station: tt.station}}).observationStation(tt.origin)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if ok != (tt.expected != "") {
+				t.Errorf("観測所の有無: 期待=%v, 実際=%v", tt.expected != "", ok)
+			}
+			if station.Name != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, station.Name)
+			}
+		})
+	}
+}
+
+// wbgtMonths のテスト
+func TestWBGTMonths(t *testing.T) {
+	months, err := (&Config{}).wbgtMonths()
+	if err != nil || len(months) != len(DefaultWBGTMonths) || months[0] != time.May {
+		t.Errorf("デフォルト値が不正です: %v (%v)", months, err)
+	}
+
+	months, err = (&Config{WBGT: ConfigWBGT{Months: []int{7, 8}}}).wbgtMonths()
+	if err != nil || len(months) != 2 || months[1] != time.August {
+		t.Errorf("期待: [July August], 実際: %v (%v)", months, err)
+	}
+
+	if _, err := (&Config{WBGT: ConfigWBGT{Months: []int{13}}}).wbgtMonths(); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// inSeason のテスト
+func TestInSeason(t *testing.T) {
+	months := []time.Month{time.May, time.June, time.July, time.August, time.September}
+
+	tests := []struct {
+		month    time.Month
+		expected bool
+	}{
+		{time.April, false},
+		{time.May, true},
+		{time.September, true},
+		{time.October, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.month.String(), func(t *testing.T) {
+			now := time.Date(2026, tt.month, 15, 12, 0, 0, 0, time.UTC)
+			if actual := inSeason(months, now); actual != tt.expected {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// healthSources のテスト
+func TestHealthSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		health   ConfigHealth
+		expected []string
+		hasError bool
+	}{
+		{name: "省略時は紫外線のみ", expected: []string{"紫外線"}},
+		{
+			name:     "花粉の市区町村コードを指定",
+			health:   ConfigHealth{Pollen: ConfigHealthItem{CityCode: "13101"}},
+			expected: []string{"紫外線", "花粉"},
+		},
+		{
+			name:     "紫外線を表示しない",
+			health:   ConfigHealth{UV: ConfigHealthItem{Disabled: true}, Pollen: ConfigHealthItem{CityCode: "13101"}},
+			expected: []string{"花粉"},
+		},
+		{name: "花粉の市区町村コードなし", health: ConfigHealth{Pollen: ConfigHealthItem{Provider: "weathernews"}}, hasError: true},
+		{name: "不正な取得元", health: ConfigHealth{UV: ConfigHealthItem{Provider: "unknown"}}, hasError: true},
+		{name: "不正な月", health: ConfigHealth{UV: ConfigHealthItem{Months: []int{0}}}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources, err := (&Config{Health: tt.health}).healthSources(city.Area{})
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			var actual []string
+			for _, source := range sources {
+				actual = append(actual, source.Provider.Kind().Label())
+			}
+			if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+
+	// 花粉は省略時 2〜5月に表示する
+	sources, _ := (&Config{Health: ConfigHealth{Pollen: ConfigHealthItem{CityCode: "13101"}}}).healthSources(city.Area{})
+	if len(sources) != 2 || len(sources[1].Months) != len(DefaultPollenMonths) || sources[1].Months[0] != time.February {
+		t.Errorf("花粉を表示する月のデフォルト値が不正です: %+v", sources)
+	}
+}
+
+// pressureSettings のテスト
+func TestPressureSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		pressure ConfigPressure
+		expected pressure.Rule
+		hasError bool
+	}{
+		{
+			name:     "省略時は6時間で6hPa",
+			expected: pressure.Rule{DropHPa: 6, Window: 6 * time.Hour, Horizon: 24 * time.Hour},
+		},
+		{
+			name:     "判定条件を指定",
+			pressure: ConfigPressure{DropHPa: 4, DropHours: 3},
+			expected: pressure.Rule{DropHPa: 4, Window: 3 * time.Hour, Horizon: 24 * time.Hour},
+		},
+		{name: "負の低下幅", pressure: ConfigPressure{DropHPa: -1}, hasError: true},
+		{name: "24時間を超える幅", pressure: ConfigPressure{DropHours: 25}, hasError: true},
+		{name: "不正な取得元", pressure: ConfigPressure{Provider: "unknown"}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, rule, err := (&Config{Pressure: tt.pressure}).pressureSettings(city.Area{})
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if tt.hasError {
+				return
+			}
+			if provider.Name() != pressure.ProviderOpenMeteo {
+				t.Errorf("取得元: 期待=%s, 実際=%s", pressure.ProviderOpenMeteo, provider.Name())
+			}
+			if rule != tt.expected {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, rule)
+			}
+		})
+	}
+}
+
+// historySettings のテスト
+func TestHistorySettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		history  ConfigHistory
+		path     string
+		days     int
+		hasError bool
+	}{
+		{name: "省略時はキャッシュの保存先に7日", path: filepath.Join(".cache", "history.jsonl"), days: 7},
+		{name: "ファイルと日数を指定", history: ConfigHistory{Path: "data/history.jsonl", Days: 14}, path: "data/history.jsonl", days: 14},
+		{name: "負の日数", history: ConfigHistory{Days: -1}, hasError: true},
+		{name: "上限を超える日数", history: ConfigHistory{Days: 32}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, days, err := (&Config{History: tt.history}).historySettings(".cache")
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if tt.hasError {
+				return
+			}
+			if store.Path != tt.path || days != tt.days {
+				t.Errorf("期待: %s (%d日), 実際: %s (%d日)", tt.path, tt.days, store.Path, days)
+			}
+		})
+	}
+}
+
+// weatherProviders のテスト
+func TestWeatherProviders(t *testing.T) {
+	tokyo := city.Area{Code: "130010", Name: "東京", Latitude: 35.6895, Longitude: 139.6917}
+	tests := []struct {
+		name      string
+		providers []string
+		expected  []string
+		hasError  bool
+	}{
+		{name: "省略時は tsukumijima のみ", expected: []string{"tsukumijima"}},
+		{name: "複数の取得元を優先する順に指定", providers: []string{"open-meteo", "tsukumijima"}, expected: []string{"open-meteo", "tsukumijima"}},
+		{name: "重複した取得元", providers: []string{"tsukumijima", "tsukumijima"}, hasError: true},
+		{name: "不正な取得元", providers: []string{"unknown"}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers, err := (&Config{Weather: ConfigWeather{Providers: tt.providers}}).weatherProviders("130010", tokyo)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if tt.hasError {
+				return
+			}
+			var names []string
+			for _, provider := range providers {
+				names = append(names, provider.Name())
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, names)
+			}
+		})
+	}
+
+	t.Run("取得元ごとの地点", func(t *testing.T) {
+		providers, _ := (&Config{Weather: ConfigWeather{Providers: []string{"tsukumijima", "open-meteo"}}}).weatherProviders("130010", tokyo)
+		if url := providers[0].URL(); !strings.HasSuffix(url, "/130010") {
+			t.Errorf("tsukumijima の URL に都市コードがありません: %s", url)
+		}
+		if openMeteo, ok := providers[1].(forecast.OpenMeteoProvider); !ok || openMeteo.Location != "東京" || openMeteo.Latitude != tokyo.Latitude {
+			t.Errorf("open-meteo の地点が不正です: %+v", providers[1])
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"math"
+	"time"
+
+	"kindle-tenki-dashboard/internal/chart"
+	"kindle-tenki-dashboard/internal/city"
+	"kindle-tenki-dashboard/internal/sun"
+)
+
+// Daylight は1日の日の出・日の入りの時刻
+type Daylight struct {
+	Sunrise time.Time `json:"sunrise"`
+	Sunset  time.Time `json:"sunset"`
+}
+
+// applyDaylight は地点の日の出・日の入りから時間別予報の各時刻が夜かどうかを決め、
+// 夜の時刻の絵文字を月や雲の絵文字にする (SVGアイコンはテンプレートで夜の版を選ぶ)
+func applyDaylight(w *WeatherData, origin city.Area) {
+	w.Daylight = nil
+	var lastDate time.Time
+	for i := range w.HourlyForecast {
+		hf := &w.HourlyForecast[i]
+
+		// 予報の期間の日ごとの日の出・日の入り (グラフの網掛けに使う)
+		date := time.Date(hf.At.Year(), hf.At.Month(), hf.At.Day(), 0, 0, 0, 0, hf.At.Location())
+		if !date.Equal(lastDate) {
+			if sunrise, sunset, ok := sun.Times(date, origin.Latitude, origin.Longitude); ok {
+				w.Daylight = append(w.Daylight, Daylight{Sunrise: sunrise, Sunset: sunset})
+			}
+			lastDate = date
+		}
+
+		hf.IsNight = !sun.IsDaytime(hf.At, origin.Latitude, origin.Longitude)
+		if hf.IsNight {
+			hf.WeatherIcon = getNightWeatherIcon(hf.Desc)
+		}
+	}
+}
+
+// nightBands は時間別予報のグラフで夜 (日の入りから日の出まで) の区間を返す
+// 時刻は隣り合う予報の時刻の間を比例配分してグラフ上の位置にする
+func nightBands(hourlyForecast []HourlyForecast, daylight []Daylight) []chart.Band {
+	if len(hourlyForecast) == 0 || len(daylight) == 0 {
+		return nil
+	}
+
+	// 夜の区間: 最初の日の出より前、日の入りから翌日の日の出まで、最後の日の入りより後
+	var bands []chart.Band
+	from := 0.0
+	for _, day := range daylight {
+		if to := hourPosition(hourlyForecast, day.Sunrise); from < to {
+			bands = append(bands, chart.Band{From: from, To: to})
+		}
+		from = hourPosition(hourlyForecast, day.Sunset)
+	}
+	if to := float64(len(hourlyForecast)); from < to {
+		bands = append(bands, chart.Band{From: from, To: to})
+	}
+	return bands
+}
+
+// hourPosition は時刻 t の時間別予報のグラフ上の位置 (chart.Band の単位) を返す
+// i番目の予報の時刻が i+0.5 (点の中心) になり、グラフの範囲外は両端に切り詰める
+func hourPosition(hourlyForecast []HourlyForecast, t time.Time) float64 {
+	count := len(hourlyForecast)
+	if count < 2 {
+		if t.Before(hourlyForecast[0].At) {
+			return 0
+		}
+		return float64(count)
+	}
+
+	// t を含む予報の間 (最初の予報より前・最後の予報より後は、最初・最後の間を延長する)
+	i := 1
+	for i < count-1 && t.After(hourlyForecast[i].At) {
+		i++
+	}
+	previous, next := hourlyForecast[i-1].At, hourlyForecast[i].At
+	position := float64(i-1) + 0.5 + float64(t.Sub(previous))/float64(next.Sub(previous))
+	return math.Max(0, math.Min(position, float64(count)))
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/chart"
+	"kindle-tenki-dashboard/internal/city"
+)
+
+// applyDaylight のテスト
+func TestApplyDaylight(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	tokyo, ok := city.Lookup("130010")
+	if !ok {
+		t.Fatal("東京の都市コードが見つかりません")
+	}
+
+	// 2026年10月18日の東京の日の入りは17時頃、19日の日の出は6時前
+	hours := []int{15, 18, 21, 24, 27, 30, 33}
+	expectedNight := []bool{false, true, true, true, true, false, false}
+	data := &WeatherData{}
+	for _, hour := range hours {
+		data.HourlyForecast = append(data.HourlyForecast, HourlyForecast{
+			At:          time.Date(2026, 10, 18, hour, 0, 0, 0, jst),
+			Desc:        "晴れ",
+			WeatherIcon: getWeatherIcon("晴れ"),
+			WeatherCode: getWeatherCode("晴れ"),
+		})
+	}
+
+	applyDaylight(data, tokyo)
+
+	for i, expected := range expectedNight {
+		hf := data.HourlyForecast[i]
+		if hf.IsNight != expected {
+			t.Errorf("%s: 期待: %v, 実際: %v", hf.At.Format("01/02 15:04"), expected, hf.IsNight)
+		}
+		expectedIcon := "☀️"
+		if expected {
+			expectedIcon = "🌙"
+		}
+		if hf.WeatherIcon != expectedIcon {
+			t.Errorf("%s のアイコン: 期待: %s, 実際: %s", hf.At.Format("01/02 15:04"), expectedIcon, hf.WeatherIcon)
+		}
+	}
+
+	if len(data.Daylight) != 2 {
+		t.Fatalf("日の出・日の入りの日数: 期待: 2, 実際: %d", len(data.Daylight))
+	}
+	if sunset := data.Daylight[0].Sunset; sunset.Hour() != 17 {
+		t.Errorf("10月18日の日の入り: 期待: 17時台, 実際: %s", sunset.Format("15:04"))
+	}
+	if sunrise := data.Daylight[1].Sunrise; sunrise.Hour() != 5 {
+		t.Errorf("10月19日の日の出: 期待: 5時台, 実際: %s", sunrise.Format("15:04"))
+	}
+}
+
+// nightBands のテスト
+func TestNightBands(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, jst)
+	}
+
+	// 12時から3時間ごとの8件 (12, 15, 18, 21, 0, 3, 6, 9時)
+	var hourly []HourlyForecast
+	for i := 0; i < 8; i++ {
+		hourly = append(hourly, HourlyForecast{At: at(18, 12+i*3, 0)})
+	}
+
+	tests := []struct {
+		name     string
+		daylight []Daylight
+		expected []chart.Band
+	}{
+		{
+			name: "予報の時刻ちょうどの日の入り・日の出",
+			daylight: []Daylight{
+				{Sunrise: at(18, 6, 0), Sunset: at(18, 18, 0)},
+				{Sunrise: at(19, 6, 0), Sunset: at(19, 18, 0)},
+			},
+			expected: []chart.Band{{From: 2.5, To: 6.5}},
+		},
+		{
+			name: "予報の時刻の間の日の入り・日の出は比例配分する",
+			daylight: []Daylight{
+				{Sunrise: at(18, 5, 45), Sunset: at(18, 16, 30)},
+				{Sunrise: at(19, 7, 30), Sunset: at(19, 16, 30)},
+			},
+			expected: []chart.Band{{From: 2, To: 7}},
+		},
+		{
+			name: "最後の日の入りより後はグラフの右端まで",
+			daylight: []Daylight{
+				{Sunrise: at(18, 6, 0), Sunset: at(18, 18, 0)},
+				{Sunrise: at(19, 6, 0), Sunset: at(19, 7, 30)},
+			},
+			expected: []chart.Band{{From: 2.5, To: 6.5}, {From: 7, To: 8}},
+		},
+		{
+			name:     "日の出・日の入りがない場合",
+			daylight: nil,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := nightBands(hourly, tt.daylight)
+			if len(actual) != len(tt.expected) {
+				t.Fatalf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+			for i := range actual {
+				if actual[i] != tt.expected[i] {
+					t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+				}
+			}
+		})
+	}
+}
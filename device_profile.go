@@ -0,0 +1,256 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// ReferenceDPI はCSSの基準とした端末(Kindle Paperwhite 758x1024)の解像度
+const ReferenceDPI = 212
+
+// 表示セクション名
+const (
+	SectionCalendar = "calendar" // 日付・祝日
+	SectionAgenda   = "agenda"   // 今日・明日の予定
+	SectionTransit  = "transit"  // 電車の運行情報
+	SectionToday    = "today"    // 今日の天気
+	SectionChart    = "chart"    // 気温グラフ
+	SectionPressure = "pressure" // 気圧の変化
+	SectionHistory  = "history"  // 最高・最低気温の推移
+	SectionHourly   = "hourly"   // 時間別予報
+	SectionDaily    = "daily"    // 3日間の予報
+	SectionCities   = "cities"   // 各地の天気
+	SectionHealth   = "health"   // 紫外線・花粉
+	SectionMonth    = "month"    // 月間カレンダー
+	SectionQuake    = "quake"    // 地震情報
+	SectionTyphoon  = "typhoon"  // 台風情報
+	SectionNews     = "news"     // ニュース
+)
+
+// 画面の向き
+const (
+	OrientationPortrait  = "portrait"
+	OrientationLandscape = "landscape"
+)
+
+// レイアウト
+const (
+	LayoutStandard     = "standard"      // 天気を2列、ニュースを2列で表示
+	LayoutSingleColumn = "single-column" // すべてのセクションを1列で表示
+	LayoutWide         = "wide"          // 天気とニュースを左右に並べる(横向き向け)
+)
+
+// 天気アイコンの表示方法
+const (
+	IconsSVG   = "svg"   // 同梱のモノクロSVGアイコン
+	IconsEmoji = "emoji" // 絵文字 (SVGを表示できない端末向け)
+)
+
+// deviceNamePattern は出力ディレクトリ名として使える端末名
+var deviceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// DeviceProfile は端末ごとの表示設定
+type DeviceProfile struct {
+	Name        string   `json:"name"`        // 端末名(出力先 dist/devices/<name>/ に使用)
+	Label       string   `json:"label"`       // 表示名
+	Width       int      `json:"width"`       // 横の解像度(px, 縦向き基準)
+	Height      int      `json:"height"`      // 縦の解像度(px, 縦向き基準)
+	DPI         int      `json:"dpi"`         // 画面の解像度(ppi)
+	Orientation string   `json:"orientation"` // portrait / landscape
+	Layout      string   `json:"layout"`      // standard / single-column / wide
+	FontScale   float64  `json:"fontScale"`   // 文字サイズの倍率
+	Sections    []string `json:"sections"`    // 表示するセクション(空の場合はすべて表示)
+	Icons       string   `json:"icons"`       // 天気アイコンの表示方法 svg / emoji
+}
+
+// builtinDeviceProfiles は組み込みの端末プロファイルを返す
+func builtinDeviceProfiles() []DeviceProfile {
+	return []DeviceProfile{
+		{
+			Name:        "paperwhite3",
+			Label:       "Kindle Paperwhite (第7世代)",
+			Width:       1072,
+			Height:      1448,
+			DPI:         300,
+			Orientation: OrientationPortrait,
+			Layout:      LayoutStandard,
+			FontScale:   1.0,
+			Icons:       IconsSVG,
+		},
+		{
+			Name:        "basic",
+			Label:       "Kindle (第8世代)",
+			Width:       600,
+			Height:      800,
+			DPI:         167,
+			Orientation: OrientationPortrait,
+			Layout:      LayoutSingleColumn,
+			FontScale:   1.0,
+			Icons:       IconsSVG,
+			Sections:    []string{SectionCalendar, SectionAgenda, SectionTransit, SectionToday, SectionChart, SectionPressure, SectionHistory, SectionDaily, SectionCities, SectionHealth, SectionTyphoon, SectionQuake, SectionNews},
+		},
+		{
+			Name:        "touch",
+			Label:       "Kindle Touch",
+			Width:       600,
+			Height:      800,
+			DPI:         167,
+			Orientation: OrientationPortrait,
+			Layout:      LayoutSingleColumn,
+			FontScale:   1.1,
+			Icons:       IconsSVG,
+			Sections:    []string{SectionCalendar, SectionAgenda, SectionToday, SectionDaily},
+		},
+	}
+}
+
+// genericDeviceProfile は dist/index.html 用の端末を特定しないプロファイルを返す
+func genericDeviceProfile() DeviceProfile {
+	return DeviceProfile{
+		Orientation: OrientationPortrait,
+		Layout:      LayoutStandard,
+		FontScale:   1.0,
+		Icons:       IconsSVG,
+	}
+}
+
+// ShowsSection は指定されたセクションを表示するかどうかを返す
+func (d DeviceProfile) ShowsSection(name string) bool {
+	if len(d.Sections) == 0 {
+		return true
+	}
+	for _, section := range d.Sections {
+		if section == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ViewportWidth は画面の向きを考慮した横幅(px)を返す
+func (d DeviceProfile) ViewportWidth() int {
+	if d.Orientation == OrientationLandscape {
+		return d.Height
+	}
+	return d.Width
+}
+
+// ViewportHeight は画面の向きを考慮した高さ(px)を返す
+func (d DeviceProfile) ViewportHeight() int {
+	if d.Orientation == OrientationLandscape {
+		return d.Width
+	}
+	return d.Height
+}
+
+// Zoom はページ全体の拡大率を返す
+// 基準端末と同じ物理サイズで文字が表示されるようDPIで補正し、FontScaleを掛ける
+func (d DeviceProfile) Zoom() float64 {
+	zoom := d.FontScale * float64(d.DPI) / ReferenceDPI
+	return math.Round(zoom*1000) / 1000
+}
+
+// validate はプロファイルの値をチェックする
+func (d DeviceProfile) validate() error {
+	if !deviceNamePattern.MatchString(d.Name) {
+		return fmt.Errorf("端末名が不正です: %q (英小文字・数字・-・_のみ使用可能)", d.Name)
+	}
+	if d.Width <= 0 || d.Height <= 0 {
+		return fmt.Errorf("端末 %s の解像度が不正です: %dx%d", d.Name, d.Width, d.Height)
+	}
+	if d.DPI <= 0 {
+		return fmt.Errorf("端末 %s のDPIが不正です: %d", d.Name, d.DPI)
+	}
+	if d.FontScale <= 0 {
+		return fmt.Errorf("端末 %s の文字サイズ倍率が不正です: %g", d.Name, d.FontScale)
+	}
+	switch d.Orientation {
+	case OrientationPortrait, OrientationLandscape:
+	default:
+		return fmt.Errorf("端末 %s の画面の向きが不正です: %q", d.Name, d.Orientation)
+	}
+	switch d.Layout {
+	case LayoutStandard, LayoutSingleColumn, LayoutWide:
+	default:
+		return fmt.Errorf("端末 %s のレイアウトが不正です: %q", d.Name, d.Layout)
+	}
+	switch d.Icons {
+	case IconsSVG, IconsEmoji:
+	default:
+		return fmt.Errorf("端末 %s の天気アイコンの表示方法が不正です: %q", d.Name, d.Icons)
+	}
+	return nil
+}
+
+// withDefaults は省略された項目にデフォルト値を設定したプロファイルを返す
+func (d DeviceProfile) withDefaults() DeviceProfile {
+	if d.Orientation == "" {
+		d.Orientation = OrientationPortrait
+	}
+	if d.Layout == "" {
+		d.Layout = LayoutStandard
+	}
+	if d.FontScale == 0 {
+		d.FontScale = 1.0
+	}
+	if d.Icons == "" {
+		d.Icons = IconsSVG
+	}
+	if d.Label == "" {
+		d.Label = d.Name
+	}
+	return d
+}
+
+// resolveDeviceProfiles は出力対象の端末プロファイルを決定する
+// 組み込みプロファイルに設定ファイルの内容を追加・上書きし、
+// selection (カンマ区切りの端末名) が指定されていればその端末のみに絞り込む
+func resolveDeviceProfiles(config *Config, selection string) ([]DeviceProfile, error) {
+	profiles := builtinDeviceProfiles()
+
+	for _, custom := range config.Devices {
+		custom = custom.withDefaults()
+		if err := custom.validate(); err != nil {
+			return nil, err
+		}
+
+		replaced := false
+		for i := range profiles {
+			if profiles[i].Name == custom.Name {
+				profiles[i] = custom
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			profiles = append(profiles, custom)
+		}
+	}
+
+	if strings.TrimSpace(selection) == "" {
+		return profiles, nil
+	}
+
+	var selected []DeviceProfile
+	for _, name := range strings.Split(selection, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, profile := range profiles {
+			if profile.Name == name {
+				selected = append(selected, profile)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("端末プロファイルが見つかりません: %s", name)
+		}
+	}
+
+	return selected, nil
+}
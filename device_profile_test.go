@@ -0,0 +1,138 @@
+package main
+
+import (
+	"testing"
+)
+
+// resolveDeviceProfiles のテスト
+func TestResolveDeviceProfiles(t *testing.T) {
+	t.Run("設定なしの場合は組み込みプロファイルを返す", func(t *testing.T) {
+		profiles, err := resolveDeviceProfiles(&Config{}, "")
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if len(profiles) != len(builtinDeviceProfiles()) {
+			t.Errorf("プロファイル数: 期待=%d, 実際=%d", len(builtinDeviceProfiles()), len(profiles))
+		}
+	})
+
+	t.Run("設定ファイルで上書きと追加ができる", func(t *testing.T) {
+		config := &Config{
+			Devices: []DeviceProfile{
+				{Name: "basic", Width: 600, Height: 800, DPI: 167, Layout: LayoutWide},
+				{Name: "oasis", Width: 1264, Height: 1680, DPI: 300},
+			},
+		}
+		profiles, err := resolveDeviceProfiles(config, "")
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if len(profiles) != len(builtinDeviceProfiles())+1 {
+			t.Fatalf("プロファイル数: 期待=%d, 実際=%d", len(builtinDeviceProfiles())+1, len(profiles))
+		}
+		for _, profile := range profiles {
+			if profile.Name == "basic" && profile.Layout != LayoutWide {
+				t.Errorf("basic の Layout: 期待=%s, 実際=%s", LayoutWide, profile.Layout)
+			}
+			if profile.Name == "oasis" {
+				if profile.Orientation != OrientationPortrait || profile.FontScale != 1.0 || profile.Label != "oasis" || profile.Icons != IconsSVG {
+					t.Errorf("oasis のデフォルト値が設定されていません: %+v", profile)
+				}
+			}
+		}
+	})
+
+	t.Run("端末を絞り込める", func(t *testing.T) {
+		profiles, err := resolveDeviceProfiles(&Config{}, "touch, basic")
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if len(profiles) != 2 || profiles[0].Name != "touch" || profiles[1].Name != "basic" {
+			t.Errorf("絞り込み結果が不正です: %+v", profiles)
+		}
+	})
+
+	t.Run("存在しない端末名はエラー", func(t *testing.T) {
+		if _, err := resolveDeviceProfiles(&Config{}, "voyage"); err == nil {
+			t.Error("期待: エラー, 実際: nil")
+		}
+	})
+
+	t.Run("不正なプロファイルはエラー", func(t *testing.T) {
+		invalidProfiles := []DeviceProfile{
+			{Name: "", Width: 600, Height: 800, DPI: 167},
+			{Name: "../etc", Width: 600, Height: 800, DPI: 167},
+			{Name: "zero", Width: 0, Height: 800, DPI: 167},
+			{Name: "nodpi", Width: 600, Height: 800},
+			{Name: "rotated", Width: 600, Height: 800, DPI: 167, Orientation: "upside-down"},
+			{Name: "grid", Width: 600, Height: 800, DPI: 167, Layout: "grid"},
+			{Name: "png", Width: 600, Height: 800, DPI: 167, Icons: "png"},
+		}
+		for _, profile := range invalidProfiles {
+			if _, err := resolveDeviceProfiles(&Config{Devices: []DeviceProfile{profile}}, ""); err == nil {
+				t.Errorf("期待: エラー, 実際: nil (%+v)", profile)
+			}
+		}
+	})
+}
+
+// DeviceProfile のメソッドのテスト
+func TestDeviceProfile(t *testing.T) {
+	tests := []struct {
+		name           string
+		profile        DeviceProfile
+		expectedWidth  int
+		expectedHeight int
+		expectedZoom   float64
+	}{
+		{
+			name:           "基準端末と同じDPI",
+			profile:        DeviceProfile{Width: 758, Height: 1024, DPI: 212, Orientation: OrientationPortrait, FontScale: 1.0},
+			expectedWidth:  758,
+			expectedHeight: 1024,
+			expectedZoom:   1.0,
+		},
+		{
+			name:           "高解像度端末",
+			profile:        DeviceProfile{Width: 1072, Height: 1448, DPI: 300, Orientation: OrientationPortrait, FontScale: 1.0},
+			expectedWidth:  1072,
+			expectedHeight: 1448,
+			expectedZoom:   1.415,
+		},
+		{
+			name:           "横向きと文字サイズ倍率",
+			profile:        DeviceProfile{Width: 600, Height: 800, DPI: 212, Orientation: OrientationLandscape, FontScale: 1.5},
+			expectedWidth:  800,
+			expectedHeight: 600,
+			expectedZoom:   1.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if width := tt.profile.ViewportWidth(); width != tt.expectedWidth {
+				t.Errorf("ViewportWidth: 期待=%d, 実際=%d", tt.expectedWidth, width)
+			}
+			if height := tt.profile.ViewportHeight(); height != tt.expectedHeight {
+				t.Errorf("ViewportHeight: 期待=%d, 実際=%d", tt.expectedHeight, height)
+			}
+			if zoom := tt.profile.Zoom(); zoom != tt.expectedZoom {
+				t.Errorf("Zoom: 期待=%g, 実際=%g", tt.expectedZoom, zoom)
+			}
+		})
+	}
+
+	t.Run("セクションの表示判定", func(t *testing.T) {
+		all := DeviceProfile{}
+		if !all.ShowsSection(SectionNews) {
+			t.Error("Sections が空の場合はすべて表示されるべきです")
+		}
+		limited := DeviceProfile{Sections: []string{SectionToday}}
+		if !limited.ShowsSection(SectionToday) {
+			t.Error("today は表示されるべきです")
+		}
+		if limited.ShowsSection(SectionNews) {
+			t.Error("news は表示されないべきです")
+		}
+	})
+}
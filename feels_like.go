@@ -0,0 +1,30 @@
+package main
+
+import (
+	"math"
+
+	"kindle-tenki-dashboard/internal/feelslike"
+)
+
+// feelsLikeTemperature は気温 (℃)・風の予報文・湿度 (%) から体感温度 (℃) と説明を求める
+// 湿度が分からない場合 (hasHumidity が false) は、暑いときの体感温度は気温と同じになる
+// 説明は気温をそのまま使う場合は空になる
+func feelsLikeTemperature(temperature int, wind string, humidity float64, hasHumidity bool) (int, string) {
+	conditions := feelslike.Conditions{
+		Temperature: float64(temperature),
+		Humidity:    humidity,
+		HasHumidity: hasHumidity,
+	}
+	conditions.WindSpeed, conditions.HasWindSpeed = estimateWindSpeed(wind)
+	return feelsLike(conditions)
+}
+
+// feelsLike は気象条件から体感温度 (四捨五入した℃) と説明を求める
+// 気温をそのまま使う場合は、気温を四捨五入した値と空の説明を返す
+func feelsLike(conditions feelslike.Conditions) (int, string) {
+	result := feelslike.Calculate(conditions)
+	if result.Method == feelslike.MethodAirTemperature {
+		return int(math.Round(conditions.Temperature)), ""
+	}
+	return int(math.Round(result.Value)), result.Method.Label()
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+// feelsLikeTemperature のテスト
+func TestFeelsLikeTemperature(t *testing.T) {
+	tests := []struct {
+		name         string
+		temperature  int
+		wind         string
+		humidity     float64
+		hasHumidity  bool
+		expected     int
+		expectedNote string
+	}{
+		{name: "寒くて風が強い", temperature: 5, wind: "北の風　やや強く", expected: -1, expectedNote: "風による冷え"},
+		{name: "蒸し暑い", temperature: 32, wind: "南の風", humidity: 70, hasHumidity: true, expected: 37, expectedNote: "湿度による蒸し暑さ"},
+		{name: "暑いが湿度が分からない", temperature: 32, wind: "南の風", expected: 32},
+		{name: "過ごしやすい気温", temperature: 20, wind: "北の風　強く", humidity: 60, hasHumidity: true, expected: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, note := feelsLikeTemperature(tt.temperature, tt.wind, tt.humidity, tt.hasHumidity)
+			if actual != tt.expected || note != tt.expectedNote {
+				t.Errorf("期待: %d℃ (%q), 実際: %d℃ (%q)", tt.expected, tt.expectedNote, actual, note)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/garbage"
+)
+
+// GarbageInfo は今日・明日に出せるゴミの種類
+type GarbageInfo struct {
+	HasSchedules bool   `json:"hasSchedules"` // 収集日が設定されているかどうか
+	Today        string `json:"today"`        // 今日のゴミ (例: 燃えるゴミ・プラ、なければ「なし」)
+	Tomorrow     string `json:"tomorrow"`     // 明日のゴミ
+	HasToday     bool   `json:"hasToday"`     // 今日出せるゴミがあるかどうか
+}
+
+// buildGarbageInfo は収集日の規則から今日・明日に出せるゴミの種類を生成する
+func buildGarbageInfo(schedules []garbage.Schedule, now time.Time) GarbageInfo {
+	if len(schedules) == 0 {
+		return GarbageInfo{}
+	}
+
+	today := garbage.Collections(schedules, now)
+	tomorrow := garbage.Collections(schedules, now.AddDate(0, 0, 1))
+	return GarbageInfo{
+		HasSchedules: true,
+		Today:        joinGarbageNames(today),
+		Tomorrow:     joinGarbageNames(tomorrow),
+		HasToday:     len(today) > 0,
+	}
+}
+
+// joinGarbageNames はゴミの種類を「・」でつなぐ (なければ「なし」)
+func joinGarbageNames(names []string) string {
+	if len(names) == 0 {
+		return "なし"
+	}
+	return strings.Join(names, "・")
+}
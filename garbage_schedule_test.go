@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/garbage"
+)
+
+// buildGarbageInfo のテスト
+func TestBuildGarbageInfo(t *testing.T) {
+	schedules := []garbage.Schedule{
+		{Name: "燃えるゴミ", Weekdays: []time.Weekday{time.Monday, time.Thursday}},
+		{Name: "プラ", Weekdays: []time.Weekday{time.Monday}, SkipHolidays: true},
+		{Name: "資源ゴミ", Weekdays: []time.Weekday{time.Wednesday}, Weeks: []int{2, 4}},
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected GarbageInfo
+	}{
+		{
+			name:     "今日は複数、明日はなし",
+			now:      time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC),
+			expected: GarbageInfo{HasSchedules: true, Today: "燃えるゴミ・プラ", Tomorrow: "なし", HasToday: true},
+		},
+		{
+			name:     "今日はなし、明日は祝日で一部休止",
+			now:      time.Date(2026, 10, 11, 6, 0, 0, 0, time.UTC),
+			expected: GarbageInfo{HasSchedules: true, Today: "なし", Tomorrow: "燃えるゴミ"},
+		},
+		{
+			name:     "第2水曜日の前日",
+			now:      time.Date(2026, 10, 13, 6, 0, 0, 0, time.UTC),
+			expected: GarbageInfo{HasSchedules: true, Today: "なし", Tomorrow: "資源ゴミ"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := buildGarbageInfo(schedules, tt.now); actual != tt.expected {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, actual)
+			}
+		})
+	}
+
+	t.Run("設定なし", func(t *testing.T) {
+		if actual := buildGarbageInfo(nil, time.Now()); actual.HasSchedules {
+			t.Errorf("期待: HasSchedules=false, 実際: %+v", actual)
+		}
+	})
+}
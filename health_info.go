@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"kindle-tenki-dashboard/internal/health"
+)
+
+// HealthInfo は紫外線・花粉の表示内容
+// 表示する月の指標がない場合は Items が空になり、セクションを表示しない
+type HealthInfo struct {
+	Items []HealthItem `json:"items"` // 指標ごとの表示内容 (紫外線・花粉の順)
+}
+
+// HealthItem は1つの指標の表示内容
+type HealthItem struct {
+	Title       string      `json:"title"`       // 指標名 (例: 紫外線)
+	Unavailable bool        `json:"unavailable"` // 取得できなかったかどうか
+	StaleAt     string      `json:"staleAt"`     // 古いキャッシュを使った場合の取得時刻 (例: 10/18 09:00)
+	Observed    bool        `json:"observed"`    // 値が予報ではなく観測値かどうか (観測値は今日の値のみ)
+	Days        []HealthDay `json:"days"`        // 今日・明日の値
+}
+
+// HealthDay は1日の指標の表示内容
+type HealthDay struct {
+	Label string `json:"label"` // 今日・明日 (観測値は「今日 (観測)」)
+	Value string `json:"value"` // 値 (例: 6、51個/m³)
+	Level string `json:"level"` // 区分 (例: 強い)
+	Rank  int    `json:"rank"`  // 区分の段階 (1 〜 5)
+}
+
+// fetchHealthInfo は表示する月の指標を取得し、表示内容を生成する
+// 取得に失敗した指標は「取得できませんでした」と表示し、他の表示は続ける
+func fetchHealthInfo(sources []healthSource, now time.Time) HealthInfo {
+	var info HealthInfo
+	for _, source := range sources {
+		if !inSeason(source.Months, now) {
+			continue
+		}
+		info.Items = append(info.Items, fetchHealthItem(source.Provider, now))
+	}
+	return info
+}
+
+// fetchHealthItem は1つの指標を取得し、表示内容を生成する
+func fetchHealthItem(provider health.Provider, now time.Time) HealthItem {
+	kind := provider.Kind()
+	item := HealthItem{Title: kind.Label(), Unavailable: true}
+
+	result, err := fetchSourceResult(kind.Label()+"の情報", provider.URL(now))
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return item
+	}
+	days, err := provider.Parse(result.Body, now.Location())
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return item
+	}
+
+	item = buildHealthItem(kind, days, now, provider.Observed())
+	if result.Stale && !item.Unavailable {
+		item.StaleAt = result.FetchedAt.In(now.Location()).Format("1/2 15:04")
+	}
+	return item
+}
+
+// buildHealthItem は日ごとの最大値から今日・明日の表示内容を生成する
+// 観測値 (observed) は今日のこれまでの最大値のみを「今日 (観測)」として表示する
+// 表示する日の値がない場合は取得できなかったものとする
+func buildHealthItem(kind health.Kind, days []health.Daily, now time.Time, observed bool) HealthItem {
+	item := HealthItem{Title: kind.Label(), Observed: observed}
+	labels := []string{"今日", "明日"}
+	if observed {
+		labels = []string{"今日 (観測)"}
+	}
+	for i, label := range labels {
+		day, ok := health.FindDay(days, now.AddDate(0, 0, i))
+		if !ok {
+			continue
+		}
+		level := kind.LevelOf(day.Max)
+		item.Days = append(item.Days, HealthDay{
+			Label: label,
+			Value: healthValue(kind, day.Max),
+			Level: level.Label,
+			Rank:  level.Rank,
+		})
+	}
+	item.Unavailable = len(item.Days) == 0
+	return item
+}
+
+// healthValue は指標の値の表記を返す
+func healthValue(kind health.Kind, value float64) string {
+	switch kind {
+	case health.KindUV:
+		// 区分の判定と同じく四捨五入した整数で表示する
+		return fmt.Sprintf("%d", int(value+0.5))
+	case health.KindPollen:
+		return fmt.Sprintf("%.0f個/m³", value)
+	default:
+		return fmt.Sprintf("%g", value)
+	}
+}
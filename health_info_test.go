@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/health"
+)
+
+// buildHealthItem のテスト
+func TestBuildHealthItem(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 4, 18, 7, 0, 0, 0, jst)
+	date := func(day int) time.Time {
+		return time.Date(2026, 4, day, 0, 0, 0, 0, jst)
+	}
+
+	tests := []struct {
+		name     string
+		kind     health.Kind
+		days     []health.Daily
+		observed bool
+		expected HealthItem
+	}{
+		{
+			name: "紫外線 (今日・明日)",
+			kind: health.KindUV,
+			days: []health.Daily{{Date: date(18), Max: 6.45}, {Date: date(19), Max: 2.5}},
+			expected: HealthItem{Title: "紫外線", Days: []HealthDay{
+				{Label: "今日", Value: "6", Level: "強い", Rank: 3},
+				{Label: "明日", Value: "3", Level: "中程度", Rank: 2},
+			}},
+		},
+		{
+			name:     "花粉 (観測値は今日のみ)",
+			kind:     health.KindPollen,
+			days:     []health.Daily{{Date: date(18), Max: 51}, {Date: date(19), Max: 12}},
+			observed: true,
+			expected: HealthItem{Title: "花粉", Observed: true, Days: []HealthDay{
+				{Label: "今日 (観測)", Value: "51個/m³", Level: "非常に多い", Rank: 4},
+			}},
+		},
+		{
+			name:     "花粉 (今日の観測値がない)",
+			kind:     health.KindPollen,
+			days:     []health.Daily{{Date: date(17), Max: 51}},
+			observed: true,
+			expected: HealthItem{Title: "花粉", Observed: true, Unavailable: true},
+		},
+		{
+			name:     "今日・明日の値がない",
+			kind:     health.KindUV,
+			days:     []health.Daily{{Date: date(17), Max: 5}},
+			expected: HealthItem{Title: "紫外線", Unavailable: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := buildHealthItem(tt.kind, tt.days, now, tt.observed)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// fetchHealthInfo のテスト (表示する月以外の指標は取得しない)
+func TestFetchHealthInfoOutOfSeason(t *testing.T) {
+	provider, err := health.NewPollenProvider("", "13101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := []healthSource{{Provider: provider, Months: []time.Month{time.February, time.March}}}
+
+	info := fetchHealthInfo(sources, time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC))
+	if len(info.Items) != 0 {
+		t.Errorf("期待: 表示なし, 実際: %+v", info.Items)
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/chart"
+	"kindle-tenki-dashboard/internal/history"
+	"kindle-tenki-dashboard/internal/telop"
+)
+
+// HistoryChartLayout は最高・最低気温の推移のグラフのレイアウト
+// 上下の余白に最高・最低気温、下の余白に日付を表示する
+var HistoryChartLayout = chart.Layout{Width: 400, Height: 80, PaddingTop: 14, PaddingRight: 4, PaddingBottom: 26, PaddingLeft: 4}
+
+// MinHistoryAverageDays は平均との比較を表示するのに必要な記録の日数
+// 記録を始めたばかりで数日しかない場合は「7日間の平均」と呼べないため表示しない
+const MinHistoryAverageDays = 3
+
+// HistoryInfo は過去の気温との比較の表示内容
+type HistoryInfo struct {
+	Enabled     bool         `json:"enabled"`     // 過去との比較を表示するかどうか
+	VsYesterday string       `json:"vsYesterday"` // 昨日の最高気温との比較 (例: 昨日より5℃低い)
+	VsAverage   string       `json:"vsAverage"`   // 直近の最高気温の平均との比較 (例: 7日間の平均より2℃高い)
+	Days        []HistoryDay `json:"days"`        // 今日までの日ごとの最高・最低気温
+	HasTrend    bool         `json:"hasTrend"`    // 推移を示せるだけの記録 (2日以上) があるかどうか
+}
+
+// HistoryDay は1日の最高・最低気温
+type HistoryDay struct {
+	Label      string `json:"label"` // 日付 (例: 10/18)
+	MaxTemp    int    `json:"maxTemp"`
+	HasMaxTemp bool   `json:"hasMaxTemp"`
+	MinTemp    int    `json:"minTemp"`
+	HasMinTemp bool   `json:"hasMinTemp"`
+}
+
+// updateHistory は今回の天気データを記録に追記し、過去の記録との比較を生成する
+// サンプルデータの場合は記録しない。記録の読み書きに失敗しても比較できる範囲で表示する
+// 現在の観測値 (アメダス) がある場合は、予報の精度の基準にするため今日これまでの観測値も記録する
+func updateHistory(store history.Store, w *WeatherData, cityCode string, days int) HistoryInfo {
+	records, err := store.Load()
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+
+	if !w.IsUsingFallbackData {
+		record := newHistoryRecord(w, cityCode)
+		if observation, ok := historyObservation(w.Observation, record.Date); ok {
+			record.Observe(records, observation)
+		}
+		if err := store.Append(record); err != nil {
+			log.Printf("⚠️  天気の記録の保存に失敗しました: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	return buildHistoryInfo(records, cityCode, w.UpdatedAt, days)
+}
+
+// newHistoryRecord は天気データから記録を作る
+// 今日の最高気温の予報がない場合 (明日の最高気温を表示している場合) は最高気温を記録しない
+// 明日以降の予報は予報の精度を取得元ごとに求めるため、まとめる前の取得元ごとの予報を記録する
+func newHistoryRecord(w *WeatherData, cityCode string) history.Record {
+	record := history.Record{
+		Date:          w.UpdatedAt.Format(history.DateLayout),
+		RecordedAt:    w.UpdatedAt,
+		CityCode:      cityCode,
+		Location:      w.Location,
+		Provider:      strings.Join(w.WeatherProviders, "+"),
+		MaxTemp:       w.MaxTemp,
+		HasMaxTemp:    w.HasMaxTemp,
+		MinTemp:       w.MinTemp,
+		HasMinTemp:    w.HasMinTemp,
+		WeatherCode:   w.WeatherCode,
+		Precipitation: telop.Parse(w.Description).HasPrecipitation(),
+	}
+	for _, f := range w.ProviderForecasts {
+		for _, day := range f.Days {
+			if day.Date <= record.Date {
+				continue
+			}
+			rainChance, hasRainChance := day.MaxRainChance()
+			record.Forecasts = append(record.Forecasts, history.Forecast{
+				Provider:      f.Provider,
+				Date:          day.Date,
+				MaxTemp:       day.MaxTemp,
+				HasMaxTemp:    day.HasMaxTemp,
+				MinTemp:       day.MinTemp,
+				HasMinTemp:    day.HasMinTemp,
+				RainChance:    rainChance,
+				HasRainChance: hasRainChance,
+			})
+		}
+	}
+	return record
+}
+
+// historyObservation は現在の観測値から記録する観測値を返す
+// 観測値がない場合や、観測時刻が記録する日 (date) と異なる場合 (日付が変わった直後) は false を返す
+func historyObservation(info ObservationInfo, date string) (history.Observation, bool) {
+	if !info.Enabled || info.Unavailable || info.ObservedAt.Format(history.DateLayout) != date {
+		return history.Observation{}, false
+	}
+	return history.Observation{
+		Temperature:        info.TemperatureValue,
+		HasTemperature:     info.HasTemperature,
+		Precipitation1h:    info.PrecipitationValue,
+		HasPrecipitation1h: info.HasPrecipitation,
+	}, true
+}
+
+// buildHistoryInfo は記録から今日と昨日・直近 days 日間の平均の比較と、今日までの days 日間の推移を生成する
+func buildHistoryInfo(records []history.Record, cityCode string, now time.Time, days int) HistoryInfo {
+	info := HistoryInfo{Enabled: true}
+	summaries := history.Days(records, cityCode, now.Location())
+
+	if comparison, ok := history.Compare(summaries, now, days); ok && comparison.Today.HasMaxTemp {
+		if diff, ok := comparison.MaxDiff(); ok {
+			info.VsYesterday = compareTemperature("昨日", diff)
+		}
+		if comparison.AverageDays >= MinHistoryAverageDays {
+			diff := int(math.Round(float64(comparison.Today.MaxTemp) - comparison.MaxAverage))
+			info.VsAverage = compareTemperature(fmt.Sprintf("%d日間の平均", days), diff)
+		}
+	}
+
+	recorded := 0
+	for _, day := range history.Recent(summaries, now, days) {
+		info.Days = append(info.Days, HistoryDay{
+			Label:      day.Date.Format("1/2"),
+			MaxTemp:    day.MaxTemp,
+			HasMaxTemp: day.HasMaxTemp,
+			MinTemp:    day.MinTemp,
+			HasMinTemp: day.HasMinTemp,
+		})
+		if day.HasMaxTemp || day.HasMinTemp {
+			recorded++
+		}
+	}
+	info.HasTrend = recorded >= 2
+	return info
+}
+
+// compareTemperature は最高気温の差を「昨日より5℃低い」の形式で返す
+func compareTemperature(subject string, diff int) string {
+	switch {
+	case diff > 0:
+		return fmt.Sprintf("%sより%d℃高い", subject, diff)
+	case diff < 0:
+		return fmt.Sprintf("%sより%d℃低い", subject, -diff)
+	case subject == "昨日":
+		return "昨日と同じ"
+	default:
+		return subject + "並み"
+	}
+}
+
+// HistoryChart は今日までの日ごとの最高・最低気温の推移のグラフ(SVG)を返す
+// 今日の棒を塗りつぶし、過去の日は白抜きにする
+func (w *WeatherData) HistoryChart() template.HTML {
+	if !w.History.HasTrend {
+		return ""
+	}
+
+	points := make([]chart.RangePoint, len(w.History.Days))
+	for i, day := range w.History.Days {
+		points[i] = chart.RangePoint{
+			Label:   day.Label,
+			Low:     float64(day.MinTemp),
+			HasLow:  day.HasMinTemp,
+			High:    float64(day.MaxTemp),
+			HasHigh: day.HasMaxTemp,
+		}
+	}
+
+	rangeChart := chart.RangeChart{
+		Title:         "最高・最低気温の推移",
+		Class:         "history-chart",
+		Layout:        HistoryChartLayout,
+		Points:        points,
+		Unit:          "℃",
+		HighlightLast: true,
+	}
+	return template.HTML(rangeChart.SVG())
+}
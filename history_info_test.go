@@ -0,0 +1,230 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/forecast"
+	"kindle-tenki-dashboard/internal/history"
+)
+
+// historyRecords は10月from日から1日ごとに、maxTemps を最高気温とする記録を作る
+func historyRecords(from int, maxTemps ...int) []history.Record {
+	jst := time.FixedZone("JST", 9*60*60)
+	var records []history.Record
+	for i, maxTemp := range maxTemps {
+		at := time.Date(2026, 10, from+i, 9, 0, 0, 0, jst)
+		records = append(records, history.Record{
+			Date:       at.Format(history.DateLayout),
+			RecordedAt: at,
+			CityCode:   "130010",
+			MaxTemp:    maxTemp,
+			HasMaxTemp: true,
+			MinTemp:    maxTemp - 8,
+			HasMinTemp: true,
+		})
+	}
+	return records
+}
+
+// buildHistoryInfo のテスト
+func TestBuildHistoryInfo(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	tests := []struct {
+		name        string
+		records     []history.Record
+		vsYesterday string
+		vsAverage   string
+		hasTrend    bool
+	}{
+		{
+			name:        "昨日より低く、7日間の平均より低い",
+			records:     historyRecords(11, 22, 21, 20, 20, 19, 18, 20, 15),
+			vsYesterday: "昨日より5℃低い",
+			vsAverage:   "7日間の平均より5℃低い",
+			hasTrend:    true,
+		},
+		{
+			name:        "昨日と同じで平均並み",
+			records:     historyRecords(14, 20, 19, 21, 20, 20),
+			vsYesterday: "昨日と同じ",
+			vsAverage:   "7日間の平均並み",
+			hasTrend:    true,
+		},
+		{
+			// 平均は3日以上の記録がある場合のみ
+			name:        "記録が少ない場合は平均と比べない",
+			records:     historyRecords(16, 18, 20, 23),
+			vsYesterday: "昨日より3℃高い",
+			hasTrend:    true,
+		},
+		{
+			name:    "今日の記録しかない場合",
+			records: historyRecords(18, 15),
+		},
+		{
+			name:     "今日の記録がない場合は比べない",
+			records:  historyRecords(15, 20, 21, 22),
+			hasTrend: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := buildHistoryInfo(tt.records, "130010", now, 7)
+			if !info.Enabled {
+				t.Error("Enabled が false です")
+			}
+			if info.VsYesterday != tt.vsYesterday {
+				t.Errorf("昨日との比較 期待: %q, 実際: %q", tt.vsYesterday, info.VsYesterday)
+			}
+			if info.VsAverage != tt.vsAverage {
+				t.Errorf("平均との比較 期待: %q, 実際: %q", tt.vsAverage, info.VsAverage)
+			}
+			if info.HasTrend != tt.hasTrend {
+				t.Errorf("HasTrend 期待: %v, 実際: %v", tt.hasTrend, info.HasTrend)
+			}
+			if len(info.Days) != 7 || info.Days[6].Label != "10/18" {
+				t.Errorf("推移の日付が異なります: %+v", info.Days)
+			}
+		})
+	}
+}
+
+// updateHistory のテスト
+func TestUpdateHistory(t *testing.T) {
+	store := history.Store{Path: filepath.Join(t.TempDir(), "history.jsonl")}
+	for _, record := range historyRecords(17, 20) {
+		if err := store.Append(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	data := &WeatherData{Location: "東京", MaxTemp: 15, HasMaxTemp: true, UpdatedAt: now}
+
+	info := updateHistory(store, data, "130010", 7)
+	if info.VsYesterday != "昨日より5℃低い" {
+		t.Errorf("期待: 昨日より5℃低い, 実際: %q", info.VsYesterday)
+	}
+
+	records, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1].Date != "2026-10-18" || records[1].MaxTemp != 15 {
+		t.Errorf("今回の天気データが記録されていません: %+v", records)
+	}
+
+	t.Run("サンプルデータは記録しない", func(t *testing.T) {
+		sample, _ := getSampleData(now)
+		updateHistory(store, sample, "130010", 7)
+		if records, _ := store.Load(); len(records) != 2 {
+			t.Errorf("期待: 2件, 実際: %d件", len(records))
+		}
+	})
+
+	t.Run("観測値は今日これまでの記録と合わせて記録する", func(t *testing.T) {
+		observation := ObservationInfo{Enabled: true, ObservedAt: now.Add(-10 * time.Minute),
+			TemperatureValue: 16.4, HasTemperature: true, PrecipitationValue: 0.5, HasPrecipitation: true}
+		updateHistory(store, &WeatherData{Location: "東京", UpdatedAt: now, Observation: observation}, "130010", 7)
+		observation.TemperatureValue, observation.PrecipitationValue = 18.1, 0
+		updateHistory(store, &WeatherData{Location: "東京", UpdatedAt: now.Add(time.Hour), Observation: observation}, "130010", 7)
+
+		records, _ := store.Load()
+		last := records[len(records)-1]
+		if !last.HasObservedTemp || last.ObservedMaxTemp != 18.1 || last.ObservedMinTemp != 16.4 {
+			t.Errorf("気温 期待: 最高18.1℃・最低16.4℃, 実際: %+v", last)
+		}
+		if !last.HasObservedPrecipitation || !last.ObservedPrecipitation {
+			t.Errorf("今日これまでに降水を観測しているはずです: %+v", last)
+		}
+	})
+
+	t.Run("前日の観測値や取得できなかった観測値は記録しない", func(t *testing.T) {
+		for _, observation := range []ObservationInfo{
+			{Enabled: true, ObservedAt: now.Add(-13 * time.Hour), TemperatureValue: 10, HasTemperature: true},
+			{Enabled: true, Unavailable: true},
+			{},
+		} {
+			if _, ok := historyObservation(observation, "2026-10-18"); ok {
+				t.Errorf("期待: 記録しない, 実際: 記録する (%+v)", observation)
+			}
+		}
+	})
+
+	t.Run("今日の最高気温がない場合は最高気温を記録しない", func(t *testing.T) {
+		record := newHistoryRecord(&WeatherData{MaxTemp: 21, HasMaxTemp: false, UpdatedAt: now}, "130010")
+		if record.HasMaxTemp {
+			t.Error("明日の最高気温を今日の最高気温として記録しています")
+		}
+	})
+}
+
+// newHistoryRecord のテスト
+func TestNewHistoryRecord(t *testing.T) {
+	now := time.Date(2026, 10, 18, 17, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	data := &WeatherData{
+		Description:      "曇のち雨",
+		MaxTemp:          17,
+		HasMaxTemp:       true,
+		UpdatedAt:        now,
+		WeatherProviders: []string{forecast.ProviderTsukumijima, forecast.ProviderOpenMeteo},
+		ProviderForecasts: []forecast.Forecast{
+			{Provider: forecast.ProviderTsukumijima, Days: []forecast.Day{
+				{Date: "2026-10-18", MaxTemp: 17, HasMaxTemp: true},
+				{Date: "2026-10-19", MaxTemp: 20, HasMaxTemp: true, MinTemp: 12, HasMinTemp: true,
+					RainChances: [forecast.RainSlots]forecast.RainChance{{Percent: 30, Has: true}, {Percent: 70, Has: true}}},
+				{Date: "2026-10-20"},
+			}},
+			{Provider: forecast.ProviderOpenMeteo, Days: []forecast.Day{
+				{Date: "2026-10-18", MaxTemp: 18, HasMaxTemp: true},
+				{Date: "2026-10-19", MaxTemp: 21, HasMaxTemp: true},
+			}},
+		},
+	}
+
+	record := newHistoryRecord(data, "130010")
+	if record.Date != "2026-10-18" || record.Provider != "tsukumijima+open-meteo" {
+		t.Errorf("日付・取得元が異なります: %s, %s", record.Date, record.Provider)
+	}
+	if !record.Precipitation {
+		t.Error("曇のち雨は雨の天気として記録するはずです")
+	}
+
+	// 今日の予報は記録せず、明日以降の予報を取得元ごとに記録する
+	expected := []history.Forecast{
+		{Provider: "tsukumijima", Date: "2026-10-19", MaxTemp: 20, HasMaxTemp: true, MinTemp: 12, HasMinTemp: true, RainChance: 70, HasRainChance: true},
+		{Provider: "tsukumijima", Date: "2026-10-20"},
+		{Provider: "open-meteo", Date: "2026-10-19", MaxTemp: 21, HasMaxTemp: true},
+	}
+	if len(record.Forecasts) != len(expected) {
+		t.Fatalf("明日以降の予報 期待: %d件, 実際: %d件", len(expected), len(record.Forecasts))
+	}
+	for i := range expected {
+		if record.Forecasts[i] != expected[i] {
+			t.Errorf("予報[%d] 期待: %+v, 実際: %+v", i, expected[i], record.Forecasts[i])
+		}
+	}
+}
+
+// HistoryChart のテスト
+func TestHistoryChart(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	data := &WeatherData{History: buildHistoryInfo(historyRecords(16, 18, 20, 15), "130010", now, 7)}
+	chart := string(data.HistoryChart())
+	for _, expected := range []string{`class="history-chart"`, "10/18", "20℃", "7℃"} {
+		if !strings.Contains(chart, expected) {
+			t.Errorf("グラフに %q が含まれていません", expected)
+		}
+	}
+
+	data = &WeatherData{History: buildHistoryInfo(historyRecords(18, 15), "130010", now, 7)}
+	if chart := data.HistoryChart(); chart != "" {
+		t.Errorf("記録が1日分の場合はグラフを表示しない: %s", chart)
+	}
+}
@@ -0,0 +1,110 @@
+// Package amedas は気象庁のアメダス (地域気象観測システム) の観測所と観測値を扱う。
+// 主な観測所の一覧を同梱しており、緯度・経度から最寄りの観測所をネットワークなしで求められる。
+// 観測値は全観測所の10分ごとの値をまとめた JSON から読み、品質情報が正常でない値は欠測として扱う。
+package amedas
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"kindle-tenki-dashboard/internal/city"
+)
+
+//go:embed stations.csv
+var stationsCSV []byte
+
+// Station はアメダスの観測所
+// Code は気象庁の観測所番号 (5桁) で、環境省の暑さ指数の地点番号と共通
+type Station struct {
+	Code       string  // 観測所番号 (例: 44132)
+	Name       string  // 観測所名 (例: 東京)
+	Prefecture string  // 都道府県名 (例: 東京都)
+	Latitude   float64 // 緯度
+	Longitude  float64 // 経度
+}
+
+var (
+	loadOnce sync.Once
+	stations []Station
+	byCode   map[string]Station
+)
+
+// load は同梱の一覧を初回のみパースする
+func load() {
+	loadOnce.Do(func() {
+		parsed, err := parseStations(stationsCSV)
+		if err != nil {
+			panic(fmt.Sprintf("amedas: 同梱の観測所一覧が不正です: %v", err))
+		}
+		stations = parsed
+		byCode = make(map[string]Station, len(parsed))
+		for _, station := range parsed {
+			byCode[station.Code] = station
+		}
+	})
+}
+
+// parseStations はCSV形式の観測所一覧をパースする
+func parseStations(content []byte) ([]Station, error) {
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("観測所が含まれていません")
+	}
+
+	var parsed []Station
+	for i, record := range records[1:] {
+		if len(record) != 5 {
+			return nil, fmt.Errorf("%d行目: 列数が不正です", i+2)
+		}
+		latitude, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%d行目: 緯度が不正です: %w", i+2, err)
+		}
+		longitude, err := strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%d行目: 経度が不正です: %w", i+2, err)
+		}
+		parsed = append(parsed, Station{
+			Code:       record[0],
+			Name:       record[1],
+			Prefecture: record[2],
+			Latitude:   latitude,
+			Longitude:  longitude,
+		})
+	}
+	return parsed, nil
+}
+
+// Stations は同梱の観測所の一覧を返す
+func Stations() []Station {
+	load()
+	return stations
+}
+
+// Lookup は観測所番号から観測所を返す
+func Lookup(code string) (Station, bool) {
+	load()
+	station, ok := byCode[code]
+	return station, ok
+}
+
+// Nearest は指定した緯度・経度に最も近い観測所と距離 (km) を返す
+func Nearest(latitude, longitude float64) (Station, float64) {
+	load()
+	var nearest Station
+	minDistance := -1.0
+	for _, station := range stations {
+		distance := city.Distance(latitude, longitude, station.Latitude, station.Longitude)
+		if minDistance < 0 || distance < minDistance {
+			nearest, minDistance = station, distance
+		}
+	}
+	return nearest, minDistance
+}
@@ -0,0 +1,137 @@
+package amedas
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/wind"
+)
+
+// 同梱の観測所一覧のテスト
+func TestStations(t *testing.T) {
+	all := Stations()
+	if len(all) == 0 {
+		t.Fatal("観測所が含まれていません")
+	}
+	seen := map[string]bool{}
+	for _, station := range all {
+		if len(station.Code) != 5 {
+			t.Errorf("観測所番号が5桁ではありません: %+v", station)
+		}
+		if seen[station.Code] {
+			t.Errorf("観測所番号が重複しています: %s", station.Code)
+		}
+		seen[station.Code] = true
+	}
+
+	if station, ok := Lookup("44132"); !ok || station.Name != "東京" {
+		t.Errorf("期待: 東京, 実際: %+v (%v)", station, ok)
+	}
+	if _, ok := Lookup("99999"); ok {
+		t.Error("期待: 見つからない, 実際: 見つかった")
+	}
+}
+
+// Nearest のテスト
+func TestNearest(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		expected  string
+	}{
+		{"東京駅", 35.681, 139.767, "東京"},
+		{"川越", 35.925, 139.486, "さいたま"},
+		{"姫路", 34.816, 134.686, "神戸"},
+		{"名護", 26.592, 127.977, "那覇"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			station, distance := Nearest(tt.latitude, tt.longitude)
+			if station.Name != tt.expected {
+				t.Errorf("期待: %s, 実際: %s (%.1fkm)", tt.expected, station.Name, distance)
+			}
+		})
+	}
+}
+
+// ParseMap のテスト
+func TestParseMap(t *testing.T) {
+	body, err := os.ReadFile("testdata/map.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	observedAt := time.Date(2026, 10, 18, 9, 50, 0, 0, jst)
+	observations, err := ParseMap(body, observedAt)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+
+	tests := []struct {
+		code             string
+		hasTemperature   bool
+		temperature      float64
+		hasHumidity      bool
+		humidity         float64
+		hasWindSpeed     bool
+		windSpeed        float64
+		windDirection    wind.Direction
+		hasPrecipitation bool
+		precipitation    float64
+	}{
+		{"44132", true, 31.2, true, 62, true, 3.4, wind.DirectionNNW, true, 0},
+		// 静穏は風向なし
+		{"43056", true, 33.5, true, 55, true, 0, wind.DirectionUnknown, true, 1.5},
+		// 欠測は使わない (風向の16は北)
+		{"44172", true, 28.1, false, 0, true, 5.2, wind.DirectionN, false, 0},
+		// 品質情報が正常でない値は使わない
+		{"46106", false, 0, true, 70, false, 0, wind.DirectionUnknown, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			observation := observations[tt.code]
+			if observation.HasTemperature != tt.hasTemperature || observation.Temperature != tt.temperature {
+				t.Errorf("気温: 期待=%v (%v), 実際=%v (%v)", tt.temperature, tt.hasTemperature, observation.Temperature, observation.HasTemperature)
+			}
+			if observation.HasHumidity != tt.hasHumidity || observation.Humidity != tt.humidity {
+				t.Errorf("湿度: 期待=%v (%v), 実際=%v (%v)", tt.humidity, tt.hasHumidity, observation.Humidity, observation.HasHumidity)
+			}
+			if observation.HasWindSpeed != tt.hasWindSpeed || observation.WindSpeed != tt.windSpeed || observation.WindDirection != tt.windDirection {
+				t.Errorf("風: 期待=%v %v (%v), 実際=%v %v (%v)", tt.windDirection.Label(), tt.windSpeed, tt.hasWindSpeed, observation.WindDirection.Label(), observation.WindSpeed, observation.HasWindSpeed)
+			}
+			if observation.HasPrecipitation1h != tt.hasPrecipitation || observation.Precipitation1h != tt.precipitation {
+				t.Errorf("降水量: 期待=%v (%v), 実際=%v (%v)", tt.precipitation, tt.hasPrecipitation, observation.Precipitation1h, observation.HasPrecipitation1h)
+			}
+			if !observation.Time.Equal(observedAt) {
+				t.Errorf("観測時刻: 期待=%v, 実際=%v", observedAt, observation.Time)
+			}
+		})
+	}
+
+	if _, err := ParseMap([]byte("<html>"), observedAt); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// ParseLatestTime と MapURL のテスト
+func TestParseLatestTime(t *testing.T) {
+	latest, err := ParseLatestTime([]byte("2026-10-18T09:50:00+09:00\n"))
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	expected := "https://www.jma.go.jp/bosai/amedas/data/map/20261018095000.json"
+	if actual := MapURL(latest); actual != expected {
+		t.Errorf("期待: %s, 実際: %s", expected, actual)
+	}
+	// UTCの時刻も日本時間のファイル名にする
+	if actual := MapURL(latest.In(time.UTC)); actual != expected {
+		t.Errorf("期待: %s, 実際: %s", expected, actual)
+	}
+
+	if _, err := ParseLatestTime([]byte("")); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
@@ -0,0 +1,87 @@
+package amedas
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/wind"
+)
+
+// LatestTimeURL は最新の観測時刻 (テキスト) のURL
+const LatestTimeURL = "https://www.jma.go.jp/bosai/amedas/data/latest_time.txt"
+
+// MapURL は指定した時刻の全観測所の観測値 (JSON) のURLを返す
+func MapURL(t time.Time) string {
+	return "https://www.jma.go.jp/bosai/amedas/data/map/" + t.In(jst).Format("20060102150405") + ".json"
+}
+
+// jst は観測時刻の表記に使う日本標準時
+var jst = time.FixedZone("JST", 9*60*60)
+
+// Observation は1つの観測所の観測値
+type Observation struct {
+	Time               time.Time // 観測時刻
+	Temperature        float64   // 気温 (℃)
+	HasTemperature     bool
+	Humidity           float64 // 相対湿度 (%)
+	HasHumidity        bool
+	WindSpeed          float64 // 平均風速 (m/s)
+	HasWindSpeed       bool
+	WindDirection      wind.Direction // 風向 (静穏・欠測の場合は DirectionUnknown)
+	Precipitation1h    float64        // 前1時間の降水量 (mm)
+	HasPrecipitation1h bool
+}
+
+// ParseLatestTime は最新の観測時刻をパースする (例: 2026-10-18T09:50:00+09:00)
+func ParseLatestTime(body []byte) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(body)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("アメダスの観測時刻のパースに失敗しました: %w", err)
+	}
+	return t, nil
+}
+
+// ParseMap は観測時刻 observedAt の全観測所の観測値をパースし、観測所番号ごとの観測値を返す
+// 観測値は [値, 品質情報] の組で、品質情報が 0 (正常) 以外の値は使わない
+func ParseMap(body []byte, observedAt time.Time) (map[string]Observation, error) {
+	var raw map[string]map[string][]json.Number
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("アメダスの観測値のパースに失敗しました: %w", err)
+	}
+
+	observations := make(map[string]Observation, len(raw))
+	for code, elements := range raw {
+		observation := Observation{Time: observedAt, WindDirection: wind.DirectionUnknown}
+		observation.Temperature, observation.HasTemperature = element(elements["temp"])
+		observation.Humidity, observation.HasHumidity = element(elements["humidity"])
+		observation.WindSpeed, observation.HasWindSpeed = element(elements["wind"])
+		observation.Precipitation1h, observation.HasPrecipitation1h = element(elements["precipitation1h"])
+		if value, ok := element(elements["windDirection"]); ok {
+			observation.WindDirection = windDirection(int(value))
+		}
+		observations[code] = observation
+	}
+	return observations, nil
+}
+
+// windDirection は気象庁の風向 (1: 北北東 〜 16: 北、0: 静穏) を16方位にする
+func windDirection(value int) wind.Direction {
+	if value < 1 || value > 16 {
+		return wind.DirectionUnknown
+	}
+	return wind.Direction(value % 16)
+}
+
+// element は [値, 品質情報] の組から正常な値を取り出す
+func element(pair []json.Number) (float64, bool) {
+	if len(pair) < 2 || pair[1] != "0" {
+		return 0, false
+	}
+	value, err := pair[0].Float64()
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,113 @@
+// Package calendar は月間カレンダーの表示用モデルを生成する。
+// Kindleのブラウザでは JavaScript が遅く不安定なため、日付の並びや強調表示の判定は
+// すべてここで行い、テンプレートは結果をそのまま表に並べるだけにする。
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"kindle-tenki-dashboard/internal/holiday"
+)
+
+// WeekdayLabels は曜日の見出し (日曜始まり)
+var WeekdayLabels = [7]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// Event はカレンダーに印を付ける予定
+type Event struct {
+	Date  time.Time // 日付 (時刻は無視する)
+	Title string    // 予定の名前
+}
+
+// Day はカレンダーの1日分のセル
+type Day struct {
+	Day         int      // 日 (表示中の月でない場合は 0)
+	InMonth     bool     // 表示中の月の日かどうか (前後の月の日は空欄にする)
+	IsSaturday  bool     // 土曜日
+	IsSunday    bool     // 日曜日
+	IsHoliday   bool     // 祝日・休日
+	HolidayName string   // 祝日・休日の名前
+	IsToday     bool     // 今日
+	Events      []string // 予定の名前
+}
+
+// HasEvents は予定があるかどうかを返す
+func (d Day) HasEvents() bool {
+	return len(d.Events) > 0
+}
+
+// IsRestDay は日曜日または祝日・休日かどうかを返す
+func (d Day) IsRestDay() bool {
+	return d.IsSunday || d.IsHoliday
+}
+
+// Week はカレンダーの1週間分の行 (日曜始まり)
+type Week struct {
+	Days [7]Day
+}
+
+// Month は月間カレンダー
+type Month struct {
+	Year  int
+	Month time.Month
+	Title string // 見出し (例: 2026年10月)
+	Weeks []Week
+}
+
+// Weekdays は曜日の見出しを返す
+func (m Month) Weekdays() [7]string {
+	return WeekdayLabels
+}
+
+// NewMonth は today を含む月のカレンダーを生成する
+// events のうち表示中の月に含まれるものに印を付ける
+func NewMonth(today time.Time, events []Event) Month {
+	year, month := today.Year(), today.Month()
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+
+	eventsByDay := map[int][]string{}
+	for _, event := range events {
+		if event.Date.Year() == year && event.Date.Month() == month {
+			eventsByDay[event.Date.Day()] = append(eventsByDay[event.Date.Day()], event.Title)
+		}
+	}
+
+	calendar := Month{
+		Year:  year,
+		Month: month,
+		Title: fmt.Sprintf("%d年%d月", year, month),
+	}
+
+	// 1日の曜日の位置から並べ始める
+	var week Week
+	column := int(first.Weekday())
+	for day := 1; day <= daysInMonth; day++ {
+		date := first.AddDate(0, 0, day-1)
+		cell := Day{
+			Day:        day,
+			InMonth:    true,
+			IsSaturday: date.Weekday() == time.Saturday,
+			IsSunday:   date.Weekday() == time.Sunday,
+			IsToday:    day == today.Day(),
+			Events:     eventsByDay[day],
+		}
+		if name, ok := holiday.Lookup(date); ok {
+			cell.IsHoliday = true
+			cell.HolidayName = name
+		}
+
+		week.Days[column] = cell
+		column++
+		if column == 7 {
+			calendar.Weeks = append(calendar.Weeks, week)
+			week = Week{}
+			column = 0
+		}
+	}
+	if column > 0 {
+		calendar.Weeks = append(calendar.Weeks, week)
+	}
+
+	return calendar
+}
@@ -0,0 +1,90 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+// NewMonth のテスト
+func TestNewMonth(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	today := time.Date(2026, 9, 18, 7, 0, 0, 0, jst)
+	events := []Event{
+		{Date: time.Date(2026, 9, 18, 0, 0, 0, 0, time.UTC), Title: "燃えるゴミ"},
+		{Date: time.Date(2026, 9, 18, 19, 0, 0, 0, jst), Title: "定例会"},
+		{Date: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Title: "翌月の予定"},
+	}
+
+	month := NewMonth(today, events)
+
+	if month.Title != "2026年9月" {
+		t.Errorf("見出し: 期待=2026年9月, 実際=%s", month.Title)
+	}
+
+	// 2026年9月1日は火曜日、30日は水曜日なので5週になる
+	if len(month.Weeks) != 5 {
+		t.Fatalf("週の数: 期待=5, 実際=%d", len(month.Weeks))
+	}
+	first := month.Weeks[0]
+	if first.Days[0].InMonth || first.Days[1].InMonth {
+		t.Error("1日より前の日は空欄であるべきです")
+	}
+	if first.Days[2].Day != 1 {
+		t.Errorf("1日の位置: 期待=火曜日, 実際=%+v", first.Days)
+	}
+	last := month.Weeks[4]
+	if last.Days[3].Day != 30 || last.Days[4].InMonth {
+		t.Errorf("月末の位置が不正です: %+v", last.Days)
+	}
+
+	tests := []struct {
+		name     string
+		week     int
+		weekday  time.Weekday
+		day      int
+		check    func(Day) bool
+		expected bool
+	}{
+		{name: "日曜日", week: 1, weekday: time.Sunday, day: 6, check: func(d Day) bool { return d.IsSunday && d.IsRestDay() }, expected: true},
+		{name: "土曜日", week: 1, weekday: time.Saturday, day: 12, check: func(d Day) bool { return d.IsSaturday && !d.IsRestDay() }, expected: true},
+		{name: "敬老の日", week: 3, weekday: time.Monday, day: 21, check: func(d Day) bool { return d.IsHoliday && d.HolidayName == "敬老の日" }, expected: true},
+		{name: "国民の休日", week: 3, weekday: time.Tuesday, day: 22, check: func(d Day) bool { return d.IsRestDay() && d.HolidayName == "国民の休日" }, expected: true},
+		{name: "今日", week: 2, weekday: time.Friday, day: 18, check: func(d Day) bool { return d.IsToday }, expected: true},
+		{name: "今日以外", week: 2, weekday: time.Thursday, day: 17, check: func(d Day) bool { return d.IsToday }, expected: false},
+		{name: "予定あり", week: 2, weekday: time.Friday, day: 18, check: func(d Day) bool { return d.HasEvents() && len(d.Events) == 2 }, expected: true},
+		{name: "予定なし", week: 2, weekday: time.Thursday, day: 17, check: func(d Day) bool { return d.HasEvents() }, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := month.Weeks[tt.week].Days[tt.weekday]
+			if day.Day != tt.day {
+				t.Fatalf("日付: 期待=%d, 実際=%d", tt.day, day.Day)
+			}
+			if result := tt.check(day); result != tt.expected {
+				t.Errorf("期待: %v, 実際: %v (%+v)", tt.expected, result, day)
+			}
+		})
+	}
+}
+
+// 週の数のテスト
+func TestNewMonthWeeks(t *testing.T) {
+	tests := []struct {
+		name     string
+		today    time.Time
+		expected int
+	}{
+		{name: "4週で収まる月 (2026年2月は日曜始まりの28日間)", today: time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), expected: 4},
+		{name: "6週になる月 (2026年8月)", today: time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC), expected: 6},
+		{name: "閏年の2月", today: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), expected: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := len(NewMonth(tt.today, nil).Weeks); result != tt.expected {
+				t.Errorf("期待: %d, 実際: %d", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package chart
+
+import "math"
+
+// BarChart は棒グラフ
+type BarChart struct {
+	Title         string      // グラフのタイトル (title要素とaria-labelに使用)
+	Class         string      // svg要素のclass属性
+	Layout        Layout      // 大きさと余白
+	Points        []Point     // 値 (値のない点は「-」を表示する)
+	Unit          string      // 値ラベルの単位 (例: %)
+	Min           float64     // 縦軸の最小値
+	Max           float64     // 縦軸の最大値
+	Gridlines     []float64   // 目盛り線を引く値 (Min は実線、それ以外は破線)
+	BarWidthRatio float64     // 1点あたりの横幅に対する棒の幅の比率 (0 の場合は 0.5)
+	ShowXLabels   bool        // 横軸ラベルを表示する
+	Separators    []Separator // 日付の境界などの区切り線
+}
+
+// SVG は棒グラフのSVGを生成する
+func (c BarChart) SVG() string {
+	w := &svgWriter{}
+	w.open(c.Layout, c.Class, c.Title)
+
+	count := len(c.Points)
+	yOf := func(value float64) float64 {
+		if c.Max == c.Min {
+			return c.Layout.plotBottom()
+		}
+		value = math.Max(c.Min, math.Min(c.Max, value))
+		return c.Layout.plotTop() + (c.Max-value)/(c.Max-c.Min)*c.Layout.plotHeight()
+	}
+
+	for _, grid := range c.Gridlines {
+		y := yOf(grid)
+		w.line(c.Layout.plotLeft(), y, c.Layout.plotRight(), y, grid != c.Min)
+		w.text(c.Layout.plotLeft()-3, y+3, "end", 8, false, formatValue(grid, c.Unit))
+	}
+
+	w.separators(c.Layout, count, c.Separators)
+
+	ratio := c.BarWidthRatio
+	if ratio <= 0 {
+		ratio = 0.5
+	}
+	barWidth := c.Layout.slotWidth(count) * ratio
+	for i, point := range c.Points {
+		x := c.Layout.slotCenter(i, count)
+		if !point.HasValue {
+			w.text(x, c.Layout.plotBottom()-3, "middle", 9, false, "-")
+			continue
+		}
+		y := yOf(point.Value)
+		if height := c.Layout.plotBottom() - y; height > 0 {
+			w.printf(`<rect x="%s" y="%s" width="%s" height="%s" fill="#000"/>`+"\n",
+				formatNumber(x-barWidth/2), formatNumber(y), formatNumber(barWidth), formatNumber(height))
+		}
+		w.text(x, y-3, "middle", 9, false, formatValue(point.Value, c.Unit))
+	}
+
+	if c.ShowXLabels {
+		w.xLabels(c.Layout, c.Points)
+	}
+	return w.close()
+}
@@ -0,0 +1,172 @@
+// Package chart はE-ink向けのモノクロSVGグラフを生成する。
+// グラフはサーバー側で完成したSVGとして出力し、テンプレートはそれを埋め込むだけにする。
+// Kindleのブラウザでは JavaScript が遅く不安定なため、描画に必要な計算はすべてここで行う。
+package chart
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MinLabelSpacing は横軸ラベルの最小間隔 (これより狭い場合はラベルを間引く)
+const MinLabelSpacing = 36.0
+
+// Layout はグラフの大きさと余白 (SVGのviewBox座標系)
+// 横軸を共有するグラフは Width と左右の余白を揃えると点の位置が一致する
+type Layout struct {
+	Width         int // viewBox の幅
+	Height        int // viewBox の高さ
+	PaddingTop    int // 上の余白 (値ラベルの領域を含む)
+	PaddingRight  int // 右の余白
+	PaddingBottom int // 下の余白 (横軸ラベルの領域を含む)
+	PaddingLeft   int // 左の余白 (縦軸ラベルの領域を含む)
+}
+
+// plotLeft などは描画領域の座標を返す
+func (l Layout) plotLeft() float64   { return float64(l.PaddingLeft) }
+func (l Layout) plotRight() float64  { return float64(l.Width - l.PaddingRight) }
+func (l Layout) plotTop() float64    { return float64(l.PaddingTop) }
+func (l Layout) plotBottom() float64 { return float64(l.Height - l.PaddingBottom) }
+func (l Layout) plotWidth() float64  { return l.plotRight() - l.plotLeft() }
+func (l Layout) plotHeight() float64 { return l.plotBottom() - l.plotTop() }
+
+// slotWidth は1点あたりの横幅を返す
+func (l Layout) slotWidth(count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return l.plotWidth() / float64(count)
+}
+
+// slotCenter はi番目の点の中心のX座標を返す
+func (l Layout) slotCenter(index, count int) float64 {
+	return l.plotLeft() + (float64(index)+0.5)*l.slotWidth(count)
+}
+
+// Point はグラフの1点
+type Point struct {
+	Label    string  // 横軸ラベル
+	Value    float64 // 値
+	HasValue bool    // 値が有効かどうか (無効な点は描画しない)
+}
+
+// Separator は点と点の間に引く縦の区切り線 (日付の境界など)
+type Separator struct {
+	Index int    // この点の直前に区切り線を引く
+	Label string // 区切り線に添えるラベル
+}
+
+// Band は網掛けする横軸の区間 (夜間など)
+// 位置は点の単位で表し、0 が最初の点の左端、0.5 が最初の点の中心、1 が2番目の点の左端
+type Band struct {
+	From float64 // 区間の始まり
+	To   float64 // 区間の終わり
+}
+
+// labelStep は横軸ラベルを何点ごとに表示するかを返す
+func labelStep(layout Layout, count int) int {
+	slot := layout.slotWidth(count)
+	if slot <= 0 || slot >= MinLabelSpacing {
+		return 1
+	}
+	return int(math.Ceil(MinLabelSpacing / slot))
+}
+
+// formatNumber は座標をSVG用に小数点以下1桁までで整形する
+func formatNumber(value float64) string {
+	rounded := math.Round(value*10) / 10
+	if rounded == 0 {
+		rounded = 0 // -0 を避ける
+	}
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// formatValue は値ラベルを整形する
+func formatValue(value float64, unit string) string {
+	return strconv.FormatFloat(value, 'f', -1, 64) + unit
+}
+
+// svgWriter はSVG要素を組み立てる
+type svgWriter struct {
+	builder strings.Builder
+}
+
+func (w *svgWriter) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&w.builder, format, args...)
+}
+
+// open はsvg要素の開始タグとタイトルを書き出す
+func (w *svgWriter) open(layout Layout, class, title string) {
+	w.printf(`<svg class="%s" viewBox="0 0 %d %d" preserveAspectRatio="xMidYMid meet" role="img" aria-label="%s">`,
+		html.EscapeString(class), layout.Width, layout.Height, html.EscapeString(title))
+	w.printf("\n<title>%s</title>\n", html.EscapeString(title))
+}
+
+func (w *svgWriter) close() string {
+	w.printf("</svg>\n")
+	return w.builder.String()
+}
+
+// line は直線を書き出す
+func (w *svgWriter) line(x1, y1, x2, y2 float64, dashed bool) {
+	dash := ""
+	if dashed {
+		dash = ` stroke-dasharray="2,3"`
+	}
+	w.printf(`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#000" stroke-width="1"%s/>`+"\n",
+		formatNumber(x1), formatNumber(y1), formatNumber(x2), formatNumber(y2), dash)
+}
+
+// text はテキストを書き出す
+func (w *svgWriter) text(x, y float64, anchor string, fontSize int, bold bool, content string) {
+	weight := ""
+	if bold {
+		weight = ` font-weight="bold"`
+	}
+	w.printf(`<text x="%s" y="%s" text-anchor="%s" font-size="%d"%s fill="#000">%s</text>`+"\n",
+		formatNumber(x), formatNumber(y), anchor, fontSize, weight, html.EscapeString(content))
+}
+
+// xLabels は横軸ラベルを書き出す
+func (w *svgWriter) xLabels(layout Layout, points []Point) {
+	step := labelStep(layout, len(points))
+	y := layout.plotBottom() + float64(layout.PaddingBottom) - 6
+	for i, point := range points {
+		if i%step != 0 || point.Label == "" {
+			continue
+		}
+		w.text(layout.slotCenter(i, len(points)), y, "middle", 9, false, point.Label)
+	}
+}
+
+// bands は区間の網掛けを書き出す (グラフの範囲外は切り詰める)
+func (w *svgWriter) bands(layout Layout, count int, bands []Band) {
+	for _, band := range bands {
+		from := math.Max(band.From, 0)
+		to := math.Min(band.To, float64(count))
+		if from >= to {
+			continue
+		}
+		x := layout.plotLeft() + from*layout.slotWidth(count)
+		width := (to - from) * layout.slotWidth(count)
+		w.printf(`<rect x="%s" y="%s" width="%s" height="%s" fill="#000" fill-opacity="0.12"/>`+"\n",
+			formatNumber(x), formatNumber(layout.plotTop()), formatNumber(width), formatNumber(layout.plotHeight()))
+	}
+}
+
+// separators は区切り線を書き出す
+func (w *svgWriter) separators(layout Layout, count int, separators []Separator) {
+	for _, separator := range separators {
+		if separator.Index <= 0 || separator.Index >= count {
+			continue
+		}
+		x := layout.plotLeft() + float64(separator.Index)*layout.slotWidth(count)
+		w.line(x, layout.plotTop(), x, layout.plotBottom(), true)
+		if separator.Label != "" {
+			w.text(x+2, layout.plotTop()+8, "start", 8, false, separator.Label)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package chart
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// go test ./internal/chart -update で golden ファイルを更新する
+var update = flag.Bool("update", false, "golden ファイルを更新する")
+
+var testLayout = Layout{Width: 400, Height: 120, PaddingTop: 20, PaddingRight: 8, PaddingBottom: 20, PaddingLeft: 32}
+
+func points(labels []string, values ...float64) []Point {
+	result := make([]Point, len(values))
+	for i, value := range values {
+		result[i] = Point{Label: labels[i], Value: value, HasValue: true}
+	}
+	return result
+}
+
+var hourLabels = []string{"12:00", "15:00", "18:00", "21:00", "00:00", "03:00", "06:00", "09:00"}
+
+// assertGolden は出力を testdata/<name>.golden.svg と比較する
+func assertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden.svg")
+	if *update {
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatalf("golden ファイルの書き込みに失敗しました: %v", err)
+		}
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden ファイルの読み込みに失敗しました: %v", err)
+	}
+	if string(expected) != actual {
+		t.Errorf("%s が golden ファイルと一致しません (-update で更新できます)\n期待:\n%s\n実際:\n%s", name, expected, actual)
+	}
+}
+
+// LineChart の golden テスト
+func TestLineChartGolden(t *testing.T) {
+	tests := []struct {
+		name  string
+		chart LineChart
+	}{
+		{
+			name: "line_basic",
+			chart: LineChart{
+				Title:  "気温",
+				Class:  "line-chart",
+				Layout: testLayout,
+				Points: points(hourLabels, 23, 25, 21, 19),
+				Unit:   "℃",
+			},
+		},
+		{
+			name: "line_smooth_annotated",
+			chart: LineChart{
+				Title:          "48時間の気温変化",
+				Class:          "line-chart",
+				Layout:         testLayout,
+				Points:         points(hourLabels, 23, 25, 21, 19, 17, 16, 20, 24),
+				Unit:           "℃",
+				Smooth:         true,
+				AnnotateMinMax: true,
+				Separators:     []Separator{{Index: 4, Label: "明日"}},
+			},
+		},
+		{
+			name: "line_bands",
+			chart: LineChart{
+				Title:          "48時間の気温変化",
+				Class:          "line-chart",
+				Layout:         testLayout,
+				Points:         points(hourLabels, 23, 25, 21, 19, 17, 16, 20, 24),
+				Unit:           "℃",
+				AnnotateMinMax: true,
+				Separators:     []Separator{{Index: 4, Label: "明日"}},
+				// 範囲外の部分は切り詰め、幅のない区間は描かない
+				Bands: []Band{{From: -1, To: 0.3}, {From: 2.2, To: 6.3}, {From: 5, To: 5}},
+			},
+		},
+		{
+			name: "line_flat",
+			chart: LineChart{
+				Title:          "気温",
+				Class:          "line-chart",
+				Layout:         testLayout,
+				Points:         points(hourLabels, 20, 20, 20),
+				Unit:           "℃",
+				Smooth:         true,
+				AnnotateMinMax: true,
+			},
+		},
+		{
+			name: "line_missing",
+			chart: LineChart{
+				Title:  "気温",
+				Class:  "line-chart",
+				Layout: testLayout,
+				Points: []Point{
+					{Label: "12:00", Value: 10, HasValue: true},
+					{Label: "15:00", Value: 12, HasValue: true},
+					{Label: "18:00"},
+					{Label: "21:00", Value: 8, HasValue: true},
+				},
+				Unit: "℃",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertGolden(t, tt.name, tt.chart.SVG())
+		})
+	}
+}
+
+// BarChart の golden テスト
+func TestBarChartGolden(t *testing.T) {
+	rain := points(hourLabels, 0, 30, 50, 100, 20)
+	rain = append(rain, Point{Label: "03:00"})
+
+	tests := []struct {
+		name  string
+		chart BarChart
+	}{
+		{
+			name: "bar_rain",
+			chart: BarChart{
+				Title:      "降水確率",
+				Class:      "line-chart rain-chart",
+				Layout:     Layout{Width: 400, Height: 70, PaddingTop: 14, PaddingRight: 8, PaddingBottom: 4, PaddingLeft: 32},
+				Points:     rain,
+				Unit:       "%",
+				Min:        0,
+				Max:        100,
+				Gridlines:  []float64{0, 50, 100},
+				Separators: []Separator{{Index: 4, Label: "明日"}},
+			},
+		},
+		{
+			name: "bar_labels",
+			chart: BarChart{
+				Title:         "降水確率",
+				Class:         "bar-chart",
+				Layout:        testLayout,
+				Points:        points(hourLabels, 10, 120, -5),
+				Unit:          "%",
+				Min:           0,
+				Max:           100,
+				BarWidthRatio: 0.8,
+				ShowXLabels:   true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertGolden(t, tt.name, tt.chart.SVG())
+		})
+	}
+}
+
+// Sparkline の golden テスト
+func TestSparklineGolden(t *testing.T) {
+	layout := Layout{Width: 240, Height: 40, PaddingTop: 6, PaddingRight: 40, PaddingBottom: 6, PaddingLeft: 40}
+	missing := points(hourLabels, 1013, 1012, 1010)
+	missing = append(missing, Point{Label: "21:00"}, Point{Label: "00:00", Value: 1004, HasValue: true}, Point{Label: "03:00", Value: 1003, HasValue: true})
+
+	tests := []struct {
+		name  string
+		chart Sparkline
+	}{
+		{
+			name: "sparkline_highlight",
+			chart: Sparkline{
+				Title:         "気圧",
+				Class:         "sparkline",
+				Layout:        layout,
+				Points:        points(hourLabels, 1013, 1012, 1010, 1007, 1004, 1003, 1004, 1006),
+				Unit:          "hPa",
+				HighlightFrom: 1,
+				HighlightTo:   4,
+			},
+		},
+		{
+			name: "sparkline_missing",
+			chart: Sparkline{
+				Title:  "気圧",
+				Class:  "sparkline",
+				Layout: layout,
+				Points: missing,
+				Unit:   "hPa",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertGolden(t, tt.name, tt.chart.SVG())
+		})
+	}
+}
+
+// RangeChart の golden テスト
+func TestRangeChartGolden(t *testing.T) {
+	layout := Layout{Width: 240, Height: 80, PaddingTop: 14, PaddingRight: 4, PaddingBottom: 26, PaddingLeft: 4}
+	day := func(label string, low, high float64) RangePoint {
+		return RangePoint{Label: label, Low: low, HasLow: true, High: high, HasHigh: true}
+	}
+
+	tests := []struct {
+		name  string
+		chart RangeChart
+	}{
+		{
+			name: "range_days",
+			chart: RangeChart{
+				Title:         "最高・最低気温の推移",
+				Class:         "range-chart",
+				Layout:        layout,
+				Points:        []RangePoint{day("10/15", 11, 19), {Label: "10/16"}, day("10/17", 12, 20), {Label: "10/18", High: 15, HasHigh: true}},
+				Unit:          "℃",
+				HighlightLast: true,
+			},
+		},
+		{
+			name: "range_empty",
+			chart: RangeChart{
+				Title:  "最高・最低気温の推移",
+				Class:  "range-chart",
+				Layout: layout,
+				Points: []RangePoint{{Label: "10/17"}, {Label: "10/18"}},
+				Unit:   "℃",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertGolden(t, tt.name, tt.chart.SVG())
+		})
+	}
+}
+
+// labelStep のテスト
+func TestLabelStep(t *testing.T) {
+	layout := Layout{Width: 800, PaddingLeft: 32, PaddingRight: 8}
+	tests := []struct {
+		name     string
+		count    int
+		expected int
+	}{
+		{name: "点がない場合", count: 0, expected: 1},
+		{name: "間隔が十分な場合", count: 16, expected: 1},
+		{name: "間隔が狭い場合は間引く", count: 24, expected: 2},
+		{name: "さらに狭い場合", count: 48, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := labelStep(layout, tt.count); result != tt.expected {
+				t.Errorf("期待: %d, 実際: %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+// smoothPath のテスト
+func TestSmoothPathDoesNotOvershoot(t *testing.T) {
+	// 平坦な区間を含む場合でも制御点が隣接する点の範囲を超えない
+	xs := []float64{0, 10, 20, 30}
+	ys := []float64{50, 10, 10, 50}
+	d := smoothPath(xs, ys)
+	expected := "M0,50 C3.3,36.7 6.7,10 10,10 C13.3,10 16.7,10 20,10 C23.3,10 26.7,36.7 30,50"
+	if d != expected {
+		t.Errorf("期待: %s, 実際: %s", expected, d)
+	}
+}
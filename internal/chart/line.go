@@ -0,0 +1,202 @@
+package chart
+
+import "math"
+
+// LineChart は折れ線グラフ
+type LineChart struct {
+	Title          string      // グラフのタイトル (title要素とaria-labelに使用)
+	Class          string      // svg要素のclass属性
+	Layout         Layout      // 大きさと余白
+	Points         []Point     // 値
+	Unit           string      // 値ラベルの単位 (例: ℃)
+	Smooth         bool        // 曲線で滑らかにつなぐ
+	AnnotateMinMax bool        // 最高・最低の点を強調する
+	Separators     []Separator // 日付の境界などの区切り線
+	Bands          []Band      // 夜間などの網掛けする区間
+}
+
+// SVG は折れ線グラフのSVGを生成する
+func (c LineChart) SVG() string {
+	w := &svgWriter{}
+	w.open(c.Layout, c.Class, c.Title)
+
+	count := len(c.Points)
+	minValue, maxValue, ok := valueRange(c.Points)
+	if !ok {
+		w.xLabels(c.Layout, c.Points)
+		return w.close()
+	}
+	scaleMin, scaleMax := minValue, maxValue
+	if scaleMin == scaleMax {
+		// 値がすべて同じ場合は中央に描画する
+		scaleMin--
+		scaleMax++
+	}
+	yOf := func(value float64) float64 {
+		return c.Layout.plotTop() + (scaleMax-value)/(scaleMax-scaleMin)*c.Layout.plotHeight()
+	}
+
+	// 網掛けは軸や線の下に描く
+	w.bands(c.Layout, count, c.Bands)
+
+	// 軸
+	w.line(c.Layout.plotLeft(), c.Layout.plotBottom(), c.Layout.plotRight(), c.Layout.plotBottom(), false)
+	w.line(c.Layout.plotLeft(), c.Layout.plotTop(), c.Layout.plotLeft(), c.Layout.plotBottom(), false)
+	w.text(c.Layout.plotLeft()-3, yOf(maxValue)+3, "end", 8, false, formatValue(maxValue, c.Unit))
+	if minValue != maxValue {
+		w.text(c.Layout.plotLeft()-3, yOf(minValue)+3, "end", 8, false, formatValue(minValue, c.Unit))
+	}
+
+	w.separators(c.Layout, count, c.Separators)
+
+	// 線 (値のない点で線を区切る)
+	for _, run := range valueRuns(c.Points) {
+		xs := make([]float64, len(run))
+		ys := make([]float64, len(run))
+		for i, index := range run {
+			xs[i] = c.Layout.slotCenter(index, count)
+			ys[i] = yOf(c.Points[index].Value)
+		}
+		if len(run) < 2 {
+			continue
+		}
+		if c.Smooth {
+			w.printf(`<path d="%s" fill="none" stroke="#000" stroke-width="2"/>`+"\n", smoothPath(xs, ys))
+		} else {
+			w.printf(`<polyline points="%s" fill="none" stroke="#000" stroke-width="2"/>`+"\n", polylinePoints(xs, ys))
+		}
+	}
+
+	// 点と値ラベル (最低値のラベルも横軸ラベルと重ならないよう点の上に置く)
+	maxIndex, minIndex := -1, -1
+	if c.AnnotateMinMax && minValue != maxValue {
+		maxIndex, minIndex = extremeIndexes(c.Points)
+	}
+	for i, point := range c.Points {
+		if !point.HasValue {
+			continue
+		}
+		x, y := c.Layout.slotCenter(i, count), yOf(point.Value)
+		switch i {
+		case maxIndex:
+			w.printf(`<circle cx="%s" cy="%s" r="4" fill="#000"/>`+"\n", formatNumber(x), formatNumber(y))
+			w.text(x, y-7, "middle", 11, true, "▲"+formatValue(point.Value, c.Unit))
+		case minIndex:
+			w.printf(`<circle cx="%s" cy="%s" r="4" fill="#000"/>`+"\n", formatNumber(x), formatNumber(y))
+			w.text(x, y-7, "middle", 11, true, "▼"+formatValue(point.Value, c.Unit))
+		default:
+			w.printf(`<circle cx="%s" cy="%s" r="2.5" fill="#000"/>`+"\n", formatNumber(x), formatNumber(y))
+			w.text(x, y-6, "middle", 10, false, formatValue(point.Value, c.Unit))
+		}
+	}
+
+	w.xLabels(c.Layout, c.Points)
+	return w.close()
+}
+
+// valueRange は有効な値の最小値と最大値を返す
+func valueRange(points []Point) (minValue, maxValue float64, ok bool) {
+	for _, point := range points {
+		if !point.HasValue {
+			continue
+		}
+		if !ok {
+			minValue, maxValue, ok = point.Value, point.Value, true
+			continue
+		}
+		minValue = math.Min(minValue, point.Value)
+		maxValue = math.Max(maxValue, point.Value)
+	}
+	return minValue, maxValue, ok
+}
+
+// extremeIndexes は最高値と最低値の点の位置を返す (同じ値が複数ある場合は最初の点)
+func extremeIndexes(points []Point) (maxIndex, minIndex int) {
+	maxIndex, minIndex = -1, -1
+	for i, point := range points {
+		if !point.HasValue {
+			continue
+		}
+		if maxIndex < 0 || point.Value > points[maxIndex].Value {
+			maxIndex = i
+		}
+		if minIndex < 0 || point.Value < points[minIndex].Value {
+			minIndex = i
+		}
+	}
+	return maxIndex, minIndex
+}
+
+// valueRuns は値が連続する点の位置をまとめて返す
+func valueRuns(points []Point) [][]int {
+	var runs [][]int
+	var current []int
+	for i, point := range points {
+		if point.HasValue {
+			current = append(current, i)
+			continue
+		}
+		if len(current) > 0 {
+			runs = append(runs, current)
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		runs = append(runs, current)
+	}
+	return runs
+}
+
+// polylinePoints はpolyline要素のpoints属性を生成する
+func polylinePoints(xs, ys []float64) string {
+	result := ""
+	for i := range xs {
+		if i > 0 {
+			result += " "
+		}
+		result += formatNumber(xs[i]) + "," + formatNumber(ys[i])
+	}
+	return result
+}
+
+// smoothPath は点を単調な3次スプライン(Fritsch-Carlson法)でつなぐpath要素のd属性を生成する
+// 単調性を保つため、極値の前後で曲線が値を行き過ぎることがない
+func smoothPath(xs, ys []float64) string {
+	n := len(xs)
+	slopes := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		slopes[i] = (ys[i+1] - ys[i]) / (xs[i+1] - xs[i])
+	}
+
+	tangents := make([]float64, n)
+	tangents[0] = slopes[0]
+	tangents[n-1] = slopes[n-2]
+	for i := 1; i < n-1; i++ {
+		if slopes[i-1]*slopes[i] <= 0 {
+			tangents[i] = 0
+		} else {
+			tangents[i] = (slopes[i-1] + slopes[i]) / 2
+		}
+	}
+	for i := 0; i < n-1; i++ {
+		if slopes[i] == 0 {
+			tangents[i], tangents[i+1] = 0, 0
+			continue
+		}
+		a, b := tangents[i]/slopes[i], tangents[i+1]/slopes[i]
+		if s := a*a + b*b; s > 9 {
+			t := 3 / math.Sqrt(s)
+			tangents[i] = t * a * slopes[i]
+			tangents[i+1] = t * b * slopes[i]
+		}
+	}
+
+	d := "M" + formatNumber(xs[0]) + "," + formatNumber(ys[0])
+	for i := 0; i < n-1; i++ {
+		h := (xs[i+1] - xs[i]) / 3
+		d += " C" + formatNumber(xs[i]+h) + "," + formatNumber(ys[i]+tangents[i]*h) +
+			" " + formatNumber(xs[i+1]-h) + "," + formatNumber(ys[i+1]-tangents[i+1]*h) +
+			" " + formatNumber(xs[i+1]) + "," + formatNumber(ys[i+1])
+	}
+	return d
+}
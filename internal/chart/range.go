@@ -0,0 +1,82 @@
+package chart
+
+import "math"
+
+// RangePoint は範囲グラフの1点 (1日の最低気温と最高気温など)
+type RangePoint struct {
+	Label   string  // 横軸ラベル
+	Low     float64 // 下端の値
+	HasLow  bool    // 下端の値が有効かどうか
+	High    float64 // 上端の値
+	HasHigh bool    // 上端の値が有効かどうか
+}
+
+// RangeChart は各点の下端から上端までを縦棒で示すグラフ
+// 日ごとの最高・最低気温の推移のように、幅のある値を並べて比べるのに使う
+type RangeChart struct {
+	Title         string       // グラフのタイトル (title要素とaria-labelに使用)
+	Class         string       // svg要素のclass属性
+	Layout        Layout       // 大きさと余白 (上下の余白に値ラベルを表示する)
+	Points        []RangePoint // 値 (値のない点は「-」を表示する)
+	Unit          string       // 値ラベルの単位 (例: ℃)
+	HighlightLast bool         // 最後の点 (今日など) を塗りつぶして強調する (それ以外は白抜き)
+}
+
+// SVG は範囲グラフのSVGを生成する
+func (c RangeChart) SVG() string {
+	w := &svgWriter{}
+	w.open(c.Layout, c.Class, c.Title)
+
+	count := len(c.Points)
+	labels := make([]Point, count)
+	var values []Point
+	for i, point := range c.Points {
+		labels[i] = Point{Label: point.Label}
+		values = append(values, Point{Value: point.Low, HasValue: point.HasLow}, Point{Value: point.High, HasValue: point.HasHigh})
+	}
+	minValue, maxValue, ok := valueRange(values)
+	if !ok {
+		w.xLabels(c.Layout, labels)
+		return w.close()
+	}
+	if minValue == maxValue {
+		// 値がすべて同じ場合は中央に描画する
+		minValue--
+		maxValue++
+	}
+	yOf := func(value float64) float64 {
+		return c.Layout.plotTop() + (maxValue-value)/(maxValue-minValue)*c.Layout.plotHeight()
+	}
+
+	barWidth := math.Min(c.Layout.slotWidth(count)*0.4, 12)
+	for i, point := range c.Points {
+		x := c.Layout.slotCenter(i, count)
+		fill := "#fff"
+		if c.HighlightLast && i == count-1 {
+			fill = "#000"
+		}
+		bold := fill == "#000"
+
+		switch {
+		case point.HasLow && point.HasHigh:
+			top, bottom := yOf(point.High), yOf(point.Low)
+			w.printf(`<rect x="%s" y="%s" width="%s" height="%s" fill="%s" stroke="#000" stroke-width="1.5"/>`+"\n",
+				formatNumber(x-barWidth/2), formatNumber(top), formatNumber(barWidth), formatNumber(math.Max(bottom-top, 1)), fill)
+			w.text(x, top-3, "middle", 9, bold, formatValue(point.High, c.Unit))
+			w.text(x, bottom+10, "middle", 9, bold, formatValue(point.Low, c.Unit))
+		case point.HasHigh:
+			y := yOf(point.High)
+			w.printf(`<circle cx="%s" cy="%s" r="3" fill="%s" stroke="#000" stroke-width="1.5"/>`+"\n", formatNumber(x), formatNumber(y), fill)
+			w.text(x, y-5, "middle", 9, bold, formatValue(point.High, c.Unit))
+		case point.HasLow:
+			y := yOf(point.Low)
+			w.printf(`<circle cx="%s" cy="%s" r="3" fill="%s" stroke="#000" stroke-width="1.5"/>`+"\n", formatNumber(x), formatNumber(y), fill)
+			w.text(x, y+12, "middle", 9, bold, formatValue(point.Low, c.Unit))
+		default:
+			w.text(x, c.Layout.plotBottom(), "middle", 9, false, "-")
+		}
+	}
+
+	w.xLabels(c.Layout, labels)
+	return w.close()
+}
@@ -0,0 +1,80 @@
+package chart
+
+// Sparkline は軸や目盛りのない小さな折れ線グラフ
+// 値の推移だけを示し、最初と最後の値を左右の余白に表示する
+type Sparkline struct {
+	Title         string  // グラフのタイトル (title要素とaria-labelに使用)
+	Class         string  // svg要素のclass属性
+	Layout        Layout  // 大きさと余白 (左右の余白に最初と最後の値を表示する)
+	Points        []Point // 値
+	Unit          string  // 値ラベルの単位 (例: hPa)
+	HighlightFrom int     // 太線で強調する区間の最初の点 (HighlightTo より小さい場合のみ強調する)
+	HighlightTo   int     // 太線で強調する区間の最後の点
+}
+
+// SVG はスパークラインのSVGを生成する
+func (c Sparkline) SVG() string {
+	w := &svgWriter{}
+	w.open(c.Layout, c.Class, c.Title)
+
+	count := len(c.Points)
+	minValue, maxValue, ok := valueRange(c.Points)
+	if !ok {
+		return w.close()
+	}
+	scaleMin, scaleMax := minValue, maxValue
+	if scaleMin == scaleMax {
+		// 値がすべて同じ場合は中央に描画する
+		scaleMin--
+		scaleMax++
+	}
+	yOf := func(value float64) float64 {
+		return c.Layout.plotTop() + (scaleMax-value)/(scaleMax-scaleMin)*c.Layout.plotHeight()
+	}
+
+	// 線 (値のない点で線を区切る)
+	for _, run := range valueRuns(c.Points) {
+		if len(run) < 2 {
+			continue
+		}
+		xs := make([]float64, len(run))
+		ys := make([]float64, len(run))
+		for i, index := range run {
+			xs[i] = c.Layout.slotCenter(index, count)
+			ys[i] = yOf(c.Points[index].Value)
+		}
+		w.printf(`<polyline points="%s" fill="none" stroke="#000" stroke-width="1.5"/>`+"\n", polylinePoints(xs, ys))
+
+		// 強調する区間 (区間内の点だけを太線で重ねる)
+		var hxs, hys []float64
+		for i, index := range run {
+			if c.HighlightFrom < c.HighlightTo && index >= c.HighlightFrom && index <= c.HighlightTo {
+				hxs = append(hxs, xs[i])
+				hys = append(hys, ys[i])
+			}
+		}
+		if len(hxs) >= 2 {
+			w.printf(`<polyline points="%s" fill="none" stroke="#000" stroke-width="4"/>`+"\n", polylinePoints(hxs, hys))
+		}
+	}
+
+	// 最初と最後の値
+	first, last := -1, -1
+	for i, point := range c.Points {
+		if !point.HasValue {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	firstX, lastX := c.Layout.slotCenter(first, count), c.Layout.slotCenter(last, count)
+	w.printf(`<circle cx="%s" cy="%s" r="2.5" fill="#000"/>`+"\n", formatNumber(lastX), formatNumber(yOf(c.Points[last].Value)))
+	w.text(firstX-4, yOf(c.Points[first].Value)+3, "end", 9, false, formatValue(c.Points[first].Value, c.Unit))
+	if last != first {
+		w.text(lastX+4, yOf(c.Points[last].Value)+3, "start", 9, true, formatValue(c.Points[last].Value, c.Unit))
+	}
+
+	return w.close()
+}
@@ -0,0 +1,210 @@
+// Package city は天気予報API (weather.tsukumijima.net) の都市コードを扱う。
+// 気象庁の一次細分区域 (primary_area.xml) の一覧を同梱しており、
+// 地点名・ローマ字での検索と都市コードの検証をネットワークなしで行える。
+package city
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed primary_area.csv
+var primaryAreaCSV []byte
+
+// Area は一次細分区域の代表地点
+type Area struct {
+	Code             string  // 都市コード (例: 130010)
+	Name             string  // 地点名 (例: 東京)
+	Romaji           string  // 地点名のローマ字 (例: tokyo)
+	Prefecture       string  // 都道府県名 (例: 東京都)
+	PrefectureRomaji string  // 都道府県名のローマ字 (例: tokyo)
+	Latitude         float64 // 代表地点の緯度
+	Longitude        float64 // 代表地点の経度
+}
+
+var (
+	loadOnce sync.Once
+	areas    []Area
+	byCode   map[string]Area
+)
+
+// load は同梱の一覧を初回のみパースする
+func load() {
+	loadOnce.Do(func() {
+		parsed, err := parseAreas(primaryAreaCSV)
+		if err != nil {
+			panic(fmt.Sprintf("city: 同梱の地点一覧が不正です: %v", err))
+		}
+		areas = parsed
+		byCode = make(map[string]Area, len(parsed))
+		for _, area := range parsed {
+			byCode[area.Code] = area
+		}
+	})
+}
+
+// parseAreas はCSV形式の地点一覧をパースする
+func parseAreas(content []byte) ([]Area, error) {
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("地点が含まれていません")
+	}
+
+	var parsed []Area
+	for i, record := range records[1:] {
+		if len(record) != 7 {
+			return nil, fmt.Errorf("%d行目: 列数が不正です", i+2)
+		}
+		latitude, err := strconv.ParseFloat(record[5], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%d行目: 緯度が不正です: %w", i+2, err)
+		}
+		longitude, err := strconv.ParseFloat(record[6], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%d行目: 経度が不正です: %w", i+2, err)
+		}
+		parsed = append(parsed, Area{
+			Code:             record[0],
+			Name:             record[1],
+			Romaji:           record[2],
+			Prefecture:       record[3],
+			PrefectureRomaji: record[4],
+			Latitude:         latitude,
+			Longitude:        longitude,
+		})
+	}
+	return parsed, nil
+}
+
+// All はすべての地点を都市コード順で返す
+func All() []Area {
+	load()
+	result := make([]Area, len(areas))
+	copy(result, areas)
+	return result
+}
+
+// Lookup は都市コードから地点を返す
+func Lookup(code string) (Area, bool) {
+	load()
+	area, ok := byCode[strings.TrimSpace(code)]
+	return area, ok
+}
+
+// earthRadiusKm は地球の平均半径 (km)
+const earthRadiusKm = 6371.0
+
+// Distance は2地点間の大圏距離 (km) を返す
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// DistanceTo は代表地点から指定した地点までの距離 (km) を返す
+func (a Area) DistanceTo(latitude, longitude float64) float64 {
+	return Distance(a.Latitude, a.Longitude, latitude, longitude)
+}
+
+// Search は地点名・都道府県名 (日本語またはローマ字) で地点を検索する
+// 地点名の完全一致、前方一致、部分一致の順に並べて返す
+func Search(query string) []Area {
+	load()
+	query = normalizeQuery(query)
+	if query == "" {
+		return nil
+	}
+
+	results := searchAreas(query)
+	if len(results) == 0 {
+		// 「大阪市」「神奈川県」のような接尾辞付きの入力にも対応する
+		if trimmed := trimAdministrativeSuffix(query); trimmed != query && trimmed != "" {
+			results = searchAreas(trimmed)
+		}
+	}
+	return results
+}
+
+// 検索結果の順位
+const (
+	rankExact = iota
+	rankPrefix
+	rankPartial
+	rankPrefecture
+)
+
+func searchAreas(query string) []Area {
+	type rankedArea struct {
+		area Area
+		rank int
+	}
+
+	var ranked []rankedArea
+	for _, area := range areas {
+		if rank, ok := matchRank(area, query); ok {
+			ranked = append(ranked, rankedArea{area: area, rank: rank})
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].rank < ranked[j].rank
+	})
+
+	results := make([]Area, len(ranked))
+	for i, r := range ranked {
+		results[i] = r.area
+	}
+	return results
+}
+
+// matchRank は地点が検索語に一致する順位を返す
+// ローマ字は検索語と同じく正規化して比べる (例: toyooka は toyoka として比べる)
+func matchRank(area Area, query string) (int, bool) {
+	romaji := normalizeQuery(area.Romaji)
+	switch {
+	case area.Name == query || romaji == query:
+		return rankExact, true
+	case strings.HasPrefix(area.Name, query) || strings.HasPrefix(romaji, query):
+		return rankPrefix, true
+	case strings.Contains(area.Name, query) || strings.Contains(romaji, query):
+		return rankPartial, true
+	case strings.Contains(area.Prefecture, query) || strings.HasPrefix(normalizeQuery(area.PrefectureRomaji), query):
+		return rankPrefecture, true
+	}
+	return 0, false
+}
+
+// normalizeQuery は検索語を比較用に正規化する
+// ローマ字は小文字にし、長音記号やハイフン、空白を取り除き、長音の表記 (ou・uu・oo) を1文字にする
+func normalizeQuery(query string) string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	replacer := strings.NewReplacer(
+		" ", "", "　", "", "-", "",
+		"ā", "a", "ī", "i", "ū", "u", "ē", "e", "ō", "o",
+		"ou", "o", "uu", "u", "oo", "o",
+	)
+	return replacer.Replace(query)
+}
+
+// trimAdministrativeSuffix は「市」「都」「道」「府」「県」などの接尾辞を取り除く
+func trimAdministrativeSuffix(query string) string {
+	for _, suffix := range []string{"市", "町", "村", "都", "道", "府", "県", "shi", "ken", "fu"} {
+		if strings.HasSuffix(query, suffix) {
+			return strings.TrimSuffix(query, suffix)
+		}
+	}
+	return query
+}
@@ -0,0 +1,158 @@
+package city
+
+import (
+	"math"
+	"testing"
+)
+
+// 同梱データのテスト
+func TestAll(t *testing.T) {
+	all := All()
+	if len(all) != 142 {
+		t.Errorf("地点数: 期待=142, 実際=%d", len(all))
+	}
+
+	codes := make(map[string]bool)
+	for _, area := range all {
+		if codes[area.Code] {
+			t.Errorf("都市コードが重複しています: %s", area.Code)
+		}
+		codes[area.Code] = true
+
+		if len(area.Code) != 6 {
+			t.Errorf("都市コードの桁数が不正です: %s", area.Code)
+		}
+		if area.Name == "" || area.Romaji == "" || area.Prefecture == "" || area.PrefectureRomaji == "" {
+			t.Errorf("地点名が空です: %+v", area)
+		}
+		// 日本の範囲内 (与那国島〜稚内、与那国島〜南鳥島付近)
+		if area.Latitude < 24 || area.Latitude > 46 || area.Longitude < 122 || area.Longitude > 146 {
+			t.Errorf("座標が日本の範囲外です: %+v", area)
+		}
+	}
+}
+
+// Lookup のテスト
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+		found    bool
+	}{
+		{code: "016010", expected: "札幌", found: true},
+		{code: "130010", expected: "東京", found: true},
+		{code: "140010", expected: "横浜", found: true},
+		{code: "230010", expected: "名古屋", found: true},
+		{code: "270000", expected: "大阪", found: true},
+		{code: "260010", expected: "京都", found: true},
+		{code: "400010", expected: "福岡", found: true},
+		{code: "471010", expected: "那覇", found: true},
+		{code: " 370000 ", expected: "高松", found: true},
+		{code: "999999", found: false},
+		{code: "", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			area, ok := Lookup(tt.code)
+			if ok != tt.found {
+				t.Fatalf("見つかったか: 期待=%v, 実際=%v", tt.found, ok)
+			}
+			if ok && area.Name != tt.expected {
+				t.Errorf("地点名: 期待=%s, 実際=%s", tt.expected, area.Name)
+			}
+		})
+	}
+}
+
+// Search のテスト
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedFirst string
+		expectedCount int // 0の場合は件数をチェックしない
+	}{
+		{name: "日本語の地点名", query: "大阪", expectedFirst: "270000"},
+		{name: "ローマ字", query: "osaka", expectedFirst: "270000"},
+		{name: "ローマ字の大文字と長音記号", query: "Ōsaka", expectedFirst: "270000"},
+		{name: "ローマ字の長音表記", query: "kouchi", expectedFirst: "390010"},
+		{name: "ローマ字の長音表記 (oo)", query: "oosaka", expectedFirst: "270000"},
+		{name: "ローマ字の長音表記 (oo) が複数", query: "tookyoo", expectedFirst: "130010"},
+		{name: "地点名のローマ字に oo を含む", query: "toyooka", expectedFirst: "280020"},
+		{name: "接尾辞付き", query: "大阪市", expectedFirst: "270000"},
+		{name: "前方一致", query: "八", expectedFirst: "020030", expectedCount: 3},
+		{name: "都道府県名", query: "北海道", expectedFirst: "011000", expectedCount: 16},
+		{name: "都道府県名の接尾辞付き", query: "長崎県", expectedFirst: "420010", expectedCount: 4},
+		{name: "ローマ字の都道府県名", query: "okinawa", expectedFirst: "471010", expectedCount: 7},
+		{name: "地点名の完全一致を優先", query: "yamaguchi", expectedFirst: "350020", expectedCount: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Search(tt.query)
+			if len(results) == 0 {
+				t.Fatalf("検索結果が空です: %s", tt.query)
+			}
+			if results[0].Code != tt.expectedFirst {
+				t.Errorf("最初の結果: 期待=%s, 実際=%s (%s)", tt.expectedFirst, results[0].Code, results[0].Name)
+			}
+			if tt.expectedCount != 0 && len(results) != tt.expectedCount {
+				t.Errorf("件数: 期待=%d, 実際=%d", tt.expectedCount, len(results))
+			}
+		})
+	}
+
+	t.Run("該当なし", func(t *testing.T) {
+		if results := Search("ニューヨーク"); len(results) != 0 {
+			t.Errorf("期待: 0件, 実際: %d件", len(results))
+		}
+	})
+
+	t.Run("空の検索語", func(t *testing.T) {
+		if results := Search("  "); results != nil {
+			t.Errorf("期待: nil, 実際: %v", results)
+		}
+	})
+}
+
+// parseAreas のエラーハンドリングのテスト
+func TestParseAreasError(t *testing.T) {
+	invalidInputs := []string{
+		"",
+		"code,name,romaji,prefecture,prefecture_romaji,latitude,longitude\n",
+		"code,name,romaji,prefecture,prefecture_romaji,latitude,longitude\n130010,東京,tokyo,東京都,tokyo,abc,139.692\n",
+		"code,name,romaji,prefecture,prefecture_romaji,latitude,longitude\n130010,東京,tokyo\n",
+	}
+
+	for _, input := range invalidInputs {
+		if _, err := parseAreas([]byte(input)); err == nil {
+			t.Errorf("期待: エラー, 実際: nil (%q)", input)
+		}
+	}
+}
+
+// Distance のテスト
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat1     float64
+		lon1     float64
+		lat2     float64
+		lon2     float64
+		expected float64
+	}{
+		{"同じ地点", 35.69, 139.69, 35.69, 139.69, 0},
+		{"東京-大阪", 35.69, 139.69, 34.69, 135.50, 400},
+		{"東京-札幌", 35.69, 139.69, 43.06, 141.35, 831},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := Distance(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+			if math.Abs(actual-tt.expected) > 5 {
+				t.Errorf("期待: 約%.0fkm, 実際: %.1fkm", tt.expected, actual)
+			}
+		})
+	}
+}
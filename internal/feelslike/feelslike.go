@@ -0,0 +1,108 @@
+// Package feelslike は気温・湿度・風速から体感温度を計算する。
+// 寒いときは風による冷え (風冷温度)、暑いときは湿度による蒸し暑さ (熱指数・Steadman の体感温度) を使い、
+// どちらにも当たらないときは気温をそのまま体感温度とする。
+package feelslike
+
+import "math"
+
+// 計算方法を切り替える気温の境目 (℃)
+const (
+	ColdThreshold = 10.0 // この気温以下で風があれば風冷温度を使う
+	HotThreshold  = 27.0 // この気温以上で湿度が分かれば熱指数・Steadman の体感温度を使う
+)
+
+// MinWindChillSpeed は風冷温度を使う最小の風速 (m/s、時速4.8km)
+const MinWindChillSpeed = 4.8 / 3.6
+
+// Method は体感温度の計算方法
+type Method int
+
+const (
+	MethodAirTemperature Method = iota // 気温をそのまま使う
+	MethodWindChill                    // 風冷温度 (寒さ)
+	MethodHeatIndex                    // 熱指数 (暑さ、風速が分からない場合)
+	MethodSteadman                     // Steadman の体感温度 (暑さ、風速も分かる場合)
+)
+
+// Label は計算方法の表示名を返す
+func (m Method) Label() string {
+	switch m {
+	case MethodWindChill:
+		return "風による冷え"
+	case MethodHeatIndex, MethodSteadman:
+		return "湿度による蒸し暑さ"
+	default:
+		return "気温"
+	}
+}
+
+// Conditions は体感温度の計算に使う気象条件
+type Conditions struct {
+	Temperature  float64 // 気温 (℃)
+	Humidity     float64 // 相対湿度 (%)
+	HasHumidity  bool
+	WindSpeed    float64 // 風速 (m/s)
+	HasWindSpeed bool
+}
+
+// Result は体感温度の計算結果
+type Result struct {
+	Value  float64 // 体感温度 (℃)
+	Method Method
+}
+
+// Calculate は気象条件から体感温度を計算する
+// 寒いときは風速、暑いときは湿度が必要で、足りない場合は気温をそのまま返す
+func Calculate(c Conditions) Result {
+	switch {
+	case c.Temperature <= ColdThreshold && c.HasWindSpeed && c.WindSpeed >= MinWindChillSpeed:
+		return Result{Value: WindChill(c.Temperature, c.WindSpeed), Method: MethodWindChill}
+	case c.Temperature >= HotThreshold && c.HasHumidity && c.HasWindSpeed:
+		return Result{Value: Steadman(c.Temperature, c.Humidity, c.WindSpeed), Method: MethodSteadman}
+	case c.Temperature >= HotThreshold && c.HasHumidity:
+		return Result{Value: HeatIndex(c.Temperature, c.Humidity), Method: MethodHeatIndex}
+	default:
+		return Result{Value: c.Temperature, Method: MethodAirTemperature}
+	}
+}
+
+// WindChill は気温 (℃) と風速 (m/s) から風冷温度を計算する
+// 米国気象局・カナダ環境省の式 (風速は時速kmで計算する)
+func WindChill(temperature, windSpeed float64) float64 {
+	v := math.Pow(windSpeed*3.6, 0.16)
+	return round1(13.12 + 0.6215*temperature - 11.37*v + 0.3965*temperature*v)
+}
+
+// HeatIndex は気温 (℃) と相対湿度 (%) から熱指数を計算する
+// 米国気象局の算出手順 (Rothfusz の回帰式と補正、計算は華氏) に従う
+func HeatIndex(temperature, humidity float64) float64 {
+	t := temperature*9/5 + 32
+	rh := humidity
+
+	// 低めの気温では簡易式を使う
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*rh -
+			0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+			0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+		switch {
+		case rh < 13 && t >= 80 && t <= 112:
+			hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		case rh > 85 && t >= 80 && t <= 87:
+			hi += (rh - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return round1((hi - 32) * 5 / 9)
+}
+
+// Steadman は気温 (℃)、相対湿度 (%)、風速 (m/s) から Steadman の体感温度 (日射なし) を計算する
+// オーストラリア気象局が使う式で、水蒸気圧で蒸し暑さを、風速で涼しさを表す
+func Steadman(temperature, humidity, windSpeed float64) float64 {
+	vapor := humidity / 100 * 6.105 * math.Exp(17.27*temperature/(237.7+temperature))
+	return round1(temperature + 0.33*vapor - 0.70*windSpeed - 4.00)
+}
+
+// round1 は小数第1位に丸める
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,90 @@
+package feelslike
+
+import "testing"
+
+// Calculate のテスト
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions Conditions
+		expected   Result
+	}{
+		{
+			name:       "寒くて風がある (風冷温度)",
+			conditions: Conditions{Temperature: -10, WindSpeed: 20 / 3.6, HasWindSpeed: true},
+			expected:   Result{Value: -17.9, Method: MethodWindChill},
+		},
+		{
+			name:       "寒いが風がほとんどない",
+			conditions: Conditions{Temperature: 5, WindSpeed: 1, HasWindSpeed: true},
+			expected:   Result{Value: 5, Method: MethodAirTemperature},
+		},
+		{
+			name:       "暑くて湿度と風速が分かる (Steadman)",
+			conditions: Conditions{Temperature: 30, Humidity: 60, HasHumidity: true, WindSpeed: 3, HasWindSpeed: true},
+			expected:   Result{Value: 32.3, Method: MethodSteadman},
+		},
+		{
+			name:       "暑くて湿度だけ分かる (熱指数)",
+			conditions: Conditions{Temperature: 30, Humidity: 60, HasHumidity: true},
+			expected:   Result{Value: 32.8, Method: MethodHeatIndex},
+		},
+		{
+			name:       "暑いが湿度が分からない",
+			conditions: Conditions{Temperature: 30, WindSpeed: 3, HasWindSpeed: true},
+			expected:   Result{Value: 30, Method: MethodAirTemperature},
+		},
+		{
+			name:       "過ごしやすい気温",
+			conditions: Conditions{Temperature: 20, Humidity: 60, HasHumidity: true, WindSpeed: 5, HasWindSpeed: true},
+			expected:   Result{Value: 20, Method: MethodAirTemperature},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := Calculate(tt.conditions)
+			if actual != tt.expected {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// HeatIndex のテスト (米国気象局の早見表の値)
+func TestHeatIndex(t *testing.T) {
+	tests := []struct {
+		name        string
+		temperature float64
+		humidity    float64
+		expected    float64
+	}{
+		{name: "華氏90度・湿度70%", temperature: 32.2, humidity: 70, expected: 41.0},
+		{name: "低めの気温は簡易式", temperature: 27, humidity: 40, expected: 26.9},
+		{name: "乾燥した猛暑の補正", temperature: 35, humidity: 10, expected: 31.9},
+		{name: "高湿度の補正", temperature: 28, humidity: 90, expected: 34.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := HeatIndex(tt.temperature, tt.humidity); actual != tt.expected {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// WindChill のテスト
+func TestWindChill(t *testing.T) {
+	// 風が強いほど寒く感じる
+	if weak, strong := WindChill(5, 3), WindChill(5, 12.5); weak != 2.5 || strong != -1 {
+		t.Errorf("期待: 2.5, -1, 実際: %v, %v", weak, strong)
+	}
+}
+
+// Method.Label のテスト
+func TestMethodLabel(t *testing.T) {
+	if MethodWindChill.Label() != "風による冷え" || MethodSteadman.Label() != "湿度による蒸し暑さ" || MethodAirTemperature.Label() != "気温" {
+		t.Error("計算方法の表示名が不正です")
+	}
+}
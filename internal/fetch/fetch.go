@@ -0,0 +1,156 @@
+// Package fetch は外部データ (天気API、ニュースRSS、ICSなど) の取得とディスクキャッシュを行う。
+// 取得に成功した内容はキャッシュに保存し、取得に失敗した場合は前回のキャッシュを返す。
+// これにより一時的なAPI障害でもサンプルデータではなく直近の実データを表示できる。
+package fetch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Fetcher はデータの取得とキャッシュを行う
+type Fetcher struct {
+	Client   *http.Client
+	CacheDir string           // キャッシュの保存先 (空の場合はキャッシュしない)
+	MaxAge   time.Duration    // この時間内に取得したキャッシュは再取得せずに使う (0 の場合は常に取得する)
+	Now      func() time.Time // 現在時刻 (テスト用)
+}
+
+// Result は取得結果
+type Result struct {
+	Body      []byte
+	FetchedAt time.Time // データを取得した時刻 (キャッシュの場合は保存した時刻)
+	FromCache bool      // キャッシュから返したかどうか
+	Stale     bool      // 取得に失敗したため古いキャッシュを返したかどうか
+	Err       error     // Stale の場合の取得エラー
+}
+
+// New は指定したタイムアウトとキャッシュの保存先で Fetcher を生成する
+func New(cacheDir string, timeout time.Duration) *Fetcher {
+	return &Fetcher{
+		Client:   &http.Client{Timeout: timeout},
+		CacheDir: cacheDir,
+		Now:      time.Now,
+	}
+}
+
+// WithMaxAge は MaxAge を変更した Fetcher を返す
+// 更新頻度の低いデータや呼び出し回数に制限のあるAPIに使う
+func (f *Fetcher) WithMaxAge(maxAge time.Duration) *Fetcher {
+	copied := *f
+	copied.MaxAge = maxAge
+	return &copied
+}
+
+// IsLocal は取得元がローカルファイルかどうかを返す
+func IsLocal(source string) bool {
+	return !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://")
+}
+
+// Get は取得元 (URL またはローカルファイルのパス) からデータを取得する
+// 取得に失敗した場合、キャッシュがあれば Stale=true としてキャッシュを返す
+func (f *Fetcher) Get(source string) (Result, error) {
+	if IsLocal(source) {
+		body, err := os.ReadFile(strings.TrimPrefix(source, "file://"))
+		if err != nil {
+			return Result{}, err
+		}
+		return Result{Body: body, FetchedAt: f.now()}, nil
+	}
+
+	cached, cachedAt, cacheErr := f.readCache(source)
+	if cacheErr == nil && f.MaxAge > 0 && f.now().Sub(cachedAt) < f.MaxAge {
+		return Result{Body: cached, FetchedAt: cachedAt, FromCache: true}, nil
+	}
+
+	body, err := f.download(source)
+	if err != nil {
+		if cacheErr == nil {
+			return Result{Body: cached, FetchedAt: cachedAt, FromCache: true, Stale: true, Err: err}, nil
+		}
+		return Result{}, err
+	}
+
+	fetchedAt := f.now()
+	// キャッシュの保存に失敗しても取得結果はそのまま使う
+	_ = f.writeCache(source, body, fetchedAt)
+
+	return Result{Body: body, FetchedAt: fetchedAt}, nil
+}
+
+// download はHTTPでデータを取得する
+func (f *Fetcher) download(url string) ([]byte, error) {
+	resp, err := f.Client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTPステータス %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("レスポンスの読み込みに失敗しました: %w", err)
+	}
+	return body, nil
+}
+
+// cachePath はURLに対応するキャッシュファイルのパスを返す
+func (f *Fetcher) cachePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(f.CacheDir, hex.EncodeToString(sum[:16])+".cache")
+}
+
+// readCache はキャッシュの内容と保存した時刻を返す
+func (f *Fetcher) readCache(url string) ([]byte, time.Time, error) {
+	if f.CacheDir == "" {
+		return nil, time.Time{}, errors.New("キャッシュは無効です")
+	}
+	path := f.cachePath(url)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return body, info.ModTime(), nil
+}
+
+// writeCache はキャッシュを保存し、更新時刻を取得した時刻に揃える
+func (f *Fetcher) writeCache(url string, body []byte, fetchedAt time.Time) error {
+	if f.CacheDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(f.CacheDir, 0755); err != nil {
+		return err
+	}
+	path := f.cachePath(url)
+	// 書き込み途中のファイルを読まないよう、一時ファイルに書いてから置き換える
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0644); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmp, fetchedAt, fetchedAt); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (f *Fetcher) now() time.Time {
+	if f.Now == nil {
+		return time.Now()
+	}
+	return f.Now()
+}
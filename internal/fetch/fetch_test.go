@@ -0,0 +1,106 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Get のテスト
+func TestGet(t *testing.T) {
+	status := http.StatusOK
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+		w.Write([]byte("body"))
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC)
+	fetcher := New(t.TempDir(), time.Second)
+	fetcher.Now = func() time.Time { return now }
+
+	t.Run("取得に成功した場合", func(t *testing.T) {
+		result, err := fetcher.Get(server.URL)
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if string(result.Body) != "body" || result.FromCache || result.Stale {
+			t.Errorf("取得結果が不正です: %+v", result)
+		}
+	})
+
+	t.Run("取得に失敗した場合はキャッシュを返す", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		defer func() { status = http.StatusOK }()
+
+		now = now.Add(time.Hour)
+		result, err := fetcher.Get(server.URL)
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if string(result.Body) != "body" || !result.Stale || result.Err == nil {
+			t.Errorf("キャッシュが返されていません: %+v", result)
+		}
+		if !result.FetchedAt.Equal(now.Add(-time.Hour)) {
+			t.Errorf("取得時刻: 期待=%v, 実際=%v", now.Add(-time.Hour), result.FetchedAt)
+		}
+	})
+
+	t.Run("MaxAge以内は再取得しない", func(t *testing.T) {
+		before := requests
+		result, err := fetcher.WithMaxAge(3 * time.Hour).Get(server.URL)
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if requests != before || !result.FromCache || result.Stale {
+			t.Errorf("キャッシュが使われていません: requests=%d, %+v", requests-before, result)
+		}
+	})
+
+	t.Run("MaxAgeを過ぎたら再取得する", func(t *testing.T) {
+		before := requests
+		if _, err := fetcher.WithMaxAge(30 * time.Minute).Get(server.URL); err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if requests != before+1 {
+			t.Errorf("再取得されていません: requests=%d", requests-before)
+		}
+	})
+
+	t.Run("キャッシュがなく取得に失敗した場合はエラー", func(t *testing.T) {
+		status = http.StatusNotFound
+		defer func() { status = http.StatusOK }()
+
+		if _, err := New("", time.Second).Get(server.URL); err == nil {
+			t.Error("期待: エラー, 実際: nil")
+		}
+	})
+}
+
+// ローカルファイルの取得のテスト
+func TestGetLocalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calendar.ics")
+	if err := os.WriteFile(path, []byte("BEGIN:VCALENDAR"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fetcher := New(t.TempDir(), time.Second)
+	for _, source := range []string{path, "file://" + path} {
+		result, err := fetcher.Get(source)
+		if err != nil {
+			t.Fatalf("期待: エラーなし, 実際: %v", err)
+		}
+		if string(result.Body) != "BEGIN:VCALENDAR" {
+			t.Errorf("期待: BEGIN:VCALENDAR, 実際: %s", result.Body)
+		}
+	}
+
+	if _, err := fetcher.Get(filepath.Join(t.TempDir(), "missing.ics")); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
@@ -0,0 +1,70 @@
+// Package forecast は天気予報の取得元ごとの日ごとの予報を共通の形で扱い、
+// 複数の取得元の予報を1つにまとめる (気温は中央値、降水確率は最大値、天気は多数決)。
+// まとめた日には取得元ごとの値の幅も残し、予報が分かれている日を見分けられるようにする。
+package forecast
+
+// 天気予報の取得元の名前
+const (
+	ProviderTsukumijima = "tsukumijima" // 天気予報 API (weather.tsukumijima.net)
+	ProviderOpenMeteo   = "open-meteo"  // Open-Meteo
+)
+
+// RainSlots は1日の降水確率の時間帯の数 (0-6時・6-12時・12-18時・18-24時)
+const RainSlots = 4
+
+// Forecast は1つの取得元の日ごとの予報
+type Forecast struct {
+	Provider string // 取得元の名前 (例: tsukumijima)
+	Location string // 地点名 (ない場合は空)
+	Days     []Day  // 日ごとの予報 (日付の順。先頭を今日とみなす)
+}
+
+// Day は1日の予報
+type Day struct {
+	Date        string                // 予報の対象の日 (YYYY-MM-DD、地点のタイムゾーンの日付)
+	Telop       string                // 天気 (気象庁の表現。例: 晴時々曇)
+	Wind        string                // 風の予報文 (ない場合は空)
+	MaxTemp     int                   // 最高気温 (℃)
+	HasMaxTemp  bool                  // 最高気温があるかどうか
+	MinTemp     int                   // 最低気温 (℃)
+	HasMinTemp  bool                  // 最低気温があるかどうか
+	RainChances [RainSlots]RainChance // 時間帯ごとの降水確率
+}
+
+// RainChance は1つの時間帯の降水確率
+type RainChance struct {
+	Percent int  // 降水確率 (%)
+	Has     bool // 発表があるかどうか (過ぎた時間帯などは発表がない)
+}
+
+// MaxRainChance は時間帯ごとの降水確率の最大値を返す (いずれの時間帯も発表がない場合は false)
+func (d Day) MaxRainChance() (int, bool) {
+	max, found := 0, false
+	for _, rainChance := range d.RainChances {
+		if rainChance.Has && (!found || rainChance.Percent > max) {
+			max, found = rainChance.Percent, true
+		}
+	}
+	return max, found
+}
+
+// Since は date (2006-01-02) より前の日を除いた予報を返す
+// 取得に失敗して古いキャッシュを使った場合に、過ぎた日の予報を今日の予報として扱わないために使う
+// 日付のない日は除かない
+func (f Forecast) Since(date string) Forecast {
+	days := make([]Day, 0, len(f.Days))
+	for _, day := range f.Days {
+		if day.Date == "" || day.Date >= date {
+			days = append(days, day)
+		}
+	}
+	f.Days = days
+	return f
+}
+
+// Provider は天気予報の取得元
+type Provider interface {
+	Name() string                        // 取得元の名前 (例: open-meteo)
+	URL() string                         // 今日から3日分の予報を取得するURL
+	Parse(body []byte) (Forecast, error) // レスポンスを日ごとの予報にする
+}
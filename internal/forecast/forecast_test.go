@@ -0,0 +1,228 @@
+package forecast
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// rain は時間帯ごとの降水確率を作る (負の値は発表なし)
+func rain(percents ...int) [RainSlots]RainChance {
+	var chances [RainSlots]RainChance
+	for i, percent := range percents {
+		if percent >= 0 {
+			chances[i] = RainChance{Percent: percent, Has: true}
+		}
+	}
+	return chances
+}
+
+// OpenMeteoProvider のテスト
+func TestOpenMeteoProviderParse(t *testing.T) {
+	body, err := os.ReadFile("testdata/open_meteo_daily.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	forecast, err := OpenMeteoProvider{Location: "東京"}.Parse(body)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	if forecast.Provider != ProviderOpenMeteo || forecast.Location != "東京" {
+		t.Errorf("取得元・地点名が不正です: %s, %s", forecast.Provider, forecast.Location)
+	}
+
+	expected := []Day{
+		{Date: "2026-10-18", Telop: "曇り", MaxTemp: 21, HasMaxTemp: true, MinTemp: 14, HasMinTemp: true, RainChances: rain(5, 20, 40, 50)},
+		{Date: "2026-10-19", Telop: "雨", MaxTemp: 18, HasMaxTemp: true, MinTemp: 12, HasMinTemp: true, RainChances: rain(80, 90, 60, 20)},
+		// 最高気温と18-24時の降水確率は値なし
+		{Date: "2026-10-20", Telop: "晴れ", MinTemp: 10, HasMinTemp: true, RainChances: rain(0, 0, 0, -1)},
+	}
+	if len(forecast.Days) != len(expected) {
+		t.Fatalf("日数: 期待=%d, 実際=%d", len(expected), len(forecast.Days))
+	}
+	for i := range expected {
+		if forecast.Days[i] != expected[i] {
+			t.Errorf("%d日目 期待: %+v, 実際: %+v", i+1, expected[i], forecast.Days[i])
+		}
+	}
+}
+
+// OpenMeteoProvider のテスト (不正なレスポンス)
+func TestOpenMeteoProviderParseError(t *testing.T) {
+	for _, body := range []string{`{`, `{"daily": {"time": []}}`} {
+		if _, err := (OpenMeteoProvider{}).Parse([]byte(body)); err == nil {
+			t.Errorf("%s: エラーが期待されましたが nil でした", body)
+		}
+	}
+}
+
+// OpenMeteoProvider の URL のテスト
+func TestOpenMeteoProviderURL(t *testing.T) {
+	url := OpenMeteoProvider{Latitude: 35.6895, Longitude: 139.6917}.URL()
+	for _, part := range []string{"latitude=35.690", "longitude=139.692", "forecast_days=3", "timezone=auto"} {
+		if !strings.Contains(url, part) {
+			t.Errorf("URL に %s が含まれていません: %s", part, url)
+		}
+	}
+}
+
+// Day.MaxRainChance のテスト
+func TestMaxRainChance(t *testing.T) {
+	tests := []struct {
+		name     string
+		chances  [RainSlots]RainChance
+		expected int
+		ok       bool
+	}{
+		{"最大値", rain(10, 40, 20, 0), 40, true},
+		{"発表のない時間帯を除く", rain(-1, -1, 0, 10), 10, true},
+		{"すべて0%", rain(0, 0, 0, 0), 0, true},
+		{"発表なし", rain(-1, -1, -1, -1), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := Day{RainChances: tt.chances}.MaxRainChance()
+			if value != tt.expected || ok != tt.ok {
+				t.Errorf("期待: %d (%v), 実際: %d (%v)", tt.expected, tt.ok, value, ok)
+			}
+		})
+	}
+}
+
+// Forecast.Since のテスト
+func TestSince(t *testing.T) {
+	f := Forecast{Provider: ProviderTsukumijima, Days: []Day{
+		{Date: "2026-10-17", Telop: "雨"},
+		{Date: "2026-10-18", Telop: "晴れ"},
+		{Date: "2026-10-19", Telop: "曇り"},
+	}}
+	since := f.Since("2026-10-18")
+	if len(since.Days) != 2 || since.Days[0].Date != "2026-10-18" || since.Provider != ProviderTsukumijima {
+		t.Errorf("期待: 10/18 以降の2日, 実際: %+v", since)
+	}
+	if len(f.Days) != 3 {
+		t.Errorf("元の予報が変わっています: %+v", f.Days)
+	}
+	if since := f.Since("2026-10-20"); len(since.Days) != 0 {
+		t.Errorf("期待: 0日, 実際: %+v", since.Days)
+	}
+	// 日付のない日は除かない
+	undated := Forecast{Days: []Day{{Telop: "晴れ"}}}
+	if since := undated.Since("2026-10-18"); len(since.Days) != 1 {
+		t.Errorf("期待: 日付のない日を残す, 実際: %+v", since.Days)
+	}
+}
+
+// Fuse のテスト
+func TestFuse(t *testing.T) {
+	tsukumijima := Forecast{
+		Provider: ProviderTsukumijima,
+		Location: "東京",
+		Days: []Day{
+			// 今日の最低気温は null
+			{Date: "2026-10-18", Telop: "晴時々曇", Wind: "北の風", MaxTemp: 22, HasMaxTemp: true, RainChances: rain(-1, 10, 20, 10)},
+			{Date: "2026-10-19", Telop: "曇のち雨", MaxTemp: 19, HasMaxTemp: true, MinTemp: 13, HasMinTemp: true, RainChances: rain(30, 50, 70, 60)},
+		},
+	}
+	openMeteo := Forecast{
+		Provider: ProviderOpenMeteo,
+		Location: "東京",
+		Days: []Day{
+			{Date: "2026-10-18", Telop: "曇り", MaxTemp: 21, HasMaxTemp: true, MinTemp: 14, HasMinTemp: true, RainChances: rain(5, 20, 40, 50)},
+			{Date: "2026-10-19", Telop: "雨", MaxTemp: 18, HasMaxTemp: true, MinTemp: 12, HasMinTemp: true, RainChances: rain(80, 90, 60, 20)},
+			{Date: "2026-10-20", Telop: "晴れ", MinTemp: 10, HasMinTemp: true, RainChances: rain(0, 0, 0, -1)},
+		},
+	}
+	other := Forecast{
+		Provider: "other",
+		Days: []Day{
+			{Date: "2026-10-18", Telop: "曇", MaxTemp: 26, HasMaxTemp: true, MinTemp: 15, HasMinTemp: true},
+			{Date: "2026-10-19", Telop: "晴れ", MaxTemp: 20, HasMaxTemp: true},
+		},
+	}
+
+	fused := Fuse([]Forecast{tsukumijima, openMeteo, other})
+	if fused.Provider != "tsukumijima+open-meteo+other" || len(fused.Providers) != 3 || fused.Location != "東京" {
+		t.Errorf("取得元・地点名が不正です: %s, %v, %s", fused.Provider, fused.Providers, fused.Location)
+	}
+
+	expected := []Day{
+		// 最高気温は 21・22・26 の中央値、最低気温は null を除いた 14・15 の平均、天気は曇りが2つ
+		{Date: "2026-10-18", Telop: "曇り", Wind: "北の風", MaxTemp: 22, HasMaxTemp: true, MinTemp: 15, HasMinTemp: true, RainChances: rain(5, 20, 40, 50)},
+		// 天気は3つとも異なるので先の取得元の天気
+		{Date: "2026-10-19", Telop: "曇のち雨", MaxTemp: 19, HasMaxTemp: true, MinTemp: 13, HasMinTemp: true, RainChances: rain(80, 90, 70, 60)},
+		// 1つの取得元にしかない日はその予報
+		{Date: "2026-10-20", Telop: "晴れ", MinTemp: 10, HasMinTemp: true, RainChances: rain(0, 0, 0, -1)},
+	}
+	if len(fused.Days) != len(expected) || len(fused.Spreads) != len(expected) {
+		t.Fatalf("日数: 期待=%d, 実際=%d (差 %d)", len(expected), len(fused.Days), len(fused.Spreads))
+	}
+	for i := range expected {
+		if fused.Days[i] != expected[i] {
+			t.Errorf("%d日目 期待: %+v, 実際: %+v", i+1, expected[i], fused.Days[i])
+		}
+	}
+
+	today := fused.Spreads[0]
+	if today.Providers != 3 || today.MaxTemp != (Range{Low: 21, High: 26, Count: 3}) || today.MinTemp != (Range{Low: 14, High: 15, Count: 2}) {
+		t.Errorf("今日の差が不正です: %+v", today)
+	}
+	if !today.MaxTemp.Disagrees(TempSpreadThreshold) || today.MinTemp.Disagrees(TempSpreadThreshold) {
+		t.Errorf("今日の気温の差の判定が不正です: %+v", today)
+	}
+	if today.Precipitation {
+		t.Error("今日は雨の予報がないのに雨の有無が分かれています")
+	}
+
+	tomorrow := fused.Spreads[1]
+	if tomorrow.RainChance != (Range{Low: 70, High: 90, Count: 2}) || tomorrow.RainChance.Disagrees(RainSpreadThreshold) {
+		t.Errorf("明日の降水確率の差が不正です: %+v", tomorrow.RainChance)
+	}
+	if !tomorrow.Precipitation {
+		t.Error("明日の雨の有無が分かれていません")
+	}
+
+	if dayAfter := fused.Spreads[2]; dayAfter.Providers != 1 || dayAfter.MinTemp.Disagrees(0) {
+		t.Errorf("明後日の差が不正です: %+v", dayAfter)
+	}
+}
+
+// Fuse のテスト (取得元が1つの場合はそのまま使う)
+func TestFuseSingle(t *testing.T) {
+	forecast := Forecast{
+		Provider: ProviderTsukumijima,
+		Location: "大阪",
+		Days: []Day{
+			{Telop: "晴れ", MaxTemp: 25, HasMaxTemp: true},
+			{Telop: "雨", MaxTemp: 20, HasMaxTemp: true},
+		},
+	}
+	fused := Fuse([]Forecast{forecast})
+	if fused.Provider != ProviderTsukumijima || fused.Location != "大阪" || len(fused.Days) != 2 || fused.Days[1] != forecast.Days[1] {
+		t.Errorf("期待: そのままの予報, 実際: %+v", fused.Forecast)
+	}
+	if len(fused.Spreads) != 2 || fused.Spreads[0].MaxTemp.Disagrees(0) || fused.Spreads[1].Precipitation {
+		t.Errorf("取得元が1つの場合に予報が分かれています: %+v", fused.Spreads)
+	}
+}
+
+// median のテスト
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values   []int
+		expected int
+		ok       bool
+	}{
+		{[]int{20, 24, 21}, 21, true},
+		{[]int{20, 21}, 21, true}, // 20.5 は四捨五入
+		{[]int{-3, -2}, -3, true}, // -2.5 は0から遠い方に丸める
+		{[]int{18}, 18, true},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		value, ok := median(tt.values)
+		if value != tt.expected || ok != tt.ok {
+			t.Errorf("%v 期待: %d (%v), 実際: %d (%v)", tt.values, tt.expected, tt.ok, value, ok)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package forecast
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"kindle-tenki-dashboard/internal/telop"
+)
+
+// 取得元の間の差がこの値以上の場合に予報が分かれているとする
+const (
+	TempSpreadThreshold = 3  // 最高・最低気温 (℃)
+	RainSpreadThreshold = 30 // 降水確率 (%)
+)
+
+// Fused は複数の取得元の予報をまとめた結果
+type Fused struct {
+	Forecast           // まとめた予報 (Provider は取得元の名前を「+」でつないだもの)
+	Providers []string // まとめた取得元 (渡した順)
+	Spreads   []Spread // Days と同じ順の、日ごとの取得元の間の差
+}
+
+// Spread は1日の予報の取得元の間の差
+type Spread struct {
+	Providers     int   // この日の予報がある取得元の数
+	MaxTemp       Range // 最高気温の範囲
+	MinTemp       Range // 最低気温の範囲
+	RainChance    Range // 降水確率 (時間帯の最大値) の範囲
+	Precipitation bool  // 雨・雪の有無が取得元によって異なるかどうか
+}
+
+// Range は取得元ごとの値の範囲
+type Range struct {
+	Low   int
+	High  int
+	Count int // 値のある取得元の数
+}
+
+// Disagrees は2つ以上の取得元の値が threshold 以上異なるかどうかを返す
+func (r Range) Disagrees(threshold int) bool {
+	return r.Count >= 2 && r.High-r.Low >= threshold
+}
+
+// add は範囲に値を加える
+func (r *Range) add(value int) {
+	if r.Count == 0 || value < r.Low {
+		r.Low = value
+	}
+	if r.Count == 0 || value > r.High {
+		r.High = value
+	}
+	r.Count++
+}
+
+// Fuse は取得元ごとの予報 (優先する順) を日付ごとにまとめる
+// 最高・最低気温は中央値 (偶数個の場合は中央の2つの平均を四捨五入)、降水確率は時間帯ごとの最大値、
+// 天気は正規化した天気コードの多数決 (同数の場合は先の取得元) で選び、風と地点名は最初に値のある取得元を使う
+// 値のない取得元はその値だけ除いてまとめる (例: 今日の最低気温がない取得元があれば、他の取得元の最低気温を使う)
+func Fuse(forecasts []Forecast) Fused {
+	if len(forecasts) == 1 {
+		// 1つの場合はそのまま使う (日付のない予報も日の順で扱える)
+		fused := Fused{Forecast: forecasts[0], Providers: []string{forecasts[0].Provider}}
+		for _, day := range forecasts[0].Days {
+			fused.Spreads = append(fused.Spreads, newSpread([]Day{day}))
+		}
+		return fused
+	}
+
+	var fused Fused
+	byDate := make(map[string][]Day)
+	for _, forecast := range forecasts {
+		fused.Providers = append(fused.Providers, forecast.Provider)
+		if fused.Location == "" {
+			fused.Location = forecast.Location
+		}
+		for _, day := range forecast.Days {
+			byDate[day.Date] = append(byDate[day.Date], day)
+		}
+	}
+	fused.Provider = strings.Join(fused.Providers, "+")
+
+	dates := make([]string, 0, len(byDate))
+	for date := range byDate {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	for _, date := range dates {
+		days := byDate[date]
+		fused.Days = append(fused.Days, fuseDay(date, days))
+		fused.Spreads = append(fused.Spreads, newSpread(days))
+	}
+	return fused
+}
+
+// fuseDay は1日の取得元ごとの予報 (優先する順) をまとめる
+func fuseDay(date string, days []Day) Day {
+	fused := Day{Date: date, Telop: majorityTelop(days)}
+
+	var maxTemps, minTemps []int
+	for _, day := range days {
+		if fused.Wind == "" {
+			fused.Wind = day.Wind
+		}
+		if day.HasMaxTemp {
+			maxTemps = append(maxTemps, day.MaxTemp)
+		}
+		if day.HasMinTemp {
+			minTemps = append(minTemps, day.MinTemp)
+		}
+		for slot, rainChance := range day.RainChances {
+			current := &fused.RainChances[slot]
+			if rainChance.Has && (!current.Has || rainChance.Percent > current.Percent) {
+				*current = rainChance
+			}
+		}
+	}
+	fused.MaxTemp, fused.HasMaxTemp = median(maxTemps)
+	fused.MinTemp, fused.HasMinTemp = median(minTemps)
+	return fused
+}
+
+// majorityTelop は正規化した天気コードが最も多い天気を返す
+// 同数の場合は先の取得元の天気を、読み取れる天気がない場合は最初の空でない天気を返す
+func majorityTelop(days []Day) string {
+	counts := make(map[string]int)
+	best, bestCount := "", 0
+	for _, day := range days {
+		code := telop.Parse(day.Telop).Code()
+		if code == "unknown" {
+			continue
+		}
+		counts[code]++
+		if counts[code] > bestCount {
+			bestCount = counts[code]
+			best = code
+		}
+	}
+	for _, day := range days {
+		if best == "" && day.Telop != "" {
+			return day.Telop
+		}
+		if best != "" && telop.Parse(day.Telop).Code() == best {
+			return day.Telop
+		}
+	}
+	return ""
+}
+
+// median は値の中央値を返す (値がない場合は false)
+func median(values []int) (int, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle], true
+	}
+	return int(math.Round(float64(sorted[middle-1]+sorted[middle]) / 2)), true
+}
+
+// newSpread は1日の取得元ごとの予報から取得元の間の差を求める
+func newSpread(days []Day) Spread {
+	spread := Spread{Providers: len(days)}
+	precipitation := make(map[bool]bool)
+	for _, day := range days {
+		if day.HasMaxTemp {
+			spread.MaxTemp.add(day.MaxTemp)
+		}
+		if day.HasMinTemp {
+			spread.MinTemp.add(day.MinTemp)
+		}
+		if rainChance, ok := day.MaxRainChance(); ok {
+			spread.RainChance.add(rainChance)
+		}
+		if parsed := telop.Parse(day.Telop); parsed.Primary != telop.ConditionNone {
+			precipitation[parsed.HasPrecipitation()] = true
+		}
+	}
+	spread.Precipitation = len(precipitation) > 1
+	return spread
+}
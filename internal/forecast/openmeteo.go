@@ -0,0 +1,126 @@
+package forecast
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OpenMeteoProvider は Open-Meteo の天気予報APIから日ごとの予報を取得する
+// 天気は WMO の天気コードを気象庁の表現に置き換え、風の予報文はないため空にする
+type OpenMeteoProvider struct {
+	Latitude  float64
+	Longitude float64
+	Location  string // 地点名 (Open-Meteo のレスポンスにはないため、設定した地点の名前を使う)
+}
+
+// Name は取得元の名前を返す
+func (p OpenMeteoProvider) Name() string {
+	return ProviderOpenMeteo
+}
+
+// URL は今日から3日分の予報を取得するURLを返す
+// 日付・時刻は指定した緯度・経度のタイムゾーンで返る (timezone=auto)
+func (p OpenMeteoProvider) URL() string {
+	query := url.Values{}
+	query.Set("latitude", strconv.FormatFloat(p.Latitude, 'f', 3, 64))
+	query.Set("longitude", strconv.FormatFloat(p.Longitude, 'f', 3, 64))
+	query.Set("daily", "weather_code,temperature_2m_max,temperature_2m_min")
+	query.Set("hourly", "precipitation_probability")
+	query.Set("timezone", "auto")
+	query.Set("forecast_days", "3")
+	return "https://api.open-meteo.com/v1/forecast?" + query.Encode()
+}
+
+// Parse は日ごとの天気・最高・最低気温と、1時間ごとの降水確率をパースする
+// 降水確率は時間帯 (0-6時など) ごとの最大値にする
+func (p OpenMeteoProvider) Parse(body []byte) (Forecast, error) {
+	var response struct {
+		Daily struct {
+			Time        []string   `json:"time"`
+			WeatherCode []*int     `json:"weather_code"`
+			MaxTemp     []*float64 `json:"temperature_2m_max"`
+			MinTemp     []*float64 `json:"temperature_2m_min"`
+		} `json:"daily"`
+		Hourly struct {
+			Time                     []string `json:"time"`
+			PrecipitationProbability []*int   `json:"precipitation_probability"`
+		} `json:"hourly"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return Forecast{}, fmt.Errorf("天気予報のパースに失敗しました: %w", err)
+	}
+	if len(response.Daily.Time) == 0 {
+		return Forecast{}, fmt.Errorf("天気予報に日ごとの予報が含まれていません")
+	}
+
+	forecast := Forecast{Provider: ProviderOpenMeteo, Location: p.Location}
+	index := make(map[string]int)
+	for i, date := range response.Daily.Time {
+		day := Day{Date: date}
+		if i < len(response.Daily.WeatherCode) && response.Daily.WeatherCode[i] != nil {
+			day.Telop = weatherCodeTelop(*response.Daily.WeatherCode[i])
+		}
+		if i < len(response.Daily.MaxTemp) && response.Daily.MaxTemp[i] != nil {
+			day.MaxTemp, day.HasMaxTemp = int(math.Round(*response.Daily.MaxTemp[i])), true
+		}
+		if i < len(response.Daily.MinTemp) && response.Daily.MinTemp[i] != nil {
+			day.MinTemp, day.HasMinTemp = int(math.Round(*response.Daily.MinTemp[i])), true
+		}
+		index[date] = i
+		forecast.Days = append(forecast.Days, day)
+	}
+
+	for i, value := range response.Hourly.Time {
+		t, err := time.Parse("2006-01-02T15:04", value)
+		if err != nil {
+			return Forecast{}, fmt.Errorf("天気予報の時刻が不正です: %q", value)
+		}
+		dayIndex, ok := index[t.Format("2006-01-02")]
+		if !ok || i >= len(response.Hourly.PrecipitationProbability) || response.Hourly.PrecipitationProbability[i] == nil {
+			continue
+		}
+		percent := *response.Hourly.PrecipitationProbability[i]
+		slot := &forecast.Days[dayIndex].RainChances[t.Hour()/(24/RainSlots)]
+		if !slot.Has || percent > slot.Percent {
+			slot.Percent, slot.Has = percent, true
+		}
+	}
+	return forecast, nil
+}
+
+// weatherCodeTelop は WMO の天気コードを気象庁の天気の表現に置き換える
+// 対応しないコードは空を返す (天気のまとめでは他の取得元の天気を使う)
+func weatherCodeTelop(code int) string {
+	switch code {
+	case 0, 1:
+		return "晴れ"
+	case 2:
+		return "晴時々曇"
+	case 3:
+		return "曇り"
+	case 45, 48:
+		return "霧"
+	case 51, 53, 55, 56, 57:
+		return "霧雨"
+	case 61, 63, 66, 67:
+		return "雨"
+	case 65, 82:
+		return "大雨"
+	case 71, 73, 77:
+		return "雪"
+	case 75:
+		return "大雪"
+	case 80, 81:
+		return "曇時々雨"
+	case 85, 86:
+		return "曇時々雪"
+	case 95, 96, 99:
+		return "雷雨"
+	default:
+		return ""
+	}
+}
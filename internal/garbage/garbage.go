@@ -0,0 +1,143 @@
+// Package garbage はゴミ出しの収集日の規則から、指定した日に出せるゴミの種類を判定する。
+// 「月・木曜日」のような毎週の規則と「第2・第4水曜日」のような月内の何週目かの規則に対応し、
+// 祝日の収集休止や年末年始などの個別の休止日・振替日を例外として指定できる。
+package garbage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/holiday"
+)
+
+// LastWeek は月の最後の該当曜日を表す Weeks の値
+const LastWeek = -1
+
+// weekdayNames は曜日の表記 (time.Weekday の順)
+var weekdayNames = [7]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// Schedule はゴミの種類ごとの収集日の規則
+type Schedule struct {
+	Name         string         // ゴミの種類 (例: 燃えるゴミ)
+	Weekdays     []time.Weekday // 収集する曜日
+	Weeks        []int          // 月の何回目の曜日か (1〜5、LastWeek は最終)。空の場合は毎週
+	SkipHolidays bool           // 祝日・休日は収集しない
+	SkipDates    []Date         // 収集しない日 (年末年始など)
+	ExtraDates   []Date         // 規則とは別に収集する日 (振替収集など)
+}
+
+// Date は年を省略できる日付 (Year が 0 の場合は毎年)
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate は YYYY-MM-DD または MM-DD (毎年) 形式の日付をパースする
+func ParseDate(value string) (Date, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}, nil
+	}
+	// 2月29日も毎年の日付として受け付けるため、うるう年で解釈する
+	if t, err := time.Parse("2006-01-02", "2000-"+value); err == nil {
+		return Date{Month: t.Month(), Day: t.Day()}, nil
+	}
+	return Date{}, fmt.Errorf("日付の形式が不正です: %q (YYYY-MM-DD または MM-DD 形式で指定してください)", value)
+}
+
+// Matches は日付が一致するかどうかを返す
+func (d Date) Matches(t time.Time) bool {
+	return (d.Year == 0 || d.Year == t.Year()) && d.Month == t.Month() && d.Day == t.Day()
+}
+
+// ParseWeekday は曜日の表記 (例: 月、月曜、月曜日) をパースする
+func ParseWeekday(value string) (time.Weekday, error) {
+	name := strings.TrimSpace(value)
+	// 「日」は末尾の「日」を取り除くと空になるため先に判定する
+	if name != weekdayNames[time.Sunday] {
+		name = strings.TrimSuffix(strings.TrimSuffix(name, "日"), "曜")
+	}
+	for i, weekdayName := range weekdayNames {
+		if name == weekdayName {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("曜日の形式が不正です: %q (日〜土で指定してください)", value)
+}
+
+// Validate は規則が正しいかチェックする
+func (s Schedule) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("ゴミの種類の名前が指定されていません")
+	}
+	if len(s.Weekdays) == 0 && len(s.ExtraDates) == 0 {
+		return fmt.Errorf("%s: 収集する曜日が指定されていません", s.Name)
+	}
+	for _, week := range s.Weeks {
+		if week != LastWeek && (week < 1 || week > 5) {
+			return fmt.Errorf("%s: 何週目かの指定が不正です: %d (1〜5、最終は -1)", s.Name, week)
+		}
+	}
+	return nil
+}
+
+// CollectsOn は指定した日に収集するかどうかを返す
+// 例外の優先順位は 振替収集日 > 休止日 > 祝日の休止 > 曜日の規則
+func (s Schedule) CollectsOn(t time.Time) bool {
+	for _, date := range s.ExtraDates {
+		if date.Matches(t) {
+			return true
+		}
+	}
+	for _, date := range s.SkipDates {
+		if date.Matches(t) {
+			return false
+		}
+	}
+	if s.SkipHolidays {
+		if _, ok := holiday.Lookup(t); ok {
+			return false
+		}
+	}
+	return s.matchesWeekday(t)
+}
+
+// matchesWeekday は曜日と何週目かの規則に合うかどうかを返す
+func (s Schedule) matchesWeekday(t time.Time) bool {
+	matched := false
+	for _, weekday := range s.Weekdays {
+		if weekday == t.Weekday() {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	if len(s.Weeks) == 0 {
+		return true
+	}
+
+	// 月の何回目の曜日か (1日〜7日が1回目)
+	nth := (t.Day()-1)/7 + 1
+	daysInMonth := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	isLast := t.Day()+7 > daysInMonth
+	for _, week := range s.Weeks {
+		if week == nth || (week == LastWeek && isLast) {
+			return true
+		}
+	}
+	return false
+}
+
+// Collections は指定した日に収集するゴミの種類を規則の順に返す
+func Collections(schedules []Schedule, t time.Time) []string {
+	var names []string
+	for _, schedule := range schedules {
+		if schedule.CollectsOn(t) {
+			names = append(names, schedule.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,162 @@
+package garbage
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 7, 0, 0, 0, time.UTC)
+}
+
+// CollectsOn のテスト
+func TestCollectsOn(t *testing.T) {
+	burnable := Schedule{Name: "燃えるゴミ", Weekdays: []time.Weekday{time.Monday, time.Thursday}}
+	recyclable := Schedule{Name: "資源ゴミ", Weekdays: []time.Weekday{time.Wednesday}, Weeks: []int{2, 4}}
+	hazardous := Schedule{Name: "有害ゴミ", Weekdays: []time.Weekday{time.Friday}, Weeks: []int{LastWeek}}
+	plastic := Schedule{
+		Name:         "プラ",
+		Weekdays:     []time.Weekday{time.Monday},
+		SkipHolidays: true,
+		SkipDates:    []Date{{Month: time.January, Day: 1}},
+		ExtraDates:   []Date{{Year: 2026, Month: time.October, Day: 13}},
+	}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		date     time.Time
+		expected bool
+	}{
+		{"毎週: 月曜日", burnable, date(2026, 10, 19), true},
+		{"毎週: 木曜日", burnable, date(2026, 10, 22), true},
+		{"毎週: 対象外の曜日", burnable, date(2026, 10, 20), false},
+		{"毎週: 祝日も収集", burnable, date(2026, 10, 12), true},
+		{"第2水曜日", recyclable, date(2026, 10, 14), true},
+		{"第4水曜日", recyclable, date(2026, 10, 28), true},
+		{"第1水曜日は対象外", recyclable, date(2026, 10, 7), false},
+		{"第3水曜日は対象外", recyclable, date(2026, 10, 21), false},
+		{"最終金曜日", hazardous, date(2026, 10, 30), true},
+		{"最終でない金曜日", hazardous, date(2026, 10, 23), false},
+		{"最終金曜日 (第4週)", hazardous, date(2026, 2, 27), true},
+		{"祝日は休止 (スポーツの日)", plastic, date(2026, 10, 12), false},
+		{"振替収集日", plastic, date(2026, 10, 13), true},
+		{"祝日でない月曜日", plastic, date(2026, 10, 19), true},
+		{"毎年の休止日", plastic, date(2029, 1, 1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := tt.schedule.CollectsOn(tt.date); actual != tt.expected {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// Collections のテスト
+func TestCollections(t *testing.T) {
+	schedules := []Schedule{
+		{Name: "燃えるゴミ", Weekdays: []time.Weekday{time.Monday, time.Thursday}},
+		{Name: "プラ", Weekdays: []time.Weekday{time.Monday}},
+		{Name: "資源ゴミ", Weekdays: []time.Weekday{time.Wednesday}, Weeks: []int{2, 4}},
+	}
+
+	tests := []struct {
+		date     time.Time
+		expected string
+	}{
+		{date(2026, 10, 19), "燃えるゴミ,プラ"},
+		{date(2026, 10, 20), ""},
+		{date(2026, 10, 28), "資源ゴミ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date.Format("2006-01-02"), func(t *testing.T) {
+			if actual := strings.Join(Collections(schedules, tt.date), ","); actual != tt.expected {
+				t.Errorf("期待: %q, 実際: %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// ParseWeekday のテスト
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Weekday
+		hasError bool
+	}{
+		{"月", time.Monday, false},
+		{"水曜", time.Wednesday, false},
+		{"金曜日", time.Friday, false},
+		{"日", time.Sunday, false},
+		{"日曜", time.Sunday, false},
+		{"日曜日", time.Sunday, false},
+		{"", 0, true},
+		{"Mon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			actual, err := ParseWeekday(tt.value)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if actual != tt.expected {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// ParseDate のテスト
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Date
+		hasError bool
+	}{
+		{"2026-12-30", Date{Year: 2026, Month: time.December, Day: 30}, false},
+		{"01-02", Date{Month: time.January, Day: 2}, false},
+		{"02-29", Date{Month: time.February, Day: 29}, false},
+		{"2026/12/30", Date{}, true},
+		{"13-01", Date{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			actual, err := ParseDate(tt.value)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if actual != tt.expected {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// Validate のテスト
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		hasError bool
+	}{
+		{"正常", Schedule{Name: "燃えるゴミ", Weekdays: []time.Weekday{time.Monday}}, false},
+		{"名前なし", Schedule{Weekdays: []time.Weekday{time.Monday}}, true},
+		{"曜日なし", Schedule{Name: "粗大ゴミ"}, true},
+		{"振替収集日のみ", Schedule{Name: "粗大ゴミ", ExtraDates: []Date{{Month: time.March, Day: 1}}}, false},
+		{"何週目かが範囲外", Schedule{Name: "資源ゴミ", Weekdays: []time.Weekday{time.Wednesday}, Weeks: []int{6}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.schedule.Validate(); (err != nil) != tt.hasError {
+				t.Errorf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Package health は紫外線指数や花粉の飛散数など、健康に関する指標を扱う。
+// 取得元ごとに Provider を実装し、日ごとの最大値を白黒表示に向いた文字の区分に変換する。
+// 紫外線指数は予報だが花粉の飛散数は観測値のため、取得元は値が観測値かどうか (Observed) も返す。
+package health
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kind は指標の種類
+type Kind int
+
+const (
+	KindUV     Kind = iota + 1 // 紫外線指数 (UVインデックス)
+	KindPollen                 // 花粉の飛散数 (1時間あたり個/m³)
+)
+
+// Label は指標の表記を返す
+func (k Kind) Label() string {
+	switch k {
+	case KindUV:
+		return "紫外線"
+	case KindPollen:
+		return "花粉"
+	default:
+		return ""
+	}
+}
+
+// Level は指標の区分 (Rank は 1 〜 5 で大きいほど注意が必要)
+type Level struct {
+	Rank  int
+	Label string
+}
+
+// threshold は区分の下限
+type threshold struct {
+	min   float64
+	label string
+}
+
+// uvThresholds は気象庁の紫外線情報の区分 (UVインデックスは整数に丸めて判定する)
+var uvThresholds = []threshold{
+	{0, "弱い"},
+	{3, "中程度"},
+	{6, "強い"},
+	{8, "非常に強い"},
+	{11, "極端に強い"},
+}
+
+// pollenThresholds はウェザーニュースの花粉の飛散数 (1時間あたり個/m³) の区分
+var pollenThresholds = []threshold{
+	{0, "少ない"},
+	{10, "やや多い"},
+	{30, "多い"},
+	{50, "非常に多い"},
+	{100, "極めて多い"},
+}
+
+// LevelOf は値の区分を返す
+func (k Kind) LevelOf(value float64) Level {
+	thresholds := pollenThresholds
+	if k == KindUV {
+		thresholds = uvThresholds
+		value = float64(int(value + 0.5))
+	}
+	level := Level{Rank: 1, Label: thresholds[0].label}
+	for i, t := range thresholds {
+		if value >= t.min {
+			level = Level{Rank: i + 1, Label: t.label}
+		}
+	}
+	return level
+}
+
+// Daily は1日の最大値
+type Daily struct {
+	Date time.Time // 日付 (その地域の0時)
+	Max  float64
+}
+
+// Provider は指標の取得元
+type Provider interface {
+	Name() string             // 取得元の名前 (例: open-meteo)
+	Kind() Kind               // 指標の種類
+	Observed() bool           // 値が予報ではなく観測値かどうか (観測値は今日の分しかない)
+	URL(now time.Time) string // now を含む日の値を取得するURL
+	Parse(body []byte, loc *time.Location) ([]Daily, error)
+}
+
+// 取得元の名前
+const (
+	ProviderOpenMeteo   = "open-meteo"  // Open-Meteo (紫外線指数)
+	ProviderWeathernews = "weathernews" // ウェザーニュース (花粉の飛散数)
+)
+
+// NewUVProvider は紫外線指数の取得元を生成する (name が空の場合は open-meteo)
+func NewUVProvider(name string, latitude, longitude float64) (Provider, error) {
+	switch name {
+	case "", ProviderOpenMeteo:
+		return OpenMeteoUVProvider{Latitude: latitude, Longitude: longitude}, nil
+	default:
+		return nil, fmt.Errorf("紫外線指数の取得元が不正です: %q (%s のみ指定できます)", name, ProviderOpenMeteo)
+	}
+}
+
+// NewPollenProvider は花粉の飛散数の取得元を生成する (name が空の場合は weathernews)
+// cityCode は全国地方公共団体コード (市区町村の5桁。例: 13101)
+func NewPollenProvider(name, cityCode string) (Provider, error) {
+	switch name {
+	case "", ProviderWeathernews:
+		if len(cityCode) != 5 {
+			return nil, fmt.Errorf("%s には市区町村コード (5桁) が必要です: %q", ProviderWeathernews, cityCode)
+		}
+		return WeathernewsPollenProvider{CityCode: cityCode}, nil
+	default:
+		return nil, fmt.Errorf("花粉の取得元が不正です: %q (%s のみ指定できます)", name, ProviderWeathernews)
+	}
+}
+
+// FindDay は指定した日 (t の地域の暦日) の最大値を返す
+func FindDay(days []Daily, t time.Time) (Daily, bool) {
+	year, month, day := t.Date()
+	for _, d := range days {
+		y, m, dd := d.Date.In(t.Location()).Date()
+		if y == year && m == month && dd == day {
+			return d, true
+		}
+	}
+	return Daily{}, false
+}
@@ -0,0 +1,119 @@
+package health
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+// OpenMeteoUVProvider のテスト
+func TestOpenMeteoUVProvider(t *testing.T) {
+	provider := OpenMeteoUVProvider{Latitude: 35.6895, Longitude: 139.6917}
+	days, err := provider.Parse(readFixture(t, "open_meteo_uv.json"), jst)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	// 値のない日は除く
+	if len(days) != 2 {
+		t.Fatalf("日数: 期待=2, 実際=%d", len(days))
+	}
+	today, ok := FindDay(days, time.Date(2026, 4, 18, 7, 0, 0, 0, jst))
+	if !ok || today.Max != 6.45 {
+		t.Errorf("今日: 期待=6.45, 実際=%v (%v)", today.Max, ok)
+	}
+
+	url := provider.URL(time.Date(2026, 4, 18, 7, 0, 0, 0, jst))
+	for _, param := range []string{"latitude=35.690", "longitude=139.692", "daily=uv_index_max", "timezone=auto", "forecast_days=2"} {
+		if !strings.Contains(url, param) {
+			t.Errorf("URLに %s が含まれていません: %s", param, url)
+		}
+	}
+
+	if _, err := provider.Parse([]byte("<html>"), jst); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// WeathernewsPollenProvider のテスト
+func TestWeathernewsPollenProvider(t *testing.T) {
+	provider := WeathernewsPollenProvider{CityCode: "13101"}
+	days, err := provider.Parse(readFixture(t, "weathernews_pollen.csv"), jst)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	// 翌日0時の観測値は前日の飛散数として扱う
+	if len(days) != 1 {
+		t.Fatalf("日数: 期待=1, 実際=%d", len(days))
+	}
+	if days[0].Date.Format("2006-01-02") != "2026-03-10" || days[0].Max != 51 {
+		t.Errorf("期待: 2026-03-10 51, 実際: %s %v", days[0].Date.Format("2006-01-02"), days[0].Max)
+	}
+
+	expectedURL := "https://wxtech.weathernews.com/opendata/v1/pollen?citycode=13101&start=20260310&end=20260310"
+	if url := provider.URL(time.Date(2026, 3, 10, 7, 0, 0, 0, jst)); url != expectedURL {
+		t.Errorf("期待: %s, 実際: %s", expectedURL, url)
+	}
+
+	if _, err := provider.Parse([]byte("<html>\n"), jst); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// LevelOf のテスト
+func TestLevelOf(t *testing.T) {
+	tests := []struct {
+		kind     Kind
+		value    float64
+		rank     int
+		expected string
+	}{
+		{KindUV, 0, 1, "弱い"},
+		{KindUV, 2.4, 1, "弱い"},
+		{KindUV, 2.5, 2, "中程度"},
+		{KindUV, 6.45, 3, "強い"},
+		{KindUV, 8, 4, "非常に強い"},
+		{KindUV, 11.2, 5, "極端に強い"},
+		{KindPollen, 0, 1, "少ない"},
+		{KindPollen, 10, 2, "やや多い"},
+		{KindPollen, 30, 3, "多い"},
+		{KindPollen, 51, 4, "非常に多い"},
+		{KindPollen, 100, 5, "極めて多い"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.Label()+tt.expected, func(t *testing.T) {
+			level := tt.kind.LevelOf(tt.value)
+			if level.Rank != tt.rank || level.Label != tt.expected {
+				t.Errorf("%v: 期待=%d %s, 実際=%d %s", tt.value, tt.rank, tt.expected, level.Rank, level.Label)
+			}
+		})
+	}
+}
+
+// NewUVProvider・NewPollenProvider のテスト
+func TestNewProvider(t *testing.T) {
+	if provider, err := NewUVProvider("", 35.6, 139.7); err != nil || provider.Name() != ProviderOpenMeteo || provider.Observed() {
+		t.Errorf("期待: %s の予報, 実際: %v (%v)", ProviderOpenMeteo, provider, err)
+	}
+	if _, err := NewUVProvider("unknown", 0, 0); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+	if provider, err := NewPollenProvider("", "13101"); err != nil || provider.Kind() != KindPollen || !provider.Observed() {
+		t.Errorf("期待: 花粉の観測値の取得元, 実際: %v (%v)", provider, err)
+	}
+	if _, err := NewPollenProvider(ProviderWeathernews, ""); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
@@ -0,0 +1,69 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OpenMeteoUVProvider は Open-Meteo の天気予報APIから日ごとの最大の紫外線指数を取得する
+// 登録不要で、緯度・経度を指定して全世界の予報を取得できる
+type OpenMeteoUVProvider struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Name は取得元の名前を返す
+func (p OpenMeteoUVProvider) Name() string {
+	return ProviderOpenMeteo
+}
+
+// Kind は指標の種類を返す
+func (p OpenMeteoUVProvider) Kind() Kind {
+	return KindUV
+}
+
+// Observed は予報のため false を返す
+func (p OpenMeteoUVProvider) Observed() bool {
+	return false
+}
+
+// URL は今日と明日の予報を取得するURLを返す
+// 日付は指定した緯度・経度のタイムゾーンで区切る (timezone=auto)
+func (p OpenMeteoUVProvider) URL(now time.Time) string {
+	query := url.Values{}
+	query.Set("latitude", strconv.FormatFloat(p.Latitude, 'f', 3, 64))
+	query.Set("longitude", strconv.FormatFloat(p.Longitude, 'f', 3, 64))
+	query.Set("daily", "uv_index_max")
+	query.Set("timezone", "auto")
+	query.Set("forecast_days", "2")
+	return "https://api.open-meteo.com/v1/forecast?" + query.Encode()
+}
+
+// Parse は日ごとの最大の紫外線指数をパースする (値のない日は除く)
+func (p OpenMeteoUVProvider) Parse(body []byte, loc *time.Location) ([]Daily, error) {
+	var response struct {
+		Daily struct {
+			Time       []string   `json:"time"`
+			UVIndexMax []*float64 `json:"uv_index_max"`
+		} `json:"daily"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("紫外線指数のパースに失敗しました: %w", err)
+	}
+
+	var days []Daily
+	for i, date := range response.Daily.Time {
+		if i >= len(response.Daily.UVIndexMax) || response.Daily.UVIndexMax[i] == nil {
+			continue
+		}
+		t, err := time.ParseInLocation("2006-01-02", date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("紫外線指数の日付が不正です: %q", date)
+		}
+		days = append(days, Daily{Date: t, Max: *response.Daily.UVIndexMax[i]})
+	}
+	return days, nil
+}
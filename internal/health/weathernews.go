@@ -0,0 +1,75 @@
+package health
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// WeathernewsPollenProvider はウェザーニュースの花粉飛散数のオープンデータから1時間ごとの飛散数を取得する
+// 花粉観測機 (ポールンロボ) の観測値で、提供は例年2月〜5月
+type WeathernewsPollenProvider struct {
+	CityCode string // 全国地方公共団体コード (市区町村の5桁)
+}
+
+// missingPollen は欠測を表す値
+const missingPollen = -9999
+
+// Name は取得元の名前を返す
+func (p WeathernewsPollenProvider) Name() string {
+	return ProviderWeathernews
+}
+
+// Kind は指標の種類を返す
+func (p WeathernewsPollenProvider) Kind() Kind {
+	return KindPollen
+}
+
+// Observed は観測値のため true を返す (飛散数の予報は提供されていない)
+func (p WeathernewsPollenProvider) Observed() bool {
+	return true
+}
+
+// URL は今日の観測値を取得するURLを返す
+func (p WeathernewsPollenProvider) URL(now time.Time) string {
+	date := now.Format("20060102")
+	return "https://wxtech.weathernews.com/opendata/v1/pollen?citycode=" + p.CityCode + "&start=" + date + "&end=" + date
+}
+
+// Parse は1時間ごとの飛散数 (citycode,date,pollen のCSV) から日ごとの最大値を求める
+// 欠測の時刻は除き、すべて欠測の日は含めない
+func (p WeathernewsPollenProvider) Parse(body []byte, loc *time.Location) ([]Daily, error) {
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("花粉の飛散数のパースに失敗しました: %w", err)
+	}
+	if len(records) == 0 || len(records[0]) != 3 || records[0][2] != "pollen" {
+		return nil, fmt.Errorf("花粉の飛散数の形式が不正です")
+	}
+
+	var days []Daily
+	for _, record := range records[1:] {
+		observedAt, err := time.Parse(time.RFC3339, record[1])
+		if err != nil {
+			return nil, fmt.Errorf("花粉の飛散数の時刻が不正です: %q", record[1])
+		}
+		count, err := strconv.Atoi(record[2])
+		if err != nil || count == missingPollen {
+			continue
+		}
+
+		// 1時の観測値は0時〜1時の飛散数のため、直前の時刻の日に含める
+		year, month, day := observedAt.In(loc).Add(-time.Minute).Date()
+		date := time.Date(year, month, day, 0, 0, 0, 0, loc)
+		if n := len(days); n > 0 && days[n-1].Date.Equal(date) {
+			if float64(count) > days[n-1].Max {
+				days[n-1].Max = float64(count)
+			}
+			continue
+		}
+		days = append(days, Daily{Date: date, Max: float64(count)})
+	}
+	return days, nil
+}
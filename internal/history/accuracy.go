@@ -0,0 +1,184 @@
+package history
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// RainThreshold は雨の予報とみなす降水確率 (%)
+const RainThreshold = 50
+
+// Reference は予報の精度の基準 (前日の予報と比べる値)
+const Reference = "アメダスの観測値 (記録した時刻の観測値から求めた最高・最低気温と降水の有無)"
+
+// Accuracy は1つの取得元の前日の予報の精度
+// 前日の予報 (前日の最後の記録の明日の予報) を、その日に記録したアメダスの観測値と比べる
+type Accuracy struct {
+	Provider string     `json:"provider"` // 天気予報の取得元
+	Days     int        `json:"days"`     // 比べた日数 (いずれかの値を比べられた日)
+	From     string     `json:"from"`     // 比べた最初の日
+	To       string     `json:"to"`       // 比べた最後の日
+	MaxTemp  ErrorStats `json:"maxTemp"`  // 最高気温の誤差
+	MinTemp  ErrorStats `json:"minTemp"`  // 最低気温の誤差
+	Rain     RainStats  `json:"rain"`     // 雨の有無の的中
+}
+
+// ErrorStats は気温の予報の誤差 (℃)
+type ErrorStats struct {
+	Count int     `json:"count"` // 比べた日数
+	Bias  float64 `json:"bias"`  // 平均誤差 (予報 - 観測値。正の場合は高めに予報している)
+	MAE   float64 `json:"mae"`   // 平均絶対誤差
+}
+
+// RainStats は雨の有無の予報の的中
+// 降水確率が RainThreshold 以上を「雨」の予報とし、その日に降水を観測したかどうかと比べる
+type RainStats struct {
+	Count   int     `json:"count"`   // 比べた日数
+	Hits    int     `json:"hits"`    // 当たった日数
+	HitRate float64 `json:"hitRate"` // 的中率 (0〜1)
+}
+
+// errorSum は誤差の合計を数える
+type errorSum struct {
+	count    int
+	sum      float64
+	absolute float64
+}
+
+func (e *errorSum) add(forecast int, observed float64) {
+	diff := float64(forecast) - observed
+	e.count++
+	e.sum += diff
+	e.absolute += math.Abs(diff)
+}
+
+func (e errorSum) stats() ErrorStats {
+	if e.count == 0 {
+		return ErrorStats{}
+	}
+	return ErrorStats{Count: e.count, Bias: e.sum / float64(e.count), MAE: e.absolute / float64(e.count)}
+}
+
+// tally は1つの取得元の予報と観測値の比較を数える
+type tally struct {
+	accuracy         Accuracy
+	maxTemp, minTemp errorSum
+	rain             RainStats
+}
+
+// add は前日の予報 (forecast) とその日の観測値 (observed の Observed* の値) を比べる
+func (t *tally) add(forecast Forecast, observed Record) {
+	compared := false
+	if forecast.HasMaxTemp && observed.HasObservedTemp {
+		t.maxTemp.add(forecast.MaxTemp, observed.ObservedMaxTemp)
+		compared = true
+	}
+	if forecast.HasMinTemp && observed.HasObservedTemp {
+		t.minTemp.add(forecast.MinTemp, observed.ObservedMinTemp)
+		compared = true
+	}
+	if forecast.HasRainChance && observed.HasObservedPrecipitation {
+		t.rain.Count++
+		if (forecast.RainChance >= RainThreshold) == observed.ObservedPrecipitation {
+			t.rain.Hits++
+		}
+		compared = true
+	}
+	if compared {
+		if t.accuracy.From == "" {
+			t.accuracy.From = forecast.Date
+		}
+		t.accuracy.To = forecast.Date
+		t.accuracy.Days++
+	}
+}
+
+// result は数えた比較から精度を求める
+func (t *tally) result() Accuracy {
+	accuracy := t.accuracy
+	accuracy.MaxTemp = t.maxTemp.stats()
+	accuracy.MinTemp = t.minTemp.stats()
+	accuracy.Rain = t.rain
+	if t.rain.Count > 0 {
+		accuracy.Rain.HitRate = float64(t.rain.Hits) / float64(t.rain.Count)
+	}
+	return accuracy
+}
+
+// Accuracies は地点 (cityCode) の記録から取得元ごとの前日の予報の精度を求め、取得元の名前の順に返す
+// 予報の取得元は予報ごとの取得元 (ない場合は記録の取得元) とし、その日に記録したアメダスの観測値と比べる
+// 観測値を記録していない日と、観測が途中の最後の記録の日は比べない
+func Accuracies(records []Record, cityCode string) []Accuracy {
+	var cityRecords []Record
+	for _, record := range records {
+		if record.CityCode == cityCode {
+			cityRecords = append(cityRecords, record)
+		}
+	}
+	sort.SliceStable(cityRecords, func(i, j int) bool {
+		return cityRecords[i].RecordedAt.Before(cityRecords[j].RecordedAt)
+	})
+
+	// 日ごとの最後の記録 (前日の予報に使う) と、日ごとの観測値
+	latest := make(map[string]Record)
+	observed := make(map[string]*Record)
+	for _, record := range cityRecords {
+		latest[record.Date] = record
+		if !record.HasObservedTemp && !record.HasObservedPrecipitation {
+			continue
+		}
+		if observed[record.Date] == nil {
+			observed[record.Date] = &Record{Date: record.Date}
+		}
+		observed[record.Date].mergeObserved(record)
+	}
+
+	dates := make([]string, 0, len(latest))
+	for date := range latest {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	if len(dates) > 0 {
+		delete(observed, dates[len(dates)-1])
+	}
+
+	tallies := make(map[string]*tally)
+	for _, issued := range dates {
+		record := latest[issued]
+		for _, forecast := range record.Forecasts {
+			day, ok := observed[forecast.Date]
+			if !ok || !isNextDay(issued, forecast.Date) {
+				continue
+			}
+			provider := forecast.Provider
+			if provider == "" {
+				provider = record.ProviderName()
+			}
+			if tallies[provider] == nil {
+				tallies[provider] = &tally{accuracy: Accuracy{Provider: provider}}
+			}
+			tallies[provider].add(forecast, *day)
+		}
+	}
+
+	accuracies := make([]Accuracy, 0, len(tallies))
+	for _, t := range tallies {
+		accuracies = append(accuracies, t.result())
+	}
+	sort.Slice(accuracies, func(i, j int) bool { return accuracies[i].Provider < accuracies[j].Provider })
+	return accuracies
+}
+
+// isNextDay は date が issued の翌日かどうかを返す (前日の予報だけを比べる)
+func isNextDay(issued, date string) bool {
+	from, err := time.Parse(DateLayout, issued)
+	if err != nil {
+		return false
+	}
+	to, err := time.Parse(DateLayout, date)
+	if err != nil {
+		return false
+	}
+	return from.AddDate(0, 0, 1).Equal(to)
+}
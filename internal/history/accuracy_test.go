@@ -0,0 +1,116 @@
+package history
+
+import (
+	"testing"
+)
+
+// forecastRecord は day 日 hour 時に記録した、明日の予報 (取得元ごと) を持つ記録を作る
+func forecastRecord(provider string, day, hour int, tomorrow ...Forecast) Record {
+	r := record(day, hour, 0, 0)
+	r.Provider = provider
+	for _, forecast := range tomorrow {
+		forecast.Date = at(day+1, 0).Format(DateLayout)
+		r.Forecasts = append(r.Forecasts, forecast)
+	}
+	return r
+}
+
+// observed は記録に今日これまでの観測値を加える
+func observed(r Record, maxTemp, minTemp float64, precipitation bool) Record {
+	r.ObservedMaxTemp, r.ObservedMinTemp, r.HasObservedTemp = maxTemp, minTemp, true
+	r.ObservedPrecipitation, r.HasObservedPrecipitation = precipitation, true
+	return r
+}
+
+func forecast(provider string, maxTemp, minTemp, rainChance int) Forecast {
+	return Forecast{
+		Provider: provider,
+		MaxTemp:  maxTemp, HasMaxTemp: true,
+		MinTemp: minTemp, HasMinTemp: true,
+		RainChance: rainChance, HasRainChance: true,
+	}
+}
+
+// Accuracies のテスト
+func TestAccuracies(t *testing.T) {
+	records := []Record{
+		// 取得元を記録する前の記録 (tsukumijima の予報とみなす)
+		forecastRecord("", 16, 17, forecast("", 22, 13, 30)),
+		forecastRecord("tsukumijima", 16, 9, forecast("", 99, 99, 0)), // 前日の最後の記録ではないので使わない
+		// 17日の観測値は3回の記録を合わせて 最高21.5℃・最低12.0℃・降水あり
+		observed(record(17, 6, 20, 12), 12.0, 12.0, false),
+		observed(record(17, 14, 20, 12), 21.5, 12.0, true),
+		observed(forecastRecord("tsukumijima+open-meteo", 17, 17,
+			forecast("tsukumijima", 18, 11, 70),
+			forecast("open-meteo", 19, 12, 60),
+		), 19.0, 19.0, false),
+		// 18日の観測値は 最高17.5℃・最低10.0℃・降水あり
+		observed(forecastRecord("tsukumijima+open-meteo", 18, 17,
+			forecast("tsukumijima", 20, 10, 0),
+		), 17.5, 10.0, true),
+		// 19日は観測値を記録していないため比べない
+		forecastRecord("tsukumijima+open-meteo", 19, 17, forecast("tsukumijima", 21, 11, 0)),
+		// 最後の記録の日 (20日) は観測が途中のため比べない
+		observed(record(20, 9, 20, 12), 18.0, 11.0, false),
+		// 別の地点
+		{Date: "2026-10-17", CityCode: "270000", Provider: "tsukumijima", ObservedMaxTemp: 30, HasObservedTemp: true},
+	}
+
+	accuracies := Accuracies(records, "130010")
+	if len(accuracies) != 2 {
+		t.Fatalf("期待: 2件, 実際: %d件 (%+v)", len(accuracies), accuracies)
+	}
+
+	openMeteo, tsukumijima := accuracies[0], accuracies[1]
+	if openMeteo.Provider != "open-meteo" || tsukumijima.Provider != "tsukumijima" {
+		t.Fatalf("取得元の順が異なります: %s, %s", openMeteo.Provider, tsukumijima.Provider)
+	}
+
+	// 17日: 予報 22/13 (降水確率30%) → 観測 21.5/12.0 降水あり
+	// 18日: 予報 18/11 (降水確率70%) → 観測 17.5/10.0 降水あり
+	if tsukumijima.Days != 2 || tsukumijima.From != "2026-10-17" || tsukumijima.To != "2026-10-18" {
+		t.Errorf("期間 期待: 2026-10-17〜2026-10-18 (2日), 実際: %s〜%s (%d日)", tsukumijima.From, tsukumijima.To, tsukumijima.Days)
+	}
+	if expected := (ErrorStats{Count: 2, Bias: 0.5, MAE: 0.5}); tsukumijima.MaxTemp != expected {
+		t.Errorf("最高気温 期待: %+v, 実際: %+v", expected, tsukumijima.MaxTemp)
+	}
+	if expected := (ErrorStats{Count: 2, Bias: 1, MAE: 1}); tsukumijima.MinTemp != expected {
+		t.Errorf("最低気温 期待: %+v, 実際: %+v", expected, tsukumijima.MinTemp)
+	}
+	if expected := (RainStats{Count: 2, Hits: 1, HitRate: 0.5}); tsukumijima.Rain != expected {
+		t.Errorf("雨 期待: %+v, 実際: %+v", expected, tsukumijima.Rain)
+	}
+
+	// 18日: 予報 19/12 (降水確率60%) → 観測 17.5/10.0 降水あり
+	if openMeteo.Days != 1 || openMeteo.From != "2026-10-18" || openMeteo.To != "2026-10-18" {
+		t.Errorf("open-meteo の期間 期待: 2026-10-18 (1日), 実際: %s〜%s (%d日)", openMeteo.From, openMeteo.To, openMeteo.Days)
+	}
+	if expected := (ErrorStats{Count: 1, Bias: 1.5, MAE: 1.5}); openMeteo.MaxTemp != expected {
+		t.Errorf("open-meteo の最高気温 期待: %+v, 実際: %+v", expected, openMeteo.MaxTemp)
+	}
+	if expected := (ErrorStats{Count: 1, Bias: 2, MAE: 2}); openMeteo.MinTemp != expected {
+		t.Errorf("open-meteo の最低気温 期待: %+v, 実際: %+v", expected, openMeteo.MinTemp)
+	}
+	if expected := (RainStats{Count: 1, Hits: 1, HitRate: 1}); openMeteo.Rain != expected {
+		t.Errorf("open-meteo の雨 期待: %+v, 実際: %+v", expected, openMeteo.Rain)
+	}
+}
+
+// Accuracies のテスト (観測値がない場合は予報の値と比べない)
+func TestAccuraciesWithoutObservation(t *testing.T) {
+	records := []Record{
+		forecastRecord("", 17, 17, forecast("", 18, 11, 0)),
+		record(18, 17, 18, 11), // 今日の予報だけで観測値がない
+		record(19, 9, 20, 12),
+	}
+	if accuracies := Accuracies(records, "130010"); len(accuracies) != 0 {
+		t.Errorf("期待: 空, 実際: %+v", accuracies)
+	}
+}
+
+// Accuracies のテスト (記録がない場合)
+func TestAccuraciesEmpty(t *testing.T) {
+	if accuracies := Accuracies(nil, "130010"); len(accuracies) != 0 {
+		t.Errorf("期待: 空, 実際: %+v", accuracies)
+	}
+}
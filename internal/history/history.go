@@ -0,0 +1,273 @@
+// Package history は取得した天気データの記録を扱う。
+// 記録は1行に1件の JSON を追記するファイル (JSON Lines) に保存し、
+// 過去の記録から日ごとの最高・最低気温をまとめて昨日や直近の平均と比べ、
+// 前日の予報と当日のアメダスの観測値を比べて取得元ごとの予報の精度を求める。
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DateLayout は記録の日付の形式
+const DateLayout = "2006-01-02"
+
+// DefaultProvider は取得元のない記録 (取得元を記録する前の記録) の取得元
+const DefaultProvider = "tsukumijima"
+
+// Record は1回の取得で記録する天気データ
+type Record struct {
+	Date          string     `json:"date"`                // 記録した日 (地点のタイムゾーンの日付)
+	RecordedAt    time.Time  `json:"recordedAt"`          // 記録した時刻
+	CityCode      string     `json:"cityCode"`            // 地点の都市コード
+	Location      string     `json:"location"`            // 地点名
+	Provider      string     `json:"provider,omitempty"`  // 天気予報の取得元 (空の場合は DefaultProvider。複数の取得元をまとめた場合は「+」でつなぐ)
+	MaxTemp       int        `json:"maxTemp"`             // 今日の最高気温 (℃)
+	HasMaxTemp    bool       `json:"hasMaxTemp"`          // 今日の最高気温があるかどうか
+	MinTemp       int        `json:"minTemp"`             // 今日の最低気温 (℃)
+	HasMinTemp    bool       `json:"hasMinTemp"`          // 今日の最低気温があるかどうか
+	WeatherCode   string     `json:"weatherCode"`         // 正規化した天気コード
+	Precipitation bool       `json:"precipitation"`       // 今日の天気に雨・雪を含むかどうか
+	Forecasts     []Forecast `json:"forecasts,omitempty"` // 明日以降の日ごとの予報 (取得元ごと)
+
+	ObservedMaxTemp          float64 `json:"observedMaxTemp,omitempty"`          // 今日これまでに観測した最高気温 (℃、アメダス)
+	ObservedMinTemp          float64 `json:"observedMinTemp,omitempty"`          // 今日これまでに観測した最低気温 (℃、アメダス)
+	HasObservedTemp          bool    `json:"hasObservedTemp,omitempty"`          // 観測した気温があるかどうか
+	ObservedPrecipitation    bool    `json:"observedPrecipitation,omitempty"`    // 今日これまでに降水を観測したかどうか (前1時間の降水量が0mmより多い)
+	HasObservedPrecipitation bool    `json:"hasObservedPrecipitation,omitempty"` // 降水量の観測値があるかどうか
+}
+
+// Forecast は記録した時点での明日以降の1日の予報
+type Forecast struct {
+	Provider      string `json:"provider,omitempty"` // 予報の取得元 (空の場合は記録の取得元)
+	Date          string `json:"date"`               // 予報の対象の日 (地点のタイムゾーンの日付)
+	MaxTemp       int    `json:"maxTemp"`
+	HasMaxTemp    bool   `json:"hasMaxTemp"`
+	MinTemp       int    `json:"minTemp"`
+	HasMinTemp    bool   `json:"hasMinTemp"`
+	RainChance    int    `json:"rainChance"` // 降水確率の最大値 (%)
+	HasRainChance bool   `json:"hasRainChance"`
+}
+
+// Observation は記録する時点のアメダスの観測値
+type Observation struct {
+	Temperature        float64 // 気温 (℃)
+	HasTemperature     bool
+	Precipitation1h    float64 // 前1時間の降水量 (mm)
+	HasPrecipitation1h bool
+}
+
+// Observe は観測値を記録に加える
+// 同じ地点・同じ日のこれまでの記録 (records) の観測値と合わせ、今日これまでの最高・最低気温と降水の有無にする
+// 記録は実行のたびに取るため、最高・最低気温は実行した時刻の観測値の中での最高・最低になる
+func (r *Record) Observe(records []Record, observation Observation) {
+	for _, record := range records {
+		if record.CityCode == r.CityCode && record.Date == r.Date {
+			r.mergeObserved(record)
+		}
+	}
+	r.mergeObserved(Record{
+		ObservedMaxTemp:          observation.Temperature,
+		ObservedMinTemp:          observation.Temperature,
+		HasObservedTemp:          observation.HasTemperature,
+		ObservedPrecipitation:    observation.Precipitation1h > 0,
+		HasObservedPrecipitation: observation.HasPrecipitation1h,
+	})
+}
+
+// mergeObserved は他の記録の観測値を合わせる
+func (r *Record) mergeObserved(other Record) {
+	if other.HasObservedTemp {
+		if !r.HasObservedTemp || other.ObservedMaxTemp > r.ObservedMaxTemp {
+			r.ObservedMaxTemp = other.ObservedMaxTemp
+		}
+		if !r.HasObservedTemp || other.ObservedMinTemp < r.ObservedMinTemp {
+			r.ObservedMinTemp = other.ObservedMinTemp
+		}
+		r.HasObservedTemp = true
+	}
+	if other.HasObservedPrecipitation {
+		r.ObservedPrecipitation = r.ObservedPrecipitation || other.ObservedPrecipitation
+		r.HasObservedPrecipitation = true
+	}
+}
+
+// ProviderName は記録の取得元を返す (取得元のない記録は DefaultProvider)
+func (r Record) ProviderName() string {
+	if r.Provider == "" {
+		return DefaultProvider
+	}
+	return r.Provider
+}
+
+// Store は記録のファイル
+type Store struct {
+	Path string // JSON Lines 形式のファイルのパス
+}
+
+// Append は記録をファイルの末尾に追記する (ファイルやディレクトリがない場合は作成する)
+func (s Store) Append(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Load はファイルのすべての記録を読み込む
+// ファイルがない場合は空を返し、書き込み途中で途切れた行など読めない行は読み飛ばす
+func (s Store) Load() ([]Record, error) {
+	body, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("天気の記録の読み込みに失敗しました: %w", err)
+	}
+
+	var records []Record
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Date == "" {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// Day は1日の最高・最低気温
+type Day struct {
+	Date       time.Time // 日付 (地点のタイムゾーンの0時)
+	MaxTemp    int
+	HasMaxTemp bool
+	MinTemp    int
+	HasMinTemp bool
+}
+
+// Days は地点 (cityCode) の記録を日ごとにまとめ、日付の順に返す
+// 同じ日の記録が複数ある場合は、最高・最低気温ごとに最後に記録した値を使う
+// (その日の実際の値に近い、後の発表の予報を優先する)
+func Days(records []Record, cityCode string, loc *time.Location) []Day {
+	sorted := make([]Record, 0, len(records))
+	for _, record := range records {
+		if record.CityCode == cityCode {
+			sorted = append(sorted, record)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RecordedAt.Before(sorted[j].RecordedAt)
+	})
+
+	byDate := make(map[string]*Day)
+	for _, record := range sorted {
+		day, ok := byDate[record.Date]
+		if !ok {
+			date, err := time.ParseInLocation(DateLayout, record.Date, loc)
+			if err != nil {
+				continue
+			}
+			day = &Day{Date: date}
+			byDate[record.Date] = day
+		}
+		if record.HasMaxTemp {
+			day.MaxTemp, day.HasMaxTemp = record.MaxTemp, true
+		}
+		if record.HasMinTemp {
+			day.MinTemp, day.HasMinTemp = record.MinTemp, true
+		}
+	}
+
+	days := make([]Day, 0, len(byDate))
+	for _, day := range byDate {
+		days = append(days, *day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date.Before(days[j].Date) })
+	return days
+}
+
+// Comparison は今日の気温と過去の気温の比較
+type Comparison struct {
+	Today        Day     // 今日
+	Yesterday    Day     // 昨日
+	HasYesterday bool    // 昨日の記録があるかどうか
+	MaxAverage   float64 // 今日より前の期間の最高気温の平均 (℃)
+	AverageDays  int     // 平均に使った日数 (最高気温の記録がある日のみ数える)
+}
+
+// Compare は日ごとの記録 (Days の結果) から、今日 (today の日付) と昨日、
+// および今日より前の window 日間の最高気温の平均を求める
+// 今日の記録がない場合は false を返す
+func Compare(days []Day, today time.Time, window int) (Comparison, bool) {
+	date := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	yesterday := date.AddDate(0, 0, -1)
+	from := date.AddDate(0, 0, -window)
+
+	var comparison Comparison
+	found := false
+	total := 0
+	for _, day := range days {
+		switch {
+		case day.Date.Equal(date):
+			comparison.Today = day
+			found = true
+		case day.Date.Equal(yesterday):
+			comparison.Yesterday = day
+			comparison.HasYesterday = true
+		}
+		if !day.Date.Before(from) && day.Date.Before(date) && day.HasMaxTemp {
+			total += day.MaxTemp
+			comparison.AverageDays++
+		}
+	}
+	if comparison.AverageDays > 0 {
+		comparison.MaxAverage = float64(total) / float64(comparison.AverageDays)
+	}
+	return comparison, found
+}
+
+// MaxDiff は今日の最高気温と昨日の最高気温の差 (今日 - 昨日) を返す
+// どちらかの記録がない場合は false を返す
+func (c Comparison) MaxDiff() (int, bool) {
+	if !c.HasYesterday || !c.Today.HasMaxTemp || !c.Yesterday.HasMaxTemp {
+		return 0, false
+	}
+	return c.Today.MaxTemp - c.Yesterday.MaxTemp, true
+}
+
+// Recent は today の日付までの直近 count 日の記録を返す (記録のない日は値のない Day で埋める)
+func Recent(days []Day, today time.Time, count int) []Day {
+	date := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	recent := make([]Day, count)
+	for i := range recent {
+		recent[i] = Day{Date: date.AddDate(0, 0, i-count+1)}
+	}
+	for _, day := range days {
+		for i := range recent {
+			if day.Date.Equal(recent[i].Date) {
+				recent[i] = day
+			}
+		}
+	}
+	return recent
+}
@@ -0,0 +1,212 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+func at(day, hour int) time.Time {
+	return time.Date(2026, 10, day, hour, 0, 0, 0, jst)
+}
+
+func record(day, hour, maxTemp, minTemp int) Record {
+	return Record{
+		Date:       at(day, 0).Format(DateLayout),
+		RecordedAt: at(day, hour),
+		CityCode:   "130010",
+		MaxTemp:    maxTemp,
+		HasMaxTemp: true,
+		MinTemp:    minTemp,
+		HasMinTemp: true,
+	}
+}
+
+// Store.Append / Store.Load のテスト
+func TestStore(t *testing.T) {
+	store := Store{Path: filepath.Join(t.TempDir(), "history", "history.jsonl")}
+
+	records, err := store.Load()
+	if err != nil || records != nil {
+		t.Fatalf("ファイルがない場合 期待: 空, 実際: %v, %v", records, err)
+	}
+
+	for _, r := range []Record{record(17, 9, 20, 12), record(18, 9, 15, 10)} {
+		if err := store.Append(r); err != nil {
+			t.Fatalf("追記に失敗しました: %v", err)
+		}
+	}
+
+	records, err = store.Load()
+	if err != nil {
+		t.Fatalf("読み込みに失敗しました: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("期待: 2件, 実際: %d件", len(records))
+	}
+	if records[1].Date != "2026-10-18" || records[1].MaxTemp != 15 || !records[1].RecordedAt.Equal(at(18, 9)) {
+		t.Errorf("2件目の記録が異なります: %+v", records[1])
+	}
+}
+
+// Store.Load のテスト (読めない行を読み飛ばす)
+func TestStoreLoadSkipsBrokenLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	body := `{"date":"2026-10-17","cityCode":"130010","maxTemp":20,"hasMaxTemp":true}
+{"date":"2026-10-18","cityCode":"130010","maxT
+{"date":"2026-10-18","cityCode":"130010","maxTemp":15,"hasMaxTemp":true}
+`
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := Store{Path: path}.Load()
+	if err != nil {
+		t.Fatalf("読み込みに失敗しました: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("期待: 2件, 実際: %d件", len(records))
+	}
+}
+
+// Days のテスト
+func TestDays(t *testing.T) {
+	morning := record(18, 6, 16, 10)
+	evening := record(18, 18, 17, 0)
+	evening.HasMinTemp = false // 夕方の発表は今日の最低気温がない
+	other := record(18, 12, 25, 20)
+	other.CityCode = "270000"
+
+	// 記録の順に関係なく、後に記録した値を使う
+	days := Days([]Record{evening, record(17, 12, 20, 12), morning, other}, "130010", jst)
+
+	if len(days) != 2 {
+		t.Fatalf("期待: 2日, 実際: %d日", len(days))
+	}
+	if !days[0].Date.Equal(at(17, 0)) || !days[1].Date.Equal(at(18, 0)) {
+		t.Errorf("日付の順が異なります: %v, %v", days[0].Date, days[1].Date)
+	}
+	if days[1].MaxTemp != 17 {
+		t.Errorf("最高気温 期待: 17 (夕方の記録), 実際: %d", days[1].MaxTemp)
+	}
+	if !days[1].HasMinTemp || days[1].MinTemp != 10 {
+		t.Errorf("最低気温 期待: 10 (朝の記録), 実際: %d (%v)", days[1].MinTemp, days[1].HasMinTemp)
+	}
+}
+
+// Compare のテスト
+func TestCompare(t *testing.T) {
+	var records []Record
+	for day, maxTemp := range map[int]int{8: 30, 11: 22, 12: 21, 14: 20, 15: 19, 16: 18, 17: 20, 18: 15} {
+		records = append(records, record(day, 9, maxTemp, 10))
+	}
+	days := Days(records, "130010", jst)
+
+	tests := []struct {
+		name         string
+		today        time.Time
+		found        bool
+		maxDiff      int
+		hasMaxDiff   bool
+		averageDays  int
+		maxAverage   float64
+		hasYesterday bool
+	}{
+		{
+			// 7日前 (11日) から昨日までの6日分の平均 (8日は期間外)
+			name:         "昨日と7日間の記録がある場合",
+			today:        at(18, 12),
+			found:        true,
+			maxDiff:      -5,
+			hasMaxDiff:   true,
+			averageDays:  6,
+			maxAverage:   20,
+			hasYesterday: true,
+		},
+		{
+			name:        "昨日の記録がない場合",
+			today:       at(14, 12),
+			found:       true,
+			averageDays: 3,
+			maxAverage:  (30 + 22 + 21) / 3.0,
+		},
+		{
+			name:  "今日の記録がない場合",
+			today: at(19, 12),
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison, found := Compare(days, tt.today, 7)
+			if found != tt.found {
+				t.Fatalf("期待: %v, 実際: %v", tt.found, found)
+			}
+			if !found {
+				return
+			}
+			if comparison.HasYesterday != tt.hasYesterday {
+				t.Errorf("HasYesterday 期待: %v, 実際: %v", tt.hasYesterday, comparison.HasYesterday)
+			}
+			maxDiff, ok := comparison.MaxDiff()
+			if ok != tt.hasMaxDiff || maxDiff != tt.maxDiff {
+				t.Errorf("MaxDiff 期待: %d (%v), 実際: %d (%v)", tt.maxDiff, tt.hasMaxDiff, maxDiff, ok)
+			}
+			if comparison.AverageDays != tt.averageDays || comparison.MaxAverage != tt.maxAverage {
+				t.Errorf("平均 期待: %.2f (%d日), 実際: %.2f (%d日)", tt.maxAverage, tt.averageDays, comparison.MaxAverage, comparison.AverageDays)
+			}
+		})
+	}
+}
+
+// Recent のテスト
+func TestRecent(t *testing.T) {
+	days := Days([]Record{record(15, 9, 19, 11), record(17, 9, 20, 12), record(18, 9, 15, 10)}, "130010", jst)
+
+	recent := Recent(days, at(18, 12), 4)
+	if len(recent) != 4 {
+		t.Fatalf("期待: 4日, 実際: %d日", len(recent))
+	}
+	expected := []struct {
+		day        int
+		hasMaxTemp bool
+	}{{15, true}, {16, false}, {17, true}, {18, true}}
+	for i, e := range expected {
+		if !recent[i].Date.Equal(at(e.day, 0)) || recent[i].HasMaxTemp != e.hasMaxTemp {
+			t.Errorf("[%d] 期待: %d日 (%v), 実際: %v (%v)", i, e.day, e.hasMaxTemp, recent[i].Date, recent[i].HasMaxTemp)
+		}
+	}
+}
+
+// Record.Observe のテスト
+func TestRecordObserve(t *testing.T) {
+	records := []Record{
+		observed(record(18, 6, 20, 12), 10.5, 10.5, false),
+		observed(record(18, 12, 20, 12), 19.0, 19.0, true),
+		observed(record(17, 14, 20, 12), 30.0, 5.0, false),                                                        // 別の日
+		{Date: "2026-10-18", CityCode: "270000", ObservedMaxTemp: 25, ObservedMinTemp: -5, HasObservedTemp: true}, // 別の地点
+	}
+
+	r := record(18, 17, 20, 12)
+	r.Observe(records, Observation{Temperature: 16.2, HasTemperature: true, Precipitation1h: 0, HasPrecipitation1h: true})
+	if !r.HasObservedTemp || r.ObservedMaxTemp != 19.0 || r.ObservedMinTemp != 10.5 {
+		t.Errorf("気温 期待: 最高19.0℃・最低10.5℃, 実際: 最高%.1f℃・最低%.1f℃ (%v)", r.ObservedMaxTemp, r.ObservedMinTemp, r.HasObservedTemp)
+	}
+	if !r.HasObservedPrecipitation || !r.ObservedPrecipitation {
+		t.Errorf("今日これまでに降水を観測しているはずです: %+v", r)
+	}
+
+	// 気温が欠測で、これまでの記録もない場合
+	first := record(18, 1, 20, 12)
+	first.Observe(nil, Observation{Precipitation1h: 0.5, HasPrecipitation1h: true})
+	if first.HasObservedTemp {
+		t.Errorf("期待: 気温の観測値なし, 実際: %+v", first)
+	}
+	if !first.HasObservedPrecipitation || !first.ObservedPrecipitation {
+		t.Errorf("期待: 降水あり, 実際: %+v", first)
+	}
+}
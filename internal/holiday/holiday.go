@@ -0,0 +1,250 @@
+// Package holiday は日本の祝日・休日をネットワークなしで計算する。
+// 「国民の祝日に関する法律」の改正履歴 (ハッピーマンデー、振替休日・国民の休日の規定変更、
+// 2019年の天皇の即位、2020/2021年の東京オリンピックに伴う移動) を反映している。
+package holiday
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// 計算できる年の範囲 (春分・秋分の近似式が有効な範囲)
+const (
+	MinYear = 1980
+	MaxYear = 2150
+)
+
+// 振替休日・国民の休日の名前
+const (
+	SubstituteHolidayName = "振替休日"
+	CitizensHolidayName   = "国民の休日"
+)
+
+// Holiday は祝日・休日
+type Holiday struct {
+	Date time.Time // 日付 (UTCの0時)
+	Name string    // 祝日名
+}
+
+// date は年月日からUTCの0時の日付を返す
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// truncate は時刻のタイムゾーンでの日付をUTCの0時に揃える
+func truncate(t time.Time) time.Time {
+	return date(t.Year(), t.Month(), t.Day())
+}
+
+// nthMonday は指定した月の第n月曜日の日付を返す
+func nthMonday(year int, month time.Month, n int) time.Time {
+	first := date(year, month, 1)
+	offset := (int(time.Monday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
+
+// 春分日・秋分日の近似式の定数 (1980〜2099年 / 2100〜2150年)
+var (
+	vernalEquinoxBase   = [2]float64{20.8431, 21.8510}
+	autumnalEquinoxBase = [2]float64{23.2488, 24.2488}
+)
+
+// equinoxDay は春分日・秋分日の日付を近似式で計算する
+// 2100年はグレゴリオ暦で閏年にならないため、2100年以降は定数を切り替える
+func equinoxDay(year int, base [2]float64) int {
+	constant := base[0]
+	if year >= 2100 {
+		constant = base[1]
+	}
+	elapsed := float64(year - 1980)
+	return int(math.Floor(constant + 0.242194*elapsed - math.Floor(elapsed/4)))
+}
+
+// nationalHolidays は「国民の祝日」(振替休日・国民の休日を除く) を返す
+func nationalHolidays(year int) map[time.Time]string {
+	holidays := map[time.Time]string{}
+	add := func(t time.Time, name string) { holidays[t] = name }
+
+	add(date(year, time.January, 1), "元日")
+
+	if year >= 2000 {
+		add(nthMonday(year, time.January, 2), "成人の日")
+	} else {
+		add(date(year, time.January, 15), "成人の日")
+	}
+
+	add(date(year, time.February, 11), "建国記念の日")
+
+	switch {
+	case year >= 2020:
+		add(date(year, time.February, 23), "天皇誕生日")
+	case year >= 1989 && year <= 2018:
+		add(date(year, time.December, 23), "天皇誕生日")
+	case year <= 1988:
+		add(date(year, time.April, 29), "天皇誕生日")
+	}
+
+	add(date(year, time.March, equinoxDay(year, vernalEquinoxBase)), "春分の日")
+
+	switch {
+	case year >= 2007:
+		add(date(year, time.April, 29), "昭和の日")
+		add(date(year, time.May, 4), "みどりの日")
+	case year >= 1989:
+		add(date(year, time.April, 29), "みどりの日")
+	}
+
+	add(date(year, time.May, 3), "憲法記念日")
+	add(date(year, time.May, 5), "こどもの日")
+
+	switch {
+	case year == 2020:
+		add(date(year, time.July, 23), "海の日")
+	case year == 2021:
+		add(date(year, time.July, 22), "海の日")
+	case year >= 2003:
+		add(nthMonday(year, time.July, 3), "海の日")
+	case year >= 1996:
+		add(date(year, time.July, 20), "海の日")
+	}
+
+	switch {
+	case year == 2020:
+		add(date(year, time.August, 10), "山の日")
+	case year == 2021:
+		add(date(year, time.August, 8), "山の日")
+	case year >= 2016:
+		add(date(year, time.August, 11), "山の日")
+	}
+
+	if year >= 2003 {
+		add(nthMonday(year, time.September, 3), "敬老の日")
+	} else {
+		add(date(year, time.September, 15), "敬老の日")
+	}
+
+	add(date(year, time.September, equinoxDay(year, autumnalEquinoxBase)), "秋分の日")
+
+	switch {
+	case year == 2020:
+		add(date(year, time.July, 24), "スポーツの日")
+	case year == 2021:
+		add(date(year, time.July, 23), "スポーツの日")
+	case year >= 2022:
+		add(nthMonday(year, time.October, 2), "スポーツの日")
+	case year >= 2000:
+		add(nthMonday(year, time.October, 2), "体育の日")
+	default:
+		add(date(year, time.October, 10), "体育の日")
+	}
+
+	add(date(year, time.November, 3), "文化の日")
+	add(date(year, time.November, 23), "勤労感謝の日")
+
+	// 皇室の慶弔行事に伴う休日
+	switch year {
+	case 1989:
+		add(date(year, time.February, 24), "昭和天皇の大喪の礼")
+	case 1990:
+		add(date(year, time.November, 12), "即位礼正殿の儀")
+	case 1993:
+		add(date(year, time.June, 9), "皇太子徳仁親王の結婚の儀")
+	case 2019:
+		add(date(year, time.May, 1), "天皇の即位の日")
+		add(date(year, time.October, 22), "即位礼正殿の儀")
+	}
+
+	return holidays
+}
+
+// InYear は指定した年の祝日・休日を日付順に返す
+// 計算できる範囲 (MinYear〜MaxYear) 外の年は nil を返す
+func InYear(year int) []Holiday {
+	if year < MinYear || year > MaxYear {
+		return nil
+	}
+
+	national := nationalHolidays(year)
+	isNational := func(t time.Time) bool {
+		_, ok := national[t]
+		return ok
+	}
+
+	holidays := map[time.Time]string{}
+	for t, name := range national {
+		holidays[t] = name
+	}
+
+	// 振替休日: 祝日が日曜日にあたる場合
+	// 2007年以降は翌日以降で最も近い祝日でない日、それ以前は翌日
+	for t := range national {
+		if t.Weekday() != time.Sunday {
+			continue
+		}
+		substitute := t.AddDate(0, 0, 1)
+		if year >= 2007 {
+			for isNational(substitute) {
+				substitute = substitute.AddDate(0, 0, 1)
+			}
+		} else if isNational(substitute) {
+			continue
+		}
+		holidays[substitute] = SubstituteHolidayName
+	}
+
+	// 国民の休日: 前日と翌日が祝日である日 (1986年以降)
+	// 2006年以前は日曜日と振替休日を除く
+	if year >= 1986 {
+		for t := range national {
+			between := t.AddDate(0, 0, 1)
+			if !isNational(between.AddDate(0, 0, 1)) || isNational(between) {
+				continue
+			}
+			if _, ok := holidays[between]; ok {
+				continue
+			}
+			if year < 2007 && between.Weekday() == time.Sunday {
+				continue
+			}
+			holidays[between] = CitizensHolidayName
+		}
+	}
+
+	result := make([]Holiday, 0, len(holidays))
+	for t, name := range holidays {
+		result = append(result, Holiday{Date: t, Name: name})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+	return result
+}
+
+// Lookup は指定した日が祝日・休日であればその名前を返す
+// 日付は t のタイムゾーンでの年月日で判定する
+func Lookup(t time.Time) (string, bool) {
+	day := truncate(t)
+	for _, h := range InYear(day.Year()) {
+		if h.Date.Equal(day) {
+			return h.Name, true
+		}
+	}
+	return "", false
+}
+
+// Next は指定した日より後の最初の祝日・休日を返す
+func Next(t time.Time) (Holiday, bool) {
+	day := truncate(t)
+	for year := day.Year(); year <= day.Year()+1; year++ {
+		for _, h := range InYear(year) {
+			if h.Date.After(day) {
+				return h, true
+			}
+		}
+	}
+	return Holiday{}, false
+}
+
+// DaysUntil は from の日付から to の日付までの日数を返す
+func DaysUntil(from time.Time, to time.Time) int {
+	return int(truncate(to).Sub(truncate(from)).Hours() / 24)
+}
@@ -0,0 +1,188 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+)
+
+// Lookup のテスト (法改正のあった年の境界を中心に確認する)
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     string
+		expected string // 空文字列の場合は祝日でない
+	}{
+		{name: "元日", date: "2025-01-01", expected: "元日"},
+		{name: "成人の日 (1999年までは1月15日)", date: "1999-01-15", expected: "成人の日"},
+		{name: "成人の日 (2000年から第2月曜日)", date: "2000-01-10", expected: "成人の日"},
+		{name: "2000年の1月15日は祝日でない", date: "2000-01-15", expected: ""},
+		{name: "天皇誕生日 (昭和)", date: "1988-04-29", expected: "天皇誕生日"},
+		{name: "昭和天皇の大喪の礼", date: "1989-02-24", expected: "昭和天皇の大喪の礼"},
+		{name: "みどりの日 (1989年から4月29日)", date: "1989-04-29", expected: "みどりの日"},
+		{name: "天皇誕生日 (平成)", date: "2018-12-23", expected: "天皇誕生日"},
+		{name: "2019年は天皇誕生日がない", date: "2019-12-23", expected: ""},
+		{name: "天皇誕生日 (令和)", date: "2020-02-23", expected: "天皇誕生日"},
+		{name: "即位礼正殿の儀 (1990年)", date: "1990-11-12", expected: "即位礼正殿の儀"},
+		{name: "結婚の儀", date: "1993-06-09", expected: "皇太子徳仁親王の結婚の儀"},
+		{name: "天皇の即位の日", date: "2019-05-01", expected: "天皇の即位の日"},
+		{name: "2019年GWの国民の休日 (4月30日)", date: "2019-04-30", expected: "国民の休日"},
+		{name: "2019年GWの国民の休日 (5月2日)", date: "2019-05-02", expected: "国民の休日"},
+		{name: "即位礼正殿の儀 (2019年)", date: "2019-10-22", expected: "即位礼正殿の儀"},
+		{name: "5月4日の国民の休日 (2006年まで)", date: "2006-05-04", expected: "国民の休日"},
+		{name: "みどりの日 (2007年から5月4日)", date: "2007-05-04", expected: "みどりの日"},
+		{name: "昭和の日", date: "2007-04-29", expected: "昭和の日"},
+		{name: "海の日 (2002年までは7月20日)", date: "2002-07-20", expected: "海の日"},
+		{name: "海の日 (2003年から第3月曜日)", date: "2003-07-21", expected: "海の日"},
+		{name: "海の日 (2020年は東京オリンピックで移動)", date: "2020-07-23", expected: "海の日"},
+		{name: "スポーツの日 (2020年)", date: "2020-07-24", expected: "スポーツの日"},
+		{name: "2020年の10月第2月曜日は祝日でない", date: "2020-10-12", expected: ""},
+		{name: "山の日 (2021年)", date: "2021-08-08", expected: "山の日"},
+		{name: "山の日の振替休日 (2021年)", date: "2021-08-09", expected: "振替休日"},
+		{name: "2015年より前は山の日がない", date: "2015-08-11", expected: ""},
+		{name: "山の日 (2016年から)", date: "2016-08-11", expected: "山の日"},
+		{name: "敬老の日 (2002年までは9月15日)", date: "2002-09-15", expected: "敬老の日"},
+		{name: "敬老の日 (2003年から第3月曜日)", date: "2003-09-15", expected: "敬老の日"},
+		{name: "体育の日 (1999年までは10月10日)", date: "1999-10-10", expected: "体育の日"},
+		{name: "体育の日 (2000年から第2月曜日)", date: "2000-10-09", expected: "体育の日"},
+		{name: "スポーツの日 (2022年から)", date: "2022-10-10", expected: "スポーツの日"},
+		{name: "シルバーウィークの国民の休日 (2009年)", date: "2009-09-22", expected: "国民の休日"},
+		{name: "シルバーウィークの国民の休日 (2015年)", date: "2015-09-22", expected: "国民の休日"},
+		{name: "シルバーウィークの国民の休日 (2026年)", date: "2026-09-22", expected: "国民の休日"},
+		{name: "振替休日 (元日が日曜日)", date: "2023-01-02", expected: "振替休日"},
+		{name: "振替休日 (2007年以降は祝日の翌日以降にずれる)", date: "2008-05-06", expected: "振替休日"},
+		{name: "振替休日 (2006年までは翌日が祝日ならなし)", date: "2003-05-06", expected: ""},
+		{name: "振替休日 (2020年GW)", date: "2020-05-06", expected: "振替休日"},
+		{name: "平日", date: "2025-06-10", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, err := time.Parse("2006-01-02", tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			name, ok := Lookup(day)
+			if ok != (tt.expected != "") {
+				t.Fatalf("祝日判定: 期待=%v, 実際=%v (%s)", tt.expected != "", ok, name)
+			}
+			if name != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, name)
+			}
+		})
+	}
+}
+
+// 春分の日・秋分の日のテスト (官報で公表された日付)
+func TestEquinox(t *testing.T) {
+	tests := []struct {
+		year     int
+		vernal   int
+		autumnal int
+	}{
+		{year: 1980, vernal: 20, autumnal: 23},
+		{year: 2012, vernal: 20, autumnal: 22},
+		{year: 2019, vernal: 21, autumnal: 23},
+		{year: 2023, vernal: 21, autumnal: 23},
+		{year: 2024, vernal: 20, autumnal: 22},
+		{year: 2025, vernal: 20, autumnal: 23},
+		{year: 2026, vernal: 20, autumnal: 23},
+	}
+
+	for _, tt := range tests {
+		vernal := equinoxDay(tt.year, vernalEquinoxBase)
+		autumnal := equinoxDay(tt.year, autumnalEquinoxBase)
+		if vernal != tt.vernal {
+			t.Errorf("%d年の春分日: 期待=%d, 実際=%d", tt.year, tt.vernal, vernal)
+		}
+		if autumnal != tt.autumnal {
+			t.Errorf("%d年の秋分日: 期待=%d, 実際=%d", tt.year, tt.autumnal, autumnal)
+		}
+	}
+}
+
+// InYear のテスト
+func TestInYear(t *testing.T) {
+	t.Run("祝日・休日の件数", func(t *testing.T) {
+		expected := map[int]int{
+			2019: 22, // 天皇の即位に伴う10連休を含む
+			2020: 18,
+			2025: 19,
+			2026: 18,
+		}
+		for year, count := range expected {
+			if result := len(InYear(year)); result != count {
+				t.Errorf("%d年: 期待=%d件, 実際=%d件", year, count, result)
+			}
+		}
+	})
+
+	t.Run("日付順に並ぶ", func(t *testing.T) {
+		holidays := InYear(2026)
+		for i := 1; i < len(holidays); i++ {
+			if !holidays[i-1].Date.Before(holidays[i].Date) {
+				t.Errorf("日付順になっていません: %v, %v", holidays[i-1].Date, holidays[i].Date)
+			}
+		}
+	})
+
+	t.Run("範囲外の年", func(t *testing.T) {
+		if InYear(MinYear-1) != nil || InYear(MaxYear+1) != nil {
+			t.Error("期待: nil")
+		}
+	})
+}
+
+// Next / DaysUntil のテスト
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     time.Time
+		expected string
+		holiday  string
+		days     int
+	}{
+		{name: "同じ月の祝日", from: time.Date(2026, 10, 18, 9, 0, 0, 0, jst), expected: "2026-11-03", holiday: "文化の日", days: 16},
+		{name: "祝日当日は次の祝日", from: time.Date(2026, 11, 3, 0, 0, 0, 0, jst), expected: "2026-11-23", holiday: "勤労感謝の日", days: 20},
+		{name: "年をまたぐ", from: time.Date(2026, 12, 1, 23, 59, 0, 0, jst), expected: "2027-01-01", holiday: "元日", days: 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok := Next(tt.from)
+			if !ok {
+				t.Fatal("期待: 次の祝日あり, 実際: なし")
+			}
+			if next.Date.Format("2006-01-02") != tt.expected || next.Name != tt.holiday {
+				t.Errorf("期待: %s %s, 実際: %s %s", tt.expected, tt.holiday, next.Date.Format("2006-01-02"), next.Name)
+			}
+			if days := DaysUntil(tt.from, next.Date); days != tt.days {
+				t.Errorf("日数: 期待=%d, 実際=%d", tt.days, days)
+			}
+		})
+	}
+}
+
+// Rokuyo のテスト
+func TestRokuyo(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected string
+	}{
+		{name: "旧暦1月1日 (2025年)", date: time.Date(2025, 1, 29, 0, 0, 0, 0, jst), expected: "先勝"},
+		{name: "旧暦1月1日 (2024年)", date: time.Date(2024, 2, 10, 0, 0, 0, 0, jst), expected: "先勝"},
+		{name: "中秋 (2024年)", date: time.Date(2024, 9, 17, 12, 0, 0, 0, jst), expected: "仏滅"},
+		{name: "中秋 (2025年)", date: time.Date(2025, 10, 6, 23, 0, 0, 0, jst), expected: "仏滅"},
+		{name: "閏2月1日 (2023年)", date: time.Date(2023, 3, 22, 0, 0, 0, 0, jst), expected: "友引"},
+		{name: "閏6月1日 (2025年)", date: time.Date(2025, 7, 25, 0, 0, 0, 0, jst), expected: "赤口"},
+		{name: "朔旦冬至 (2014年)", date: time.Date(2014, 12, 22, 0, 0, 0, 0, jst), expected: "大安"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Rokuyo(tt.date); result != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package holiday
+
+import (
+	"math"
+	"time"
+)
+
+// rokuyoNames は (旧暦の月 + 日) を6で割った余りに対応する六曜
+var rokuyoNames = [6]string{"大安", "赤口", "先勝", "友引", "先負", "仏滅"}
+
+// jst は旧暦の日付を決める基準の時刻 (日本標準時)
+var jst = time.FixedZone("JST", 9*60*60)
+
+// 暦の計算に使う定数
+const (
+	unixEpochJD = 2440587.5       // 1970-01-01T00:00:00Z のユリウス日
+	j2000JD     = 2451545.0       // 2000-01-01T12:00:00 TT のユリウス日
+	deltaT      = 69.0 / 86400.0  // 地球時(TT)と世界時(UT)の差 (日)
+	synodicDays = 29.530588861    // 朔望月の平均日数
+	degree      = math.Pi / 180.0 // 度からラジアンへの変換
+)
+
+// Rokuyo は指定した日の六曜を返す
+// 日付は t のタイムゾーンでの年月日で判定する
+func Rokuyo(t time.Time) string {
+	month, day := lunarDate(t.Year(), t.Month(), t.Day())
+	return rokuyoNames[(month+day)%6]
+}
+
+// lunarDate は指定した日の旧暦(天保暦)の月と日を返す
+// 閏月は前の月と同じ月番号を返す (六曜は閏月でも月番号で決まるため)
+func lunarDate(year int, month time.Month, day int) (int, int) {
+	target := time.Date(year, month, day, 0, 0, 0, 0, jst)
+
+	// 指定した日以前で最も近い朔(新月)を探す
+	k := math.Floor((float64(year) + (float64(month)-0.5)/12 - 2000) * 12.3685)
+	for newMoonDate(k).After(target) {
+		k--
+	}
+	for !newMoonDate(k + 1).After(target) {
+		k++
+	}
+
+	lunarDay := int(target.Sub(newMoonDate(k)).Hours()/24) + 1
+	return lunarMonth(k), lunarDay
+}
+
+// lunarMonth は k 番目の朔から始まる月の月番号を返す
+// 月番号はその月に含まれる中気(太陽黄経が30度の倍数になる日)で決まる
+// 中気を含まない月は閏月で、前の月と同じ月番号になる
+func lunarMonth(k float64) int {
+	for i := 0; i < 2; i++ {
+		start := sunLongitude(julianDay(newMoonDate(k - float64(i))))
+		end := sunLongitude(julianDay(newMoonDate(k - float64(i) + 1)))
+		if end < start {
+			end += 360
+		}
+		if chuki := math.Ceil(start/30) * 30; chuki < end {
+			// 春分(黄経0度)を含む月が2月、雨水(黄経330度)を含む月が1月
+			return (int(chuki/30)%12+1)%12 + 1
+		}
+	}
+	return 0
+}
+
+// newMoonDate は k 番目の朔(2000年1月6日を0とする)を含む日の日本時間の0時を返す
+func newMoonDate(k float64) time.Time {
+	jd := newMoonJD(k) - deltaT
+	seconds := (jd - unixEpochJD) * 86400
+	t := time.Unix(int64(math.Floor(seconds)), 0).In(jst)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, jst)
+}
+
+// julianDay は時刻のユリウス日を返す
+func julianDay(t time.Time) float64 {
+	return float64(t.Unix())/86400 + unixEpochJD
+}
+
+// newMoonJD は k 番目の朔の時刻を力学時のユリウス日で返す
+// Jean Meeus "Astronomical Algorithms" 第49章の計算式 (誤差は数十秒程度)
+func newMoonJD(k float64) float64 {
+	T := k / 1236.85
+	T2, T3, T4 := T*T, T*T*T, T*T*T*T
+
+	jde := 2451550.09766 + synodicDays*k + 0.00015437*T2 - 0.000000150*T3 + 0.00000000073*T4
+
+	E := 1 - 0.002516*T - 0.0000074*T2
+	M := (2.5534 + 29.10535670*k - 0.0000014*T2 - 0.00000011*T3) * degree
+	Mp := (201.5643 + 385.81693528*k + 0.0107582*T2 + 0.00001238*T3 - 0.000000058*T4) * degree
+	F := (160.7108 + 390.67050284*k - 0.0016118*T2 - 0.00000227*T3 + 0.000000011*T4) * degree
+	Omega := (124.7746 - 1.56375588*k + 0.0020672*T2 + 0.00000215*T3) * degree
+
+	correction := -0.40720*math.Sin(Mp) +
+		0.17241*E*math.Sin(M) +
+		0.01608*math.Sin(2*Mp) +
+		0.01039*math.Sin(2*F) +
+		0.00739*E*math.Sin(Mp-M) -
+		0.00514*E*math.Sin(Mp+M) +
+		0.00208*E*E*math.Sin(2*M) -
+		0.00111*math.Sin(Mp-2*F) -
+		0.00057*math.Sin(Mp+2*F) +
+		0.00056*E*math.Sin(2*Mp+M) -
+		0.00042*math.Sin(3*Mp) +
+		0.00042*E*math.Sin(M+2*F) +
+		0.00038*E*math.Sin(M-2*F) -
+		0.00024*E*math.Sin(2*Mp-M) -
+		0.00017*math.Sin(Omega) -
+		0.00007*math.Sin(Mp+2*M) +
+		0.00004*math.Sin(2*Mp-2*F) +
+		0.00004*math.Sin(3*M) +
+		0.00003*math.Sin(Mp+M-2*F) +
+		0.00003*math.Sin(2*Mp+2*F) -
+		0.00003*math.Sin(Mp+M+2*F) +
+		0.00003*math.Sin(Mp-M+2*F) -
+		0.00002*math.Sin(Mp-M-2*F) -
+		0.00002*math.Sin(3*Mp+M) +
+		0.00002*math.Sin(4*Mp)
+
+	// 惑星による摂動
+	planetary := []struct{ coefficient, base, rate float64 }{
+		{0.000325, 299.77, 0.107408},
+		{0.000165, 251.88, 0.016321},
+		{0.000164, 251.83, 26.651886},
+		{0.000126, 349.42, 36.412478},
+		{0.000110, 84.66, 18.206239},
+		{0.000062, 141.74, 53.303771},
+		{0.000060, 207.14, 2.453732},
+		{0.000056, 154.84, 7.306860},
+		{0.000047, 34.52, 27.261239},
+		{0.000042, 207.19, 0.121824},
+		{0.000040, 291.34, 1.844379},
+		{0.000037, 161.72, 24.198154},
+		{0.000035, 239.56, 25.513099},
+		{0.000023, 331.55, 3.592518},
+	}
+	for i, p := range planetary {
+		angle := p.base + p.rate*k
+		if i == 0 {
+			angle -= 0.009173 * T2
+		}
+		correction += p.coefficient * math.Sin(angle*degree)
+	}
+
+	return jde + correction
+}
+
+// sunLongitude は太陽の視黄経(度, 0〜360)を返す
+// Jean Meeus "Astronomical Algorithms" 第25章の簡易式 (誤差は0.01度程度)
+func sunLongitude(jd float64) float64 {
+	T := (jd + deltaT - j2000JD) / 36525
+	L0 := 280.46646 + 36000.76983*T + 0.0003032*T*T
+	M := (357.52911 + 35999.05029*T - 0.0001537*T*T) * degree
+	C := (1.914602-0.004817*T-0.000014*T*T)*math.Sin(M) +
+		(0.019993-0.000101*T)*math.Sin(2*M) +
+		0.000289*math.Sin(3*M)
+	Omega := (125.04 - 1934.136*T) * degree
+	longitude := L0 + C - 0.00569 - 0.00478*math.Sin(Omega)
+	return math.Mod(math.Mod(longitude, 360)+360, 360)
+}
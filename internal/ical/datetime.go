@@ -0,0 +1,92 @@
+package ical
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// windowsTimeZones は Outlook などが TZID に使う Windows のタイムゾーン名と IANA 名の対応
+var windowsTimeZones = map[string]string{
+	"Tokyo Standard Time":   "Asia/Tokyo",
+	"UTC":                   "UTC",
+	"GMT Standard Time":     "Europe/London",
+	"Pacific Standard Time": "America/Los_Angeles",
+	"Eastern Standard Time": "America/New_York",
+	"Korea Standard Time":   "Asia/Seoul",
+	"China Standard Time":   "Asia/Shanghai",
+}
+
+// resolveLocation は TZID からタイムゾーンを返す (解決できない場合は loc)
+func resolveLocation(tzid string, loc *time.Location) *time.Location {
+	tzid = strings.TrimPrefix(strings.Trim(tzid, `"`), "/")
+	if tzid == "" {
+		return loc
+	}
+	if name, ok := windowsTimeZones[tzid]; ok {
+		tzid = name
+	}
+	if location, err := time.LoadLocation(tzid); err == nil {
+		return location
+	}
+	return loc
+}
+
+// parseDateTime は DATE / DATE-TIME 型の値をパースする
+// 戻り値の bool は日付のみ (終日) かどうか
+func parseDateTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	location := resolveLocation(params["TZID"], loc)
+
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("日付の形式が不正です: %q", value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("日時の形式が不正です: %q", value)
+		}
+		return t.In(location), false, nil
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, location)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("日時の形式が不正です: %q", value)
+	}
+	return t, false, nil
+}
+
+// durationPattern は DURATION 型の値 (例: P1D, PT1H30M, P2W, -PT15M)
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration は DURATION 型の値をパースする
+func parseDuration(value string) (time.Duration, error) {
+	match := durationPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("期間の形式が不正です: %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var duration time.Duration
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("期間の形式が不正です: %q", value)
+		}
+		duration += time.Duration(n) * unit
+	}
+	if match[1] == "-" {
+		duration = -duration
+	}
+	return duration, nil
+}
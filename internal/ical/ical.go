@@ -0,0 +1,362 @@
+// Package ical は iCalendar (RFC 5545) 形式の予定を読み込み、指定した期間の予定を展開する。
+// VEVENT の繰り返し (RRULE)、除外日 (EXDATE)、繰り返しの個別変更 (RECURRENCE-ID)、
+// 終日の予定、タイムゾーン (TZID) に対応する。VTODO などの予定以外の要素は無視する。
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Event は VEVENT 1件分の予定
+type Event struct {
+	UID          string
+	Summary      string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool        // 終日の予定 (DTSTART が日付のみ)
+	Rule         *Rule       // 繰り返しの規則 (繰り返さない場合は nil)
+	ExDates      []time.Time // 繰り返しから除外する日時
+	RecurrenceID time.Time   // 繰り返しの個別変更の場合、変更前の開始日時
+	Cancelled    bool        // STATUS:CANCELLED
+
+	duration time.Duration // DURATION で指定された長さ
+}
+
+// Calendar は ICS ファイル1つ分の予定
+type Calendar struct {
+	Events []Event
+}
+
+// Occurrence は期間内に展開した予定の1回分
+type Occurrence struct {
+	UID      string
+	Summary  string
+	Location string
+	Start    time.Time
+	End      time.Time
+	AllDay   bool
+}
+
+// property はコンテンツ行 (NAME;PARAM=VALUE:VALUE) をパースしたもの
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse は ICS の内容をパースする
+// タイムゾーンの指定がない日時 (floating time) と終日の予定は loc の時刻として扱う
+func Parse(data []byte, loc *time.Location) (*Calendar, error) {
+	lines := unfold(data)
+	calendar := &Calendar{}
+
+	var current *Event
+	var depth []string
+	for number, line := range lines {
+		if line == "" {
+			continue
+		}
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("%d行目: %w", number+1, err)
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			depth = append(depth, strings.ToUpper(prop.value))
+			if strings.EqualFold(prop.value, "VEVENT") {
+				current = &Event{}
+			}
+			continue
+		case "END":
+			if len(depth) == 0 || !strings.EqualFold(depth[len(depth)-1], prop.value) {
+				return nil, fmt.Errorf("%d行目: BEGIN と END が対応していません: %s", number+1, prop.value)
+			}
+			depth = depth[:len(depth)-1]
+			if strings.EqualFold(prop.value, "VEVENT") && current != nil {
+				if err := finishEvent(current); err != nil {
+					return nil, fmt.Errorf("%d行目: %w", number+1, err)
+				}
+				calendar.Events = append(calendar.Events, *current)
+				current = nil
+			}
+			continue
+		}
+
+		// VEVENT 直下のプロパティのみ読む (VALARM などの入れ子は無視する)
+		if current == nil || len(depth) == 0 || depth[len(depth)-1] != "VEVENT" {
+			continue
+		}
+		if err := applyProperty(current, prop, loc); err != nil {
+			return nil, fmt.Errorf("%d行目 (%s): %w", number+1, prop.name, err)
+		}
+	}
+
+	if len(depth) > 0 {
+		return nil, fmt.Errorf("END:%s がありません", depth[len(depth)-1])
+	}
+	return calendar, nil
+}
+
+// unfold は折り返された行 (CRLF の後に空白またはタブ) を1行に戻す
+func unfold(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseProperty はコンテンツ行をパースする
+// パラメータの値はダブルクォートで囲まれている場合があり、その中の ; や : は区切りとしない
+func parseProperty(line string) (property, error) {
+	prop := property{params: map[string]string{}}
+
+	inQuote := false
+	nameEnd, valueStart := -1, -1
+	var paramStarts []int
+	for i, r := range line {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case r == ';':
+			if nameEnd < 0 {
+				nameEnd = i
+			}
+			paramStarts = append(paramStarts, i+1)
+		case r == ':':
+			if nameEnd < 0 {
+				nameEnd = i
+			}
+			valueStart = i + 1
+		}
+		if valueStart >= 0 {
+			break
+		}
+	}
+	if valueStart < 0 {
+		return prop, fmt.Errorf("コンテンツ行の形式が不正です: %q", line)
+	}
+
+	prop.name = strings.ToUpper(line[:nameEnd])
+	prop.value = line[valueStart:]
+	for i, start := range paramStarts {
+		end := valueStart - 1
+		if i+1 < len(paramStarts) {
+			end = paramStarts[i+1] - 1
+		}
+		key, value, _ := strings.Cut(line[start:end], "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+// applyProperty は VEVENT のプロパティを予定に反映する
+func applyProperty(event *Event, prop property, loc *time.Location) error {
+	switch prop.name {
+	case "UID":
+		event.UID = prop.value
+	case "SUMMARY":
+		event.Summary = unescapeText(prop.value)
+	case "LOCATION":
+		event.Location = unescapeText(prop.value)
+	case "STATUS":
+		event.Cancelled = strings.EqualFold(prop.value, "CANCELLED")
+	case "DTSTART":
+		t, allDay, err := parseDateTime(prop.value, prop.params, loc)
+		if err != nil {
+			return err
+		}
+		event.Start, event.AllDay = t, allDay
+	case "DTEND":
+		t, _, err := parseDateTime(prop.value, prop.params, loc)
+		if err != nil {
+			return err
+		}
+		event.End = t
+	case "DURATION":
+		d, err := parseDuration(prop.value)
+		if err != nil {
+			return err
+		}
+		// DTSTART より前に書かれている場合に備えて、終了日時は finishEvent で計算する
+		event.duration = d
+	case "RRULE":
+		rule, err := parseRule(prop.value, loc)
+		if err != nil {
+			return err
+		}
+		event.Rule = rule
+	case "EXDATE":
+		for _, value := range strings.Split(prop.value, ",") {
+			t, _, err := parseDateTime(value, prop.params, loc)
+			if err != nil {
+				return err
+			}
+			event.ExDates = append(event.ExDates, t)
+		}
+	case "RECURRENCE-ID":
+		t, _, err := parseDateTime(prop.value, prop.params, loc)
+		if err != nil {
+			return err
+		}
+		event.RecurrenceID = t
+	}
+	return nil
+}
+
+// finishEvent は予定の必須項目をチェックし、終了日時を補う
+func finishEvent(event *Event) error {
+	if event.Start.IsZero() {
+		return fmt.Errorf("DTSTART のない予定があります: %s", event.Summary)
+	}
+	switch {
+	case event.End.IsZero() && event.duration != 0:
+		event.End = event.Start.Add(event.duration)
+	case event.End.IsZero() && event.AllDay:
+		event.End = event.Start.AddDate(0, 0, 1)
+	case event.End.IsZero():
+		event.End = event.Start
+	}
+	if event.End.Before(event.Start) {
+		return fmt.Errorf("終了日時が開始日時より前です: %s", event.Summary)
+	}
+	return nil
+}
+
+// Occurrences は [from, to) の期間に重なる予定を開始日時の順に返す
+func (c *Calendar) Occurrences(from, to time.Time) []Occurrence {
+	// 繰り返しの個別変更は元の回の代わりに表示する
+	overridden := map[string][]time.Time{}
+	for _, event := range c.Events {
+		if !event.RecurrenceID.IsZero() {
+			overridden[event.UID] = append(overridden[event.UID], event.RecurrenceID)
+		}
+	}
+
+	var occurrences []Occurrence
+	for _, event := range c.Events {
+		if event.Cancelled {
+			continue
+		}
+		duration := event.End.Sub(event.Start)
+		for _, start := range event.starts(from, to) {
+			if event.RecurrenceID.IsZero() && containsTime(overridden[event.UID], start, event.AllDay) {
+				continue
+			}
+			occurrences = append(occurrences, Occurrence{
+				UID:      event.UID,
+				Summary:  event.Summary,
+				Location: event.Location,
+				Start:    start,
+				End:      endOf(start, duration, event.AllDay),
+				AllDay:   event.AllDay,
+			})
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if !occurrences[i].Start.Equal(occurrences[j].Start) {
+			return occurrences[i].Start.Before(occurrences[j].Start)
+		}
+		// 同じ開始時刻なら終日の予定を先に並べる
+		return occurrences[i].AllDay && !occurrences[j].AllDay
+	})
+	return occurrences
+}
+
+// starts は [from, to) の期間に重なる回の開始日時を返す
+func (e Event) starts(from, to time.Time) []time.Time {
+	duration := e.End.Sub(e.Start)
+	overlaps := func(start time.Time) bool {
+		end := endOf(start, duration, e.AllDay)
+		if end.Equal(start) {
+			// 終了日時のない予定は開始日時が期間内かどうかで判定する
+			return !start.Before(from) && start.Before(to)
+		}
+		return start.Before(to) && end.After(from)
+	}
+
+	if e.Rule == nil || !e.RecurrenceID.IsZero() {
+		if overlaps(e.Start) {
+			return []time.Time{e.Start}
+		}
+		return nil
+	}
+
+	var starts []time.Time
+	e.Rule.expand(e.Start, to, func(start time.Time) {
+		if containsTime(e.ExDates, start, e.AllDay) {
+			return
+		}
+		if overlaps(start) {
+			starts = append(starts, start)
+		}
+	})
+	return starts
+}
+
+// endOf は開始日時と長さから終了日時を返す
+// 終日の予定は夏時間の切り替えで時刻がずれないよう日数で計算する
+func endOf(start time.Time, duration time.Duration, allDay bool) time.Time {
+	if allDay {
+		days := int((duration + 12*time.Hour) / (24 * time.Hour))
+		return start.AddDate(0, 0, days)
+	}
+	return start.Add(duration)
+}
+
+// containsTime は times に t が含まれるかどうかを返す (終日の予定は日付で比較する)
+func containsTime(times []time.Time, t time.Time, allDay bool) bool {
+	for _, candidate := range times {
+		if allDay {
+			c := candidate.In(t.Location())
+			if c.Year() == t.Year() && c.YearDay() == t.YearDay() {
+				return true
+			}
+			continue
+		}
+		if candidate.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// unescapeText は TEXT 型の値のエスケープ (\\ \; \, \n) を戻す
+func unescapeText(value string) string {
+	var builder strings.Builder
+	escaped := false
+	for _, r := range value {
+		if escaped {
+			switch r {
+			case 'n', 'N':
+				builder.WriteRune('\n')
+			default:
+				builder.WriteRune(r)
+			}
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
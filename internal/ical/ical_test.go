@@ -0,0 +1,256 @@
+package ical
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+func loadSample(t *testing.T) *Calendar {
+	t.Helper()
+	data, err := os.ReadFile("testdata/sample.ics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	calendar, err := Parse(data, jst)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	return calendar
+}
+
+// Parse のテスト
+func TestParse(t *testing.T) {
+	calendar := loadSample(t)
+
+	// VTODO は読み込まない
+	if len(calendar.Events) != 5 {
+		t.Fatalf("予定の件数: 期待=5, 実際=%d", len(calendar.Events))
+	}
+
+	standup := calendar.Events[0]
+	if standup.Location != "会議室A, 3階" {
+		t.Errorf("場所: 期待=%q, 実際=%q", "会議室A, 3階", standup.Location)
+	}
+	if standup.Rule == nil || standup.Rule.Freq != FreqWeekly || len(standup.Rule.ByDay) != 3 {
+		t.Errorf("繰り返しの規則が不正です: %+v", standup.Rule)
+	}
+	if len(standup.ExDates) != 1 {
+		t.Errorf("除外日の件数: 期待=1, 実際=%d", len(standup.ExDates))
+	}
+
+	trip := calendar.Events[2]
+	if !trip.AllDay || trip.Summary != "出張" {
+		t.Errorf("終日の予定が不正です: %+v", trip)
+	}
+
+	call := calendar.Events[3]
+	if call.Summary != "海外との電話会議" {
+		t.Errorf("折り返し行: 期待=%q, 実際=%q", "海外との電話会議", call.Summary)
+	}
+	if got := call.End.Sub(call.Start); got != 30*time.Minute {
+		t.Errorf("DURATION: 期待=30m, 実際=%v", got)
+	}
+}
+
+// Parse のエラーのテスト
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+	}{
+		{"ENDがない", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20261018T090000\nEND:VEVENT"},
+		{"BEGINとENDが対応しない", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR"},
+		{"DTSTARTがない", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:予定\nEND:VEVENT\nEND:VCALENDAR"},
+		{"日時が不正", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2026-10-18\nEND:VEVENT\nEND:VCALENDAR"},
+		{"コロンがない行", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY\nEND:VEVENT\nEND:VCALENDAR"},
+		{"未対応の頻度", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20261018T090000\nRRULE:FREQ=HOURLY\nEND:VEVENT\nEND:VCALENDAR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.ics), jst); err == nil {
+				t.Error("期待: エラー, 実際: nil")
+			}
+		})
+	}
+}
+
+// Occurrences のテスト
+func TestOccurrences(t *testing.T) {
+	calendar := loadSample(t)
+
+	from := time.Date(2026, 10, 18, 0, 0, 0, 0, jst)
+	occurrences := calendar.Occurrences(from, from.AddDate(0, 0, 6))
+
+	expected := []string{
+		"10/18 00:00 出張",
+		"10/19 08:00 海外との電話会議",
+		// 10/19 の朝会は EXDATE で除外
+		"10/21 11:00 朝会 (時間変更)",
+		"10/23 09:30 朝会",
+	}
+	var actual []string
+	for _, o := range occurrences {
+		actual = append(actual, o.Start.In(jst).Format("01/02 15:04 ")+o.Summary)
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("期待:\n%s\n実際:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	// 前日から続く終日の予定も期間に含める
+	next := calendar.Occurrences(from.AddDate(0, 0, 1), from.AddDate(0, 0, 2))
+	if len(next) == 0 || next[0].Summary != "出張" || !next[0].AllDay {
+		t.Errorf("複数日にまたがる終日の予定が含まれていません: %+v", next)
+	}
+}
+
+// 繰り返しの展開のテスト
+func TestRuleExpand(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		dtstart  time.Time
+		expected []string
+	}{
+		{
+			name:     "毎日 (回数指定)",
+			rule:     "FREQ=DAILY;COUNT=3",
+			dtstart:  time.Date(2026, 10, 18, 9, 0, 0, 0, jst),
+			expected: []string{"2026-10-18 09:00", "2026-10-19 09:00", "2026-10-20 09:00"},
+		},
+		{
+			name:     "隔週 (期限指定)",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;UNTIL=20261115T000000Z",
+			dtstart:  time.Date(2026, 10, 18, 9, 0, 0, 0, jst),
+			expected: []string{"2026-10-18 09:00", "2026-11-01 09:00", "2026-11-15 09:00"},
+		},
+		{
+			name:     "毎月の月末",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			dtstart:  time.Date(2026, 12, 31, 0, 0, 0, 0, jst),
+			expected: []string{"2026-12-31 00:00", "2027-01-31 00:00", "2027-02-28 00:00"},
+		},
+		{
+			name:     "存在しない日は飛ばす",
+			rule:     "FREQ=MONTHLY;COUNT=3",
+			dtstart:  time.Date(2027, 1, 30, 0, 0, 0, 0, jst),
+			expected: []string{"2027-01-30 00:00", "2027-03-30 00:00", "2027-04-30 00:00"},
+		},
+		{
+			name:     "第2月曜日",
+			rule:     "FREQ=MONTHLY;BYDAY=2MO;COUNT=3",
+			dtstart:  time.Date(2026, 10, 12, 19, 0, 0, 0, jst),
+			expected: []string{"2026-10-12 19:00", "2026-11-09 19:00", "2026-12-14 19:00"},
+		},
+		{
+			name:     "最終金曜日",
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR;COUNT=2",
+			dtstart:  time.Date(2026, 10, 30, 18, 0, 0, 0, jst),
+			expected: []string{"2026-10-30 18:00", "2026-11-27 18:00"},
+		},
+		{
+			name:     "毎年 (成人の日)",
+			rule:     "FREQ=YEARLY;BYMONTH=1;BYDAY=2MO;COUNT=2",
+			dtstart:  time.Date(2027, 1, 11, 0, 0, 0, 0, jst),
+			expected: []string{"2027-01-11 00:00", "2028-01-10 00:00"},
+		},
+		{
+			name:     "うるう日",
+			rule:     "FREQ=YEARLY;COUNT=2",
+			dtstart:  time.Date(2028, 2, 29, 0, 0, 0, 0, jst),
+			expected: []string{"2028-02-29 00:00", "2032-02-29 00:00"},
+		},
+		{
+			name:     "平日の毎日",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;COUNT=4",
+			dtstart:  time.Date(2026, 10, 22, 8, 0, 0, 0, jst),
+			expected: []string{"2026-10-22 08:00", "2026-10-23 08:00", "2026-10-26 08:00", "2026-10-27 08:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(tt.rule, jst)
+			if err != nil {
+				t.Fatalf("期待: エラーなし, 実際: %v", err)
+			}
+			var actual []string
+			rule.expand(tt.dtstart, time.Date(2040, 1, 1, 0, 0, 0, 0, jst), func(start time.Time) {
+				actual = append(actual, start.Format("2006-01-02 15:04"))
+			})
+			if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// 期限のない繰り返しが展開の終了日時で止まることのテスト
+func TestRuleExpandStopsAtEnd(t *testing.T) {
+	rule, err := parseRule("FREQ=DAILY", jst)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	dtstart := time.Date(2026, 10, 18, 9, 0, 0, 0, jst)
+	count := 0
+	rule.expand(dtstart, dtstart.AddDate(0, 0, 10), func(time.Time) { count++ })
+	if count != 10 {
+		t.Errorf("期待: %d, 実際: %d", 10, count)
+	}
+}
+
+// parseDuration のテスト
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"PT1H30M", 90 * time.Minute, false},
+		{"P1D", 24 * time.Hour, false},
+		{"P2W", 14 * 24 * time.Hour, false},
+		{"-PT15M", -15 * time.Minute, false},
+		{"P1DT12H", 36 * time.Hour, false},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"1H", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			actual, err := parseDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.wantErr, err)
+			}
+			if actual != tt.expected {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// TZID の解決のテスト
+func TestResolveLocation(t *testing.T) {
+	tests := []struct {
+		tzid     string
+		expected string
+	}{
+		{"Asia/Tokyo", "Asia/Tokyo"},
+		{"Tokyo Standard Time", "Asia/Tokyo"},
+		{`"/Asia/Tokyo"`, "Asia/Tokyo"},
+		{"Unknown/Zone", "JST"},
+		{"", "JST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tzid, func(t *testing.T) {
+			if actual := resolveLocation(tt.tzid, jst).String(); actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 繰り返しの頻度
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxPeriods は繰り返しを展開する期間の上限 (不正な規則で無限に展開しないため)
+const maxPeriods = 50000
+
+// weekdayCodes は BYDAY / WKST の曜日の表記
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// WeekdayNum は BYDAY の1要素 (例: 2MO = 第2月曜日, -1FR = 最終金曜日)
+type WeekdayNum struct {
+	Ordinal int // 0 の場合はすべての該当曜日
+	Weekday time.Weekday
+}
+
+// Rule は繰り返しの規則 (RRULE)
+// BYSETPOS、BYWEEKNO、BYYEARDAY、時刻単位の頻度には対応しない
+type Rule struct {
+	Freq       string
+	Interval   int
+	Count      int       // 0 の場合は回数の制限なし
+	Until      time.Time // ゼロ値の場合は期限なし
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+}
+
+// parseRule は RRULE の値をパースする
+func parseRule(value string, loc *time.Location) (*Rule, error) {
+	rule := &Rule{Interval: 1, WeekStart: time.Monday}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("繰り返しの規則の形式が不正です: %q", part)
+		}
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && rule.Interval < 1 {
+				err = fmt.Errorf("INTERVAL は1以上です")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+		case "UNTIL":
+			rule.Until, _, err = parseDateTime(val, map[string]string{}, loc)
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				var weekday WeekdayNum
+				weekday, err = parseWeekdayNum(day)
+				if err != nil {
+					break
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				var n int
+				n, err = strconv.Atoi(day)
+				if err != nil {
+					break
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(val, ",") {
+				var n int
+				n, err = strconv.Atoi(month)
+				if err != nil {
+					break
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(n))
+			}
+		case "WKST":
+			weekday, ok := weekdayCodes[strings.ToUpper(val)]
+			if !ok {
+				err = fmt.Errorf("曜日の形式が不正です: %q", val)
+			}
+			rule.WeekStart = weekday
+		}
+		if err != nil {
+			return nil, fmt.Errorf("繰り返しの規則 %s の値が不正です: %w", key, err)
+		}
+	}
+
+	switch rule.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	default:
+		return nil, fmt.Errorf("対応していない繰り返しの頻度です: %q", rule.Freq)
+	}
+	return rule, nil
+}
+
+// parseWeekdayNum は BYDAY の要素 (例: MO, 2MO, -1FR) をパースする
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) < 2 {
+		return WeekdayNum{}, fmt.Errorf("曜日の形式が不正です: %q", value)
+	}
+	weekday, ok := weekdayCodes[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("曜日の形式が不正です: %q", value)
+	}
+	result := WeekdayNum{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 {
+			return WeekdayNum{}, fmt.Errorf("曜日の形式が不正です: %q", value)
+		}
+		result.Ordinal = n
+	}
+	return result, nil
+}
+
+// expand は dtstart から繰り返す回の開始日時を順に yield に渡す
+// to 以降の回、UNTIL を過ぎた回、COUNT を超えた回は渡さない
+// EXDATE で除外する回も COUNT に数えるため、除外は呼び出し側で行う
+func (r *Rule) expand(dtstart time.Time, to time.Time, yield func(time.Time)) {
+	count := 0
+	for period := 0; period < maxPeriods; period++ {
+		periodStart, candidates := r.candidates(dtstart, period)
+		if !periodStart.Before(to) {
+			return
+		}
+		for _, candidate := range candidates {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			if !r.Until.IsZero() && candidate.After(r.Until) {
+				return
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return
+			}
+			if !candidate.Before(to) {
+				return
+			}
+			yield(candidate)
+		}
+	}
+}
+
+// candidates は period 番目の期間の開始日と、その期間に含まれる回の開始日時を返す
+func (r *Rule) candidates(dtstart time.Time, period int) (time.Time, []time.Time) {
+	loc := dtstart.Location()
+	year, month, day := dtstart.Date()
+	hour, minute, second := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, minute, second, 0, loc)
+	}
+	midnight := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+	step := period * r.Interval
+
+	var periodStart time.Time
+	var days []time.Time // 期間内の候補日 (0時)
+	switch r.Freq {
+	case FreqDaily:
+		periodStart = midnight(year, month, day+step)
+		days = []time.Time{periodStart}
+	case FreqWeekly:
+		offset := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+		periodStart = midnight(year, month, day-offset+step*7)
+		for i := 0; i < 7; i++ {
+			candidate := periodStart.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && candidate.Weekday() != dtstart.Weekday() {
+				continue
+			}
+			days = append(days, candidate)
+		}
+	case FreqMonthly:
+		periodStart = midnight(year, month+time.Month(step), 1)
+		days = r.monthDays(periodStart, day)
+	case FreqYearly:
+		periodStart = midnight(year+step, time.January, 1)
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{month}
+		}
+		for _, m := range months {
+			days = append(days, r.monthDays(midnight(year+step, m, 1), day)...)
+		}
+	}
+
+	var result []time.Time
+	for _, d := range days {
+		if !r.matches(d) {
+			continue
+		}
+		result = append(result, at(d.Year(), d.Month(), d.Day()))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return periodStart, result
+}
+
+// monthDays は月 (first はその月の1日) に含まれる候補日を返す
+// BYMONTHDAY、BYDAY の順に指定があればそれを使い、なければ dtstart と同じ日を使う
+func (r *Rule) monthDays(first time.Time, defaultDay int) []time.Time {
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+	var days []int
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, n := range r.ByMonthDay {
+			if n < 0 {
+				n = daysInMonth + n + 1
+			}
+			days = append(days, n)
+		}
+	case len(r.ByDay) > 0:
+		for _, weekday := range r.ByDay {
+			firstMatch := 1 + (int(weekday.Weekday)-int(first.Weekday())+7)%7
+			switch {
+			case weekday.Ordinal > 0:
+				days = append(days, firstMatch+(weekday.Ordinal-1)*7)
+			case weekday.Ordinal < 0:
+				lastMatch := firstMatch + (daysInMonth-firstMatch)/7*7
+				days = append(days, lastMatch+(weekday.Ordinal+1)*7)
+			default:
+				for d := firstMatch; d <= daysInMonth; d += 7 {
+					days = append(days, d)
+				}
+			}
+		}
+	default:
+		days = []int{defaultDay}
+	}
+
+	var result []time.Time
+	seen := map[int]bool{}
+	for _, d := range days {
+		// 存在しない日 (2月30日など) は飛ばす
+		if d < 1 || d > daysInMonth || seen[d] {
+			continue
+		}
+		seen[d] = true
+		result = append(result, first.AddDate(0, 0, d-1))
+	}
+	return result
+}
+
+// matches は候補日が BYMONTH / BYMONTHDAY / BYDAY の絞り込みに合うかどうかを返す
+// 候補日を作るときに使った条件は必ず合うため、残りの条件だけが絞り込みとして働く
+func (r *Rule) matches(day time.Time) bool {
+	if len(r.ByMonth) > 0 && !containsMonth(r.ByMonth, day.Month()) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 {
+		daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+		matched := false
+		for _, n := range r.ByMonthDay {
+			if n == day.Day() || daysInMonth+n+1 == day.Day() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(r.ByDay) > 0 {
+		// 序数付きの曜日は monthDays で日付に展開済みのため、ここでは曜日だけを見る
+		matched := false
+		for _, weekday := range r.ByDay {
+			if weekday.Weekday == day.Weekday() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+// Package icon は天気を表すモノクロのSVGアイコンを扱う。
+// 正規化した天気コード (internal/telop の Telop.Code。例: clear-cloudy) と昼夜からアイコンを選び、
+// HTMLに埋め込むSVGを返す。アイコンは svg/ のファイルをバイナリに埋め込んでいる。
+package icon
+
+import (
+	"embed"
+	"strings"
+)
+
+//go:embed svg/*.svg
+var files embed.FS
+
+// アイコン名 (svg/ のファイル名から拡張子を除いたもの)
+const (
+	Clear             = "clear"               // 晴れ
+	ClearNight        = "clear-night"         // 晴れ (夜)
+	PartlyCloudy      = "partly-cloudy"       // 晴れ時々曇り
+	PartlyCloudyNight = "partly-cloudy-night" // 晴れ時々曇り (夜)
+	MostlyCloudy      = "mostly-cloudy"       // 曇り時々晴れ
+	MostlyCloudyNight = "mostly-cloudy-night" // 曇り時々晴れ (夜)
+	Cloudy            = "cloudy"              // 曇り
+	Rain              = "rain"                // 雨
+	Showers           = "showers"             // 晴れ時々雨
+	ShowersNight      = "showers-night"       // 晴れ時々雨 (夜)
+	Snow              = "snow"                // 雪
+	SnowShowers       = "snow-showers"        // 晴れ時々雪
+	SnowShowersNight  = "snow-showers-night"  // 晴れ時々雪 (夜)
+	Sleet             = "sleet"               // 雨か雪・みぞれ
+	Thunder           = "thunder"             // 雷雨
+	Fog               = "fog"                 // 霧
+	Unknown           = "unknown"             // 天気が分からない
+)
+
+// nightNames は太陽を描いたアイコンの夜の版
+var nightNames = map[string]string{
+	Clear:        ClearNight,
+	PartlyCloudy: PartlyCloudyNight,
+	MostlyCloudy: MostlyCloudyNight,
+	Showers:      ShowersNight,
+	SnowShowers:  SnowShowersNight,
+}
+
+// Name は天気コードと昼夜からアイコン名を返す
+// 主な天気と副の天気の組み合わせで選び、night が true の場合は太陽を月に替えたアイコンにする
+// 読み取れない天気コードの場合は Unknown を返す
+func Name(code string, night bool) string {
+	name := dayName(code)
+	if night {
+		if nightName, ok := nightNames[name]; ok {
+			return nightName
+		}
+	}
+	return name
+}
+
+// dayName は天気コードから昼のアイコン名を返す
+func dayName(code string) string {
+	parts := strings.Split(code, "-")
+	primary := parts[0]
+	secondary := ""
+	thunder := false
+	for _, part := range parts[1:] {
+		if part == "thunder" {
+			thunder = true
+		}
+		if secondary == "" {
+			secondary = part
+		}
+	}
+
+	if primary == "thunder" || (thunder && isPrecipitation(primary)) {
+		return Thunder
+	}
+	switch primary {
+	case "clear":
+		switch {
+		case secondary == "cloudy":
+			return PartlyCloudy
+		case secondary == "snow" || secondary == "sleet":
+			return SnowShowers
+		case isPrecipitation(secondary):
+			return Showers
+		}
+		return Clear
+	case "cloudy":
+		switch {
+		case secondary == "clear":
+			return MostlyCloudy
+		case secondary == "snow" || secondary == "sleet":
+			return Snow
+		case isPrecipitation(secondary):
+			return Rain
+		}
+		return Cloudy
+	case "fog":
+		return Fog
+	case "rain":
+		return Rain
+	case "sleet":
+		return Sleet
+	case "snow":
+		return Snow
+	default:
+		return Unknown
+	}
+}
+
+// isPrecipitation は天気コードの天気の名前が降水 (雨・雪など) かどうかを返す
+func isPrecipitation(key string) bool {
+	switch key {
+	case "rain", "sleet", "snow", "thunder":
+		return true
+	default:
+		return false
+	}
+}
+
+// SVG はHTMLに埋め込むアイコンのSVGを返す
+// 大きさは周りの文字の大きさ (1em) に合わせ、線の色は文字の色 (currentColor) にする
+// アイコンがない場合は false を返す
+func SVG(name string) (string, bool) {
+	if name == "" || strings.ContainsAny(name, "/.") {
+		return "", false
+	}
+	content, err := files.ReadFile("svg/" + name + ".svg")
+	if err != nil {
+		return "", false
+	}
+	svg := strings.TrimSpace(string(content))
+	svg = strings.Replace(svg, "<svg ", `<svg class="icon icon-`+name+`" width="1em" height="1em" aria-hidden="true" `, 1)
+	return svg, true
+}
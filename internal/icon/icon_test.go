@@ -0,0 +1,118 @@
+package icon
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+// allNames はすべてのアイコン名
+var allNames = []string{
+	Clear, ClearNight, PartlyCloudy, PartlyCloudyNight, MostlyCloudy, MostlyCloudyNight,
+	Cloudy, Rain, Showers, ShowersNight, Snow, SnowShowers, SnowShowersNight,
+	Sleet, Thunder, Fog, Unknown,
+}
+
+// Name のテスト
+func TestName(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		night    bool
+		expected string
+	}{
+		{"晴れ", "clear", false, Clear},
+		{"晴れ (夜)", "clear", true, ClearNight},
+		{"晴れ時々曇り", "clear-cloudy", false, PartlyCloudy},
+		{"晴れ時々曇り (夜)", "clear-cloudy", true, PartlyCloudyNight},
+		{"曇り時々晴れ", "cloudy-clear", false, MostlyCloudy},
+		{"曇り時々晴れ (夜)", "cloudy-clear", true, MostlyCloudyNight},
+		{"曇り", "cloudy", false, Cloudy},
+		// 太陽を描いていないアイコンは夜も同じ
+		{"曇り (夜)", "cloudy", true, Cloudy},
+		{"曇りのち雨", "cloudy-rain", false, Rain},
+		{"曇り時々雪", "cloudy-snow", false, Snow},
+		{"曇り一時雨か雪", "cloudy-sleet", false, Snow},
+		{"晴れ時々雨", "clear-rain", false, Showers},
+		{"晴れ時々雨 (夜)", "clear-rain", true, ShowersNight},
+		{"晴れ山沿い雷雨", "clear-thunder", false, Showers},
+		{"晴れ時々雪", "clear-snow", false, SnowShowers},
+		{"晴れ一時雨か雪 (夜)", "clear-sleet", true, SnowShowersNight},
+		{"雨", "rain", false, Rain},
+		{"雪", "snow", false, Snow},
+		{"雨か雪", "sleet", false, Sleet},
+		{"雷雨", "thunder", false, Thunder},
+		{"雨で雷を伴う", "rain-thunder", false, Thunder},
+		{"雪で雷を伴う", "snow-cloudy-thunder", false, Thunder},
+		// 主な天気が降水でなければ雷を伴っても雨のアイコン
+		{"曇り時々雨で雷を伴う", "cloudy-rain-thunder", false, Rain},
+		{"霧", "fog", false, Fog},
+		{"不明", "unknown", false, Unknown},
+		{"不明 (夜)", "unknown", true, Unknown},
+		{"空", "", false, Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := Name(tt.code, tt.night); actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// SVG のテスト (すべてのアイコンが埋め込まれ、SVGとして読めること)
+func TestSVG(t *testing.T) {
+	for _, name := range allNames {
+		t.Run(name, func(t *testing.T) {
+			svg, ok := SVG(name)
+			if !ok {
+				t.Fatalf("アイコン %s が見つかりません", name)
+			}
+			if !strings.HasPrefix(svg, `<svg class="icon icon-`+name+`" width="1em" height="1em"`) {
+				t.Errorf("大きさの指定がありません: %s", svg)
+			}
+			if !strings.Contains(svg, `stroke="currentColor"`) {
+				t.Errorf("線の色が文字の色になっていません: %s", svg)
+			}
+			var parsed struct {
+				XMLName xml.Name
+			}
+			if err := xml.Unmarshal([]byte(svg), &parsed); err != nil || parsed.XMLName.Local != "svg" {
+				t.Errorf("SVGとして読めません: %v", err)
+			}
+		})
+	}
+}
+
+// SVG のテスト (アイコンがない場合)
+func TestSVGNotFound(t *testing.T) {
+	for _, name := range []string{"", "sunny", "../icon", "clear.svg"} {
+		t.Run(name, func(t *testing.T) {
+			if svg, ok := SVG(name); ok {
+				t.Errorf("アイコンがない場合は false を返すはずです: %s", svg)
+			}
+		})
+	}
+}
+
+// svg/ のすべてのファイルがアイコン名の定数になっていること
+func TestAllFilesHaveNames(t *testing.T) {
+	entries, err := files.ReadDir("svg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := make(map[string]bool, len(allNames))
+	for _, name := range allNames {
+		known[name] = true
+	}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".svg")
+		if !known[name] {
+			t.Errorf("アイコン名の定数がないファイルがあります: %s", entry.Name())
+		}
+	}
+	if len(entries) != len(allNames) {
+		t.Errorf("期待: %d, 実際: %d", len(allNames), len(entries))
+	}
+}
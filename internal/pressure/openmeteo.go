@@ -0,0 +1,69 @@
+package pressure
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OpenMeteoProvider は Open-Meteo の天気予報APIから1時間ごとの海面気圧と湿度を取得する
+type OpenMeteoProvider struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Name は取得元の名前を返す
+func (p OpenMeteoProvider) Name() string {
+	return ProviderOpenMeteo
+}
+
+// URL は今日から2日分 (48時間) の予報を取得するURLを返す
+// 時刻は指定した緯度・経度のタイムゾーンで返る (timezone=auto)
+func (p OpenMeteoProvider) URL(now time.Time) string {
+	query := url.Values{}
+	query.Set("latitude", strconv.FormatFloat(p.Latitude, 'f', 3, 64))
+	query.Set("longitude", strconv.FormatFloat(p.Longitude, 'f', 3, 64))
+	query.Set("hourly", "pressure_msl,relative_humidity_2m")
+	query.Set("timezone", "auto")
+	query.Set("forecast_days", "2")
+	return "https://api.open-meteo.com/v1/forecast?" + query.Encode()
+}
+
+// Parse は1時間ごとの海面気圧と湿度をパースする
+// 時刻はタイムゾーンのない表記のため、utc_offset_seconds の時差で解釈する
+func (p OpenMeteoProvider) Parse(body []byte) ([]Point, error) {
+	var response struct {
+		UTCOffsetSeconds int `json:"utc_offset_seconds"`
+		Hourly           struct {
+			Time             []string   `json:"time"`
+			PressureMSL      []*float64 `json:"pressure_msl"`
+			RelativeHumidity []*float64 `json:"relative_humidity_2m"`
+		} `json:"hourly"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("気圧の予報のパースに失敗しました: %w", err)
+	}
+	if len(response.Hourly.Time) == 0 {
+		return nil, fmt.Errorf("気圧の予報が含まれていません")
+	}
+
+	loc := time.FixedZone("", response.UTCOffsetSeconds)
+	points := make([]Point, 0, len(response.Hourly.Time))
+	for i, value := range response.Hourly.Time {
+		t, err := time.ParseInLocation("2006-01-02T15:04", value, loc)
+		if err != nil {
+			return nil, fmt.Errorf("気圧の予報の時刻が不正です: %q", value)
+		}
+		point := Point{Time: t}
+		if i < len(response.Hourly.PressureMSL) && response.Hourly.PressureMSL[i] != nil {
+			point.Pressure, point.HasPressure = *response.Hourly.PressureMSL[i], true
+		}
+		if i < len(response.Hourly.RelativeHumidity) && response.Hourly.RelativeHumidity[i] != nil {
+			point.Humidity, point.HasHumidity = *response.Hourly.RelativeHumidity[i], true
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
@@ -0,0 +1,118 @@
+// Package pressure は1時間ごとの海面気圧と湿度の予報を扱う。
+// 予報から今後の気圧の変化を求め、気圧による頭痛などの目安となる急な低下を検出する。
+// 急な低下は、指定した時間の幅 (例: 6時間) での低下幅がしきい値以上かどうかで判定する。
+package pressure
+
+import (
+	"fmt"
+	"time"
+)
+
+// Point は1時間ごとの予報
+type Point struct {
+	Time        time.Time
+	Pressure    float64 // 海面気圧 (hPa)
+	HasPressure bool
+	Humidity    float64 // 相対湿度 (%)
+	HasHumidity bool
+}
+
+// Provider は気圧・湿度の予報の取得元
+type Provider interface {
+	Name() string             // 取得元の名前 (例: open-meteo)
+	URL(now time.Time) string // now から24時間以上先までの予報を取得するURL
+	Parse(body []byte) ([]Point, error)
+}
+
+// ProviderOpenMeteo は Open-Meteo の取得元の名前
+const ProviderOpenMeteo = "open-meteo"
+
+// NewProvider は気圧・湿度の予報の取得元を生成する (name が空の場合は open-meteo)
+func NewProvider(name string, latitude, longitude float64) (Provider, error) {
+	switch name {
+	case "", ProviderOpenMeteo:
+		return OpenMeteoProvider{Latitude: latitude, Longitude: longitude}, nil
+	default:
+		return nil, fmt.Errorf("気圧の取得元が不正です: %q (%s のみ指定できます)", name, ProviderOpenMeteo)
+	}
+}
+
+// Rule は急な気圧の低下の判定条件
+type Rule struct {
+	DropHPa float64       // この値以上の低下を急な低下とする (hPa)
+	Window  time.Duration // 低下を求める時間の幅 (例: 6時間)
+	Horizon time.Duration // 現在から何時間先までの予報を調べるか (例: 24時間)
+}
+
+// Trend は今後の気圧の変化
+type Trend struct {
+	Points      []Point   // 現在から Horizon までの予報 (気圧のない時刻を含む)
+	Current     float64   // 現在 (直近の時刻) の気圧
+	Change      float64   // Horizon 後までの気圧の変化 (hPa、下降は負)
+	MaxDrop     float64   // Window 内の最大の低下幅 (hPa、低下がない場合は 0)
+	DropFrom    time.Time // 最大の低下が始まる時刻
+	DropTo      time.Time // 最大の低下が終わる時刻
+	IsRapid     bool      // 最大の低下幅が Rule.DropHPa 以上かどうか
+	Humidity    float64   // 現在 (直近の時刻) の湿度
+	HasHumidity bool
+}
+
+// Analyze は now 以降 Horizon までの予報から気圧の変化を求める
+// now を含む時刻 (now 以前で最も新しい時刻) を現在とし、現在の気圧がない場合は false を返す
+func Analyze(points []Point, now time.Time, rule Rule) (Trend, bool) {
+	start := -1
+	for i, point := range points {
+		if !point.Time.After(now) {
+			start = i
+		}
+	}
+	if start < 0 {
+		// 予報が now より後から始まる場合は最初の時刻を現在とする
+		start = 0
+	}
+	if start >= len(points) || !points[start].HasPressure {
+		return Trend{}, false
+	}
+
+	end := points[start].Time.Add(rule.Horizon)
+	var upcoming []Point
+	for _, point := range points[start:] {
+		if point.Time.After(end) {
+			break
+		}
+		upcoming = append(upcoming, point)
+	}
+
+	current := upcoming[0]
+	trend := Trend{
+		Points:      upcoming,
+		Current:     current.Pressure,
+		Humidity:    current.Humidity,
+		HasHumidity: current.HasHumidity,
+	}
+	for i := len(upcoming) - 1; i > 0; i-- {
+		if upcoming[i].HasPressure {
+			trend.Change = upcoming[i].Pressure - current.Pressure
+			break
+		}
+	}
+
+	for i, from := range upcoming {
+		if !from.HasPressure {
+			continue
+		}
+		for _, to := range upcoming[i+1:] {
+			if to.Time.Sub(from.Time) > rule.Window {
+				break
+			}
+			if !to.HasPressure {
+				continue
+			}
+			if drop := from.Pressure - to.Pressure; drop > trend.MaxDrop {
+				trend.MaxDrop, trend.DropFrom, trend.DropTo = drop, from.Time, to.Time
+			}
+		}
+	}
+	trend.IsRapid = rule.DropHPa > 0 && trend.MaxDrop >= rule.DropHPa
+	return trend, true
+}
@@ -0,0 +1,105 @@
+package pressure
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+func readPoints(t *testing.T) []Point {
+	t.Helper()
+	body, err := os.ReadFile("testdata/open_meteo_hourly.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	points, err := OpenMeteoProvider{}.Parse(body)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	return points
+}
+
+// OpenMeteoProvider のテスト
+func TestOpenMeteoProviderParse(t *testing.T) {
+	points := readPoints(t)
+	if len(points) != 48 {
+		t.Fatalf("時刻の数: 期待=48, 実際=%d", len(points))
+	}
+	// 時刻は utc_offset_seconds の時差で解釈する
+	if !points[0].Time.Equal(time.Date(2026, 10, 18, 0, 0, 0, 0, jst)) {
+		t.Errorf("最初の時刻: 期待=2026-10-18 00:00 JST, 実際=%s", points[0].Time)
+	}
+	if !points[0].HasPressure || points[0].Pressure != 1016.0 || !points[0].HasHumidity || points[0].Humidity != 55 {
+		t.Errorf("最初の予報が不正です: %+v", points[0])
+	}
+	// 値のない時刻
+	if points[30].HasPressure || points[12].HasHumidity {
+		t.Errorf("値のない時刻が不正です: %+v, %+v", points[30], points[12])
+	}
+
+	url := OpenMeteoProvider{Latitude: 35.6895, Longitude: 139.6917}.URL(time.Now())
+	for _, param := range []string{"latitude=35.690", "hourly=pressure_msl%2Crelative_humidity_2m", "timezone=auto"} {
+		if !strings.Contains(url, param) {
+			t.Errorf("URLに %s が含まれていません: %s", param, url)
+		}
+	}
+
+	if _, err := (OpenMeteoProvider{}).Parse([]byte(`{"hourly": {"time": []}}`)); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// Analyze のテスト
+func TestAnalyze(t *testing.T) {
+	points := readPoints(t)
+	rule := Rule{DropHPa: 6, Window: 6 * time.Hour, Horizon: 24 * time.Hour}
+
+	trend, ok := Analyze(points, time.Date(2026, 10, 18, 9, 30, 0, 0, jst), rule)
+	if !ok {
+		t.Fatal("期待: 気圧の変化あり, 実際: なし")
+	}
+	if trend.Current != 1015.1 || !trend.HasHumidity || trend.Humidity != 64 {
+		t.Errorf("現在の気圧・湿度が不正です: %v hPa, %v %%", trend.Current, trend.Humidity)
+	}
+	// 09時から翌09時までの25時刻
+	if len(trend.Points) != 25 {
+		t.Errorf("時刻の数: 期待=25, 実際=%d", len(trend.Points))
+	}
+	if math.Abs(trend.Change-(-8.0)) > 0.01 {
+		t.Errorf("24時間の変化: 期待=-8.0, 実際=%.2f", trend.Change)
+	}
+	if math.Abs(trend.MaxDrop-8.4) > 0.01 || trend.DropFrom.Hour() != 14 || trend.DropTo.Hour() != 20 || !trend.IsRapid {
+		t.Errorf("最大の低下: 期待=14時〜20時に8.4hPa (急な低下), 実際=%s〜%sに%.2fhPa (%v)",
+			trend.DropFrom.Format("15:04"), trend.DropTo.Format("15:04"), trend.MaxDrop, trend.IsRapid)
+	}
+
+	// 判定条件より小さい低下は急な低下としない
+	if trend, _ := Analyze(points, time.Date(2026, 10, 18, 9, 30, 0, 0, jst), Rule{DropHPa: 10, Window: 6 * time.Hour, Horizon: 24 * time.Hour}); trend.IsRapid {
+		t.Error("期待: 急な低下なし, 実際: あり")
+	}
+
+	// 上昇中は低下幅が 0
+	trend, ok = Analyze(points, time.Date(2026, 10, 19, 8, 0, 0, 0, jst), rule)
+	if !ok || trend.MaxDrop != 0 || trend.IsRapid || trend.Change <= 0 {
+		t.Errorf("上昇中の変化が不正です: %+v (%v)", trend, ok)
+	}
+
+	// 現在の気圧がない場合
+	if _, ok := Analyze(points, time.Date(2026, 10, 19, 6, 30, 0, 0, jst), rule); ok {
+		t.Error("期待: 気圧の変化なし, 実際: あり")
+	}
+}
+
+// NewProvider のテスト
+func TestNewProvider(t *testing.T) {
+	if provider, err := NewProvider("", 35.6, 139.7); err != nil || provider.Name() != ProviderOpenMeteo {
+		t.Errorf("期待: %s, 実際: %v (%v)", ProviderOpenMeteo, provider, err)
+	}
+	if _, err := NewProvider("unknown", 0, 0); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
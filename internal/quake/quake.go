@@ -0,0 +1,259 @@
+// Package quake は気象庁防災情報 (bosai) の地震情報をパースし、
+// 震度・発生時刻・震源からの距離で絞り込んだ最近の地震の一覧を生成する。
+// 震度は気象庁の表記 (5-・6+) と表示名 (5弱・6強) のどちらも、大小を比べられる値 (Intensity) にして扱う。
+package quake
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/city"
+)
+
+// ListURL は地震情報の一覧 (JSON) のURL
+const ListURL = "https://www.jma.go.jp/bosai/quake/data/list.json"
+
+// detailBaseURL は地震情報の詳細 (JSON) のURLの前半
+const detailBaseURL = "https://www.jma.go.jp/bosai/quake/data/"
+
+// DetailURL は一覧の json フィールドのファイル名から詳細のURLを返す
+func DetailURL(file string) string {
+	return detailBaseURL + file
+}
+
+// Intensity は震度階級 (大きいほど強い。0 は不明)
+type Intensity int
+
+const (
+	IntensityUnknown Intensity = iota
+	Intensity1
+	Intensity2
+	Intensity3
+	Intensity4
+	Intensity5Lower
+	Intensity5Upper
+	Intensity6Lower
+	Intensity6Upper
+	Intensity7
+)
+
+// intensityCodes は気象庁の震度の表記 (Intensity の順)
+var intensityCodes = []string{"", "1", "2", "3", "4", "5-", "5+", "6-", "6+", "7"}
+
+// intensityLabels は震度の表示名 (Intensity の順)
+var intensityLabels = []string{"不明", "1", "2", "3", "4", "5弱", "5強", "6弱", "6強", "7"}
+
+// ParseIntensity は震度の表記 (例: 3、5-、5弱) をパースする
+func ParseIntensity(value string) (Intensity, error) {
+	value = strings.TrimSpace(value)
+	for i := 1; i < len(intensityCodes); i++ {
+		if value == intensityCodes[i] || value == intensityLabels[i] {
+			return Intensity(i), nil
+		}
+	}
+	return IntensityUnknown, fmt.Errorf("震度の形式が不正です: %q (1〜7、5弱、5強、6弱、6強で指定してください)", value)
+}
+
+// Label は震度の表示名を返す
+func (i Intensity) Label() string {
+	if i < 0 || int(i) >= len(intensityLabels) {
+		return intensityLabels[IntensityUnknown]
+	}
+	return intensityLabels[i]
+}
+
+// Earthquake は1つの地震の情報 (同じ地震の複数の発表をまとめたもの)
+type Earthquake struct {
+	EventID      string    // 地震の識別子
+	Time         time.Time // 発生時刻
+	Epicenter    string    // 震央地名 (例: 千葉県北西部)
+	HasEpicenter bool      // 震源の位置が分かっているかどうか
+	Latitude     float64   // 震源の緯度
+	Longitude    float64   // 震源の経度
+	HasDepth     bool      // 深さが分かっているかどうか
+	DepthKm      int       // 深さ (km、0 はごく浅い)
+	HasMagnitude bool      // マグニチュードが分かっているかどうか
+	Magnitude    float64   // マグニチュード
+	MaxIntensity Intensity // 最大震度
+	DetailFile   string    // 詳細のファイル名 (津波の情報の取得に使う)
+	IsDistant    bool      // 遠地地震 (国外で発生した規模の大きな地震) かどうか
+	Tsunami      Tsunami   // 津波の状況 (詳細から呼び出し側で設定する。未取得は TsunamiUnknown)
+
+	detailHasComment bool // DetailFile が震源・震度情報 (津波に関する付加文を含む) かどうか
+}
+
+// listEntry は地震情報の一覧の1件 (1回の発表)
+type listEntry struct {
+	EventID      string `json:"eid"`  // 地震の識別子
+	ReportTime   string `json:"rdt"`  // 発表時刻
+	Title        string `json:"ttl"`  // 情報の種類 (例: 震源・震度情報)
+	Time         string `json:"at"`   // 発生時刻
+	Epicenter    string `json:"anm"`  // 震央地名
+	Coordinate   string `json:"cod"`  // 震源の位置 (ISO 6709 形式、例: +35.7+140.1-70000/)
+	Magnitude    string `json:"mag"`  // マグニチュード
+	MaxIntensity string `json:"maxi"` // 最大震度
+	DetailFile   string `json:"json"` // 詳細のファイル名
+}
+
+// coordinatePattern は震源の位置 (緯度・経度・深さ(m)) の形式
+var coordinatePattern = regexp.MustCompile(`^([+-]\d+(?:\.\d+)?)([+-]\d+(?:\.\d+)?)([+-]\d+)?/?$`)
+
+// ParseList は地震情報の一覧をパースし、地震ごとにまとめて発生時刻の新しい順に返す
+// 同じ地震の発表 (震度速報 → 震源に関する情報 → 震源・震度情報) は新しい発表の内容を優先する
+func ParseList(body []byte) ([]Earthquake, error) {
+	var entries []listEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("地震情報のパースに失敗しました: %w", err)
+	}
+	// 発表時刻の新しい順に並べる (一覧は新しい順だが、念のため並べ直す)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ReportTime > entries[j].ReportTime
+	})
+
+	byEvent := map[string]*Earthquake{}
+	var order []string
+	for _, entry := range entries {
+		if entry.EventID == "" {
+			continue
+		}
+		quake, ok := byEvent[entry.EventID]
+		if !ok {
+			quake = &Earthquake{EventID: entry.EventID}
+			byEvent[entry.EventID] = quake
+			order = append(order, entry.EventID)
+		}
+		mergeEntry(quake, entry)
+	}
+
+	var quakes []Earthquake
+	for _, eventID := range order {
+		if quake := byEvent[eventID]; !quake.Time.IsZero() {
+			quakes = append(quakes, *quake)
+		}
+	}
+	sort.SliceStable(quakes, func(i, j int) bool {
+		return quakes[i].Time.After(quakes[j].Time)
+	})
+	return quakes, nil
+}
+
+// mergeEntry はまだ分かっていない項目を発表の内容で補う (新しい発表から順に呼ぶ)
+func mergeEntry(quake *Earthquake, entry listEntry) {
+	if quake.Time.IsZero() {
+		if t, err := time.Parse(time.RFC3339, entry.Time); err == nil {
+			quake.Time = t
+		}
+	}
+	if !quake.HasEpicenter && entry.Epicenter != "" {
+		if match := coordinatePattern.FindStringSubmatch(entry.Coordinate); match != nil {
+			quake.Epicenter = entry.Epicenter
+			quake.HasEpicenter = true
+			quake.Latitude, _ = strconv.ParseFloat(match[1], 64)
+			quake.Longitude, _ = strconv.ParseFloat(match[2], 64)
+			if match[3] != "" {
+				depth, _ := strconv.Atoi(match[3])
+				quake.HasDepth = true
+				quake.DepthKm = -depth / 1000
+			}
+		} else if quake.Epicenter == "" {
+			quake.Epicenter = entry.Epicenter
+		}
+	}
+	if !quake.HasMagnitude {
+		if magnitude, err := strconv.ParseFloat(entry.Magnitude, 64); err == nil {
+			quake.Magnitude = magnitude
+			quake.HasMagnitude = true
+		}
+	}
+	if quake.MaxIntensity == IntensityUnknown {
+		if intensity, err := ParseIntensity(entry.MaxIntensity); err == nil {
+			quake.MaxIntensity = intensity
+		}
+	}
+	if entry.Title == "遠地地震に関する情報" {
+		quake.IsDistant = true
+	}
+	// 津波に関する付加文は震源・震度情報の詳細にあるため、それを優先する
+	if entry.DetailFile == "" || quake.detailHasComment {
+		return
+	}
+	if entry.Title == "震源・震度情報" {
+		quake.DetailFile = entry.DetailFile
+		quake.detailHasComment = true
+	} else if quake.DetailFile == "" {
+		quake.DetailFile = entry.DetailFile
+	}
+}
+
+// TsunamiCheckMagnitude は震度や距離の条件に合わなくても津波の状況を確かめる地震の規模
+// 津波警報・注意報は多くの場合 M6.5 以上の地震で発表されるため、余裕を持たせて M6.0 以上とする
+const TsunamiCheckMagnitude = 6.0
+
+// MayCauseTsunami は津波警報・注意報が発表される可能性がある地震かどうかを返す
+// 遠地地震と TsunamiCheckMagnitude 以上の地震が当たる
+func (q Earthquake) MayCauseTsunami() bool {
+	return q.IsDistant || (q.HasMagnitude && q.Magnitude >= TsunamiCheckMagnitude)
+}
+
+// Filter は表示する地震の条件
+type Filter struct {
+	MinIntensity  Intensity     // この震度以上の地震のみ
+	MaxDistanceKm float64       // 基準地点からこの距離以内の地震のみ (0 の場合は制限なし)
+	Origin        city.Area     // 距離の基準地点
+	Period        time.Duration // 過去この時間以内に発生した地震のみ (0 の場合は制限なし)
+	MaxItems      int           // 最大件数 (0 の場合は制限なし)
+}
+
+// Candidates は津波の状況を確かめる地震を返す
+// 期間内の地震のうち、震度・距離の条件に合う地震と、津波警報・注意報が発表される可能性がある地震が当たる
+// (件数は絞らない)。呼び出し側はこれらの Tsunami を設定してから Apply を呼ぶ
+func (f Filter) Candidates(quakes []Earthquake, now time.Time) []Earthquake {
+	var result []Earthquake
+	for _, quake := range quakes {
+		if f.inPeriod(quake, now) && (f.matches(quake) || quake.MayCauseTsunami()) {
+			result = append(result, quake)
+		}
+	}
+	return result
+}
+
+// Apply は now を基準に条件に合う地震を返す
+// 震源の位置が分からない地震 (震度速報のみ) は距離では絞り込まない
+// 津波警報・注意報を発表中の地震は、震度や距離の条件に合わなくても期間内であれば返す
+func (f Filter) Apply(quakes []Earthquake, now time.Time) []Earthquake {
+	var result []Earthquake
+	for _, quake := range quakes {
+		if !f.inPeriod(quake, now) {
+			continue
+		}
+		if quake.Tsunami != TsunamiWarning && !f.matches(quake) {
+			continue
+		}
+		result = append(result, quake)
+		if f.MaxItems > 0 && len(result) >= f.MaxItems {
+			break
+		}
+	}
+	return result
+}
+
+// inPeriod は地震が表示する期間内に発生したかどうかを返す
+func (f Filter) inPeriod(quake Earthquake, now time.Time) bool {
+	return f.Period <= 0 || !quake.Time.Before(now.Add(-f.Period))
+}
+
+// matches は地震が震度・距離の条件に合うかどうかを返す
+func (f Filter) matches(quake Earthquake) bool {
+	if quake.MaxIntensity < f.MinIntensity {
+		return false
+	}
+	if f.MaxDistanceKm > 0 && quake.HasEpicenter && f.Origin.DistanceTo(quake.Latitude, quake.Longitude) > f.MaxDistanceKm {
+		return false
+	}
+	return true
+}
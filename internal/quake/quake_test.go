@@ -0,0 +1,241 @@
+package quake
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/city"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// ParseList のテスト
+func TestParseList(t *testing.T) {
+	quakes, err := ParseList(readFixture(t, "list.json"))
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	// 同じ地震の複数の発表は1件にまとめる
+	if len(quakes) != 5 {
+		t.Fatalf("地震の件数: 期待=5, 実際=%d", len(quakes))
+	}
+
+	chiba := quakes[0]
+	if chiba.Epicenter != "千葉県北西部" || !chiba.HasEpicenter || chiba.DepthKm != 70 {
+		t.Errorf("震源が不正です: %+v", chiba)
+	}
+	if !chiba.HasMagnitude || chiba.Magnitude != 4.8 || chiba.MaxIntensity != Intensity4 {
+		t.Errorf("規模・震度が不正です: %+v", chiba)
+	}
+	if chiba.DetailFile != "20261018071012_20261018070312_VXSE5k_1.json" {
+		t.Errorf("詳細のファイル名: 期待=震源・震度情報, 実際=%s", chiba.DetailFile)
+	}
+
+	// 震源・震度情報の前に出た震源に関する情報より、震源・震度情報の詳細を使う
+	miyagi := quakes[2]
+	if miyagi.MaxIntensity != Intensity5Lower || miyagi.DetailFile != "20261016031512_20261016030512_VXSE5k_1.json" {
+		t.Errorf("宮城県沖の地震が不正です: %+v", miyagi)
+	}
+
+	// 震度速報のみの地震は震源が分からない
+	if quakes[3].HasEpicenter || quakes[3].HasMagnitude || quakes[3].MaxIntensity != Intensity3 {
+		t.Errorf("震度速報のみの地震が不正です: %+v", quakes[3])
+	}
+
+	// 遠地地震は震度がなくても津波の状況を確かめる
+	distant := quakes[4]
+	if !distant.IsDistant || distant.MaxIntensity != IntensityUnknown || !distant.MayCauseTsunami() {
+		t.Errorf("遠地地震が不正です: %+v", distant)
+	}
+	if chiba.IsDistant || chiba.MayCauseTsunami() {
+		t.Errorf("千葉県北西部の地震は津波の状況を確かめる対象ではありません: %+v", chiba)
+	}
+
+	if _, err := ParseList([]byte("{")); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// Filter のテスト
+func TestFilterApply(t *testing.T) {
+	quakes, err := ParseList(readFixture(t, "list.json"))
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	tokyo, _ := city.Lookup("130010")
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 18, 8, 0, 0, 0, jst)
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		tsunamis map[string]Tsunami
+		expected []string
+	}{
+		{
+			name:     "震度3以上",
+			filter:   Filter{MinIntensity: Intensity3},
+			expected: []string{"20261018070312", "20261016030512", "20261015120012"},
+		},
+		{
+			name:     "震度3以上・300km以内 (震源不明は残す)",
+			filter:   Filter{MinIntensity: Intensity3, MaxDistanceKm: 300, Origin: tokyo},
+			expected: []string{"20261018070312", "20261015120012"},
+		},
+		{
+			name:     "過去24時間",
+			filter:   Filter{MinIntensity: Intensity1, Period: 24 * time.Hour},
+			expected: []string{"20261018070312", "20261017225012"},
+		},
+		{
+			name:     "震度が小さく遠い地震でも津波警報・注意報を発表中なら残す",
+			filter:   Filter{MinIntensity: Intensity3, MaxDistanceKm: 300, Origin: tokyo},
+			tsunamis: map[string]Tsunami{"20261014090012": TsunamiWarning, "20261017225012": TsunamiWarning},
+			expected: []string{"20261018070312", "20261017225012", "20261015120012", "20261014090012"},
+		},
+		{
+			name:     "津波の心配がない遠地地震は残さない",
+			filter:   Filter{MinIntensity: Intensity3, MaxDistanceKm: 300, Origin: tokyo},
+			tsunamis: map[string]Tsunami{"20261014090012": TsunamiSlight},
+			expected: []string{"20261018070312", "20261015120012"},
+		},
+		{
+			name:     "津波警報・注意報を発表中でも期間外の地震は残さない",
+			filter:   Filter{MinIntensity: Intensity3, Period: 72 * time.Hour},
+			tsunamis: map[string]Tsunami{"20261014090012": TsunamiWarning},
+			expected: []string{"20261018070312", "20261016030512", "20261015120012"},
+		},
+		{
+			name:     "最大件数",
+			filter:   Filter{MinIntensity: Intensity1, MaxItems: 1},
+			expected: []string{"20261018070312"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTsunami := make([]Earthquake, len(quakes))
+			for i, quake := range quakes {
+				quake.Tsunami = tt.tsunamis[quake.EventID]
+				withTsunami[i] = quake
+			}
+			var actual []string
+			for _, quake := range tt.filter.Apply(withTsunami, now) {
+				actual = append(actual, quake.EventID)
+			}
+			if len(actual) != len(tt.expected) {
+				t.Fatalf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+			for i := range actual {
+				if actual[i] != tt.expected[i] {
+					t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+					break
+				}
+			}
+		})
+	}
+}
+
+// Candidates のテスト
+func TestFilterCandidates(t *testing.T) {
+	quakes, err := ParseList(readFixture(t, "list.json"))
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	tokyo, _ := city.Lookup("130010")
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 18, 8, 0, 0, 0, jst)
+
+	// 条件に合う地震に加えて、条件に合わない M6.0 以上の地震と遠地地震も津波の状況を確かめる
+	filter := Filter{MinIntensity: Intensity5Lower, MaxDistanceKm: 100, Origin: tokyo, MaxItems: 1}
+	var actual []string
+	for _, quake := range filter.Candidates(quakes, now) {
+		actual = append(actual, quake.EventID)
+	}
+	expected := []string{"20261016030512", "20261014090012"}
+	if len(actual) != len(expected) || actual[0] != expected[0] || actual[1] != expected[1] {
+		t.Errorf("期待: %v, 実際: %v", expected, actual)
+	}
+}
+
+// ParseIntensity のテスト
+func TestParseIntensity(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Intensity
+		hasError bool
+	}{
+		{"1", Intensity1, false},
+		{"5-", Intensity5Lower, false},
+		{"5弱", Intensity5Lower, false},
+		{"6+", Intensity6Upper, false},
+		{"7", Intensity7, false},
+		{"", IntensityUnknown, true},
+		{"8", IntensityUnknown, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			actual, err := ParseIntensity(tt.value)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected.Label(), actual.Label())
+			}
+		})
+	}
+}
+
+// ParseTsunami のテスト
+func TestParseTsunami(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected Tsunami
+	}{
+		{"detail_no_tsunami.json", TsunamiNone},
+		{"detail_tsunami_warning.json", TsunamiWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			actual, err := ParseTsunami(readFixture(t, tt.file))
+			if err != nil {
+				t.Fatalf("期待: エラーなし, 実際: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected.Label(), actual.Label())
+			}
+		})
+	}
+}
+
+// classifyTsunami のテスト
+func TestClassifyTsunami(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Tsunami
+	}{
+		{"この地震による津波の心配はありません。", TsunamiNone},
+		{"この地震により、日本の沿岸では若干の海面変動があるかもしれませんが、被害の心配はありません。", TsunamiSlight},
+		{"津波警報等（大津波警報・津波警報あるいは津波注意報）を発表中です。", TsunamiWarning},
+		{"震源が海底の場合、津波が発生するおそれがあります。", TsunamiUnknown},
+		{"", TsunamiUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if actual := classifyTsunami(tt.text); actual != tt.expected {
+				t.Errorf("期待: %d, 実際: %d", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package quake
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Tsunami は地震による津波の状況
+type Tsunami int
+
+const (
+	TsunamiUnknown Tsunami = iota // 津波に関する付加文がない (震度速報のみなど)
+	TsunamiNone                   // 津波の心配なし
+	TsunamiSlight                 // 若干の海面変動 (被害の心配なし)
+	TsunamiWarning                // 津波警報・注意報を発表中
+)
+
+// Label は津波の状況の表示名を返す
+func (t Tsunami) Label() string {
+	switch t {
+	case TsunamiNone:
+		return "津波の心配なし"
+	case TsunamiSlight:
+		return "若干の海面変動"
+	case TsunamiWarning:
+		return "津波警報・注意報"
+	default:
+		return ""
+	}
+}
+
+// detail は地震情報の詳細のうち津波に関する付加文の部分
+type detail struct {
+	Body struct {
+		Comments struct {
+			ForecastComment struct {
+				Text string `json:"Text"`
+				Code string `json:"Code"`
+			} `json:"ForecastComment"`
+		} `json:"Comments"`
+	} `json:"Body"`
+}
+
+// ParseTsunami は地震情報の詳細から津波の状況を返す
+func ParseTsunami(body []byte) (Tsunami, error) {
+	var parsed detail
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return TsunamiUnknown, fmt.Errorf("地震情報の詳細のパースに失敗しました: %w", err)
+	}
+	return classifyTsunami(parsed.Body.Comments.ForecastComment.Text), nil
+}
+
+// classifyTsunami は津波に関する付加文から津波の状況を判定する
+func classifyTsunami(text string) Tsunami {
+	switch {
+	case text == "":
+		return TsunamiUnknown
+	case strings.Contains(text, "津波警報等") || strings.Contains(text, "津波警報") || strings.Contains(text, "津波注意報"):
+		return TsunamiWarning
+	case strings.Contains(text, "海面変動"):
+		return TsunamiSlight
+	case strings.Contains(text, "津波の心配はありません"):
+		return TsunamiNone
+	default:
+		// 「震源が海底の場合、津波が発生するおそれがあります」など、判定できない付加文は今後の情報を待つ
+		return TsunamiUnknown
+	}
+}
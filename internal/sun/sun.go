@@ -0,0 +1,98 @@
+// Package sun は地点の日の出・日の入りの時刻を計算する。
+// 日の出の式 (NOAA の簡略式) を使い、誤差は日本付近でおおむね1〜2分以内。
+// 大気による屈折と太陽の視半径を考慮し、太陽の上端が地平線に接する時刻とする。
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// horizonAltitude は日の出・日の入りとみなす太陽の中心の高度 (度)
+// 大気差 (約34分) と太陽の視半径 (約16分) の分だけ地平線より下になる
+const horizonAltitude = -0.833
+
+// obliquity は黄道傾斜角 (度)
+const obliquity = 23.4397
+
+// j2000 は2000年1月1日12時 (UTC) のユリウス日
+const j2000 = 2451545.0
+
+// unixEpochJulianDay は1970年1月1日0時 (UTC) のユリウス日
+const unixEpochJulianDay = 2440587.5
+
+// Times は date の日付 (date のタイムゾーンでの日付) の日の出と日の入りの時刻を返す
+// 時刻は date と同じタイムゾーンで返す
+// 白夜・極夜で日の出・日の入りがない日は false を返す
+func Times(date time.Time, latitude, longitude float64) (sunrise, sunset time.Time, ok bool) {
+	transit, cosHourAngle := solarDay(date, latitude, longitude)
+	if cosHourAngle < -1 || cosHourAngle > 1 {
+		return time.Time{}, time.Time{}, false
+	}
+	hourAngle := degrees(math.Acos(cosHourAngle))
+	sunrise = fromJulianDay(transit-hourAngle/360, date.Location())
+	sunset = fromJulianDay(transit+hourAngle/360, date.Location())
+	return sunrise, sunset, true
+}
+
+// IsDaytime は t に太陽が出ている (日の出から日の入りまでの間) かどうかを返す
+// 白夜の日は終日 true、極夜の日は終日 false を返す
+func IsDaytime(t time.Time, latitude, longitude float64) bool {
+	sunrise, sunset, ok := Times(t, latitude, longitude)
+	if !ok {
+		_, cosHourAngle := solarDay(t, latitude, longitude)
+		return cosHourAngle < -1
+	}
+	return !t.Before(sunrise) && t.Before(sunset)
+}
+
+// solarDay は date の日付の南中時刻 (ユリウス日) と、日の出の時角の余弦を返す
+// 余弦が -1 より小さい場合は白夜、1 より大きい場合は極夜
+func solarDay(date time.Time, latitude, longitude float64) (transit, cosHourAngle float64) {
+	// 地方の日付の正午 (UTC) から J2000 までの日数
+	year, month, day := date.Date()
+	noon := time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+	days := math.Round(toJulianDay(noon) - j2000)
+
+	// 平均南中時刻 (東経を正とする)
+	meanNoon := days - longitude/360
+
+	// 平均近点角・中心差・黄経
+	meanAnomaly := normalizeDegrees(357.5291 + 0.98560028*meanNoon)
+	m := radians(meanAnomaly)
+	center := 1.9148*math.Sin(m) + 0.0200*math.Sin(2*m) + 0.0003*math.Sin(3*m)
+	eclipticLongitude := radians(normalizeDegrees(meanAnomaly + center + 180 + 102.9372))
+
+	// 南中時刻 (均時差の補正)
+	transit = j2000 + meanNoon + 0.0053*math.Sin(m) - 0.0069*math.Sin(2*eclipticLongitude)
+
+	// 赤緯と日の出の時角
+	sinDeclination := math.Sin(eclipticLongitude) * math.Sin(radians(obliquity))
+	cosDeclination := math.Cos(math.Asin(sinDeclination))
+	phi := radians(latitude)
+	cosHourAngle = (math.Sin(radians(horizonAltitude)) - math.Sin(phi)*sinDeclination) / (math.Cos(phi) * cosDeclination)
+	return transit, cosHourAngle
+}
+
+// toJulianDay は時刻をユリウス日に変換する
+func toJulianDay(t time.Time) float64 {
+	return float64(t.Unix())/86400 + unixEpochJulianDay
+}
+
+// fromJulianDay はユリウス日を loc の時刻 (秒単位) に変換する
+func fromJulianDay(julianDay float64, loc *time.Location) time.Time {
+	seconds := math.Round((julianDay - unixEpochJulianDay) * 86400)
+	return time.Unix(int64(seconds), 0).In(loc)
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// normalizeDegrees は角度を 0〜360度 に収める
+func normalizeDegrees(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
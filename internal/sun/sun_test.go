@@ -0,0 +1,107 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+// tolerance は国立天文台の暦の値との許容誤差
+const tolerance = 2 * time.Minute
+
+func date(value string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02", value, jst)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func clock(day time.Time, value string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", day.Format("2006-01-02")+" "+value, jst)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func within(actual, expected time.Time) bool {
+	diff := actual.Sub(expected)
+	return diff > -tolerance && diff < tolerance
+}
+
+// Times のテスト (国立天文台の日の出・日の入りの時刻と比較)
+func TestTimes(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      string
+		latitude  float64
+		longitude float64
+		sunrise   string
+		sunset    string
+	}{
+		{"東京 夏至", "2026-06-21", 35.6895, 139.6917, "04:25", "19:00"},
+		{"東京 冬至", "2026-12-22", 35.6895, 139.6917, "06:47", "16:32"},
+		{"札幌 夏至", "2026-06-21", 43.0642, 141.3469, "03:55", "19:18"},
+		{"那覇 冬至", "2026-12-22", 26.2124, 127.6809, "07:13", "17:43"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := date(tt.date)
+			sunrise, sunset, ok := Times(day, tt.latitude, tt.longitude)
+			if !ok {
+				t.Fatal("日の出・日の入りがありません")
+			}
+			if !within(sunrise, clock(day, tt.sunrise)) {
+				t.Errorf("日の出 期待: %s, 実際: %s", tt.sunrise, sunrise.Format("15:04:05"))
+			}
+			if !within(sunset, clock(day, tt.sunset)) {
+				t.Errorf("日の入り 期待: %s, 実際: %s", tt.sunset, sunset.Format("15:04:05"))
+			}
+			if sunrise.Location() != jst {
+				t.Errorf("タイムゾーンが date と異なります: %v", sunrise.Location())
+			}
+		})
+	}
+}
+
+// Times のテスト (白夜・極夜)
+func TestTimesPolar(t *testing.T) {
+	// ロングイェールビーン (北緯78度)
+	if _, _, ok := Times(date("2026-06-21"), 78.22, 15.65); ok {
+		t.Error("白夜の日は false を返すはずです")
+	}
+	if _, _, ok := Times(date("2026-12-21"), 78.22, 15.65); ok {
+		t.Error("極夜の日は false を返すはずです")
+	}
+}
+
+// IsDaytime のテスト
+func TestIsDaytime(t *testing.T) {
+	tests := []struct {
+		name      string
+		time      time.Time
+		latitude  float64
+		longitude float64
+		expected  bool
+	}{
+		{"東京 夏の昼", clock(date("2026-06-21"), "12:00"), 35.6895, 139.6917, true},
+		{"東京 夏の18時", clock(date("2026-06-21"), "18:00"), 35.6895, 139.6917, true},
+		{"東京 冬の18時", clock(date("2026-12-22"), "18:00"), 35.6895, 139.6917, false},
+		{"東京 冬の6時", clock(date("2026-12-22"), "06:00"), 35.6895, 139.6917, false},
+		{"東京 夏の21時", clock(date("2026-06-21"), "21:00"), 35.6895, 139.6917, false},
+		{"東京 夏の3時", clock(date("2026-06-21"), "03:00"), 35.6895, 139.6917, false},
+		{"白夜の深夜", clock(date("2026-06-21"), "00:00"), 78.22, 15.65, true},
+		{"極夜の正午", clock(date("2026-12-21"), "12:00"), 78.22, 15.65, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := IsDaytime(tt.time, tt.latitude, tt.longitude); actual != tt.expected {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+}
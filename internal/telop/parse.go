@@ -0,0 +1,157 @@
+package telop
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// phrase は天気の表現に現れる語
+type phrase struct {
+	text      string
+	kind      phraseKind
+	condition Condition
+	connector Connector
+	severe    bool // 激しい天気 (大雨・暴風など) かどうか
+	thunder   bool // 雷を伴うかどうか
+}
+
+type phraseKind int
+
+const (
+	phraseCondition phraseKind = iota // 天気 (晴・曇・雨など)
+	phraseThunder                     // 雷 (雷を伴う)
+	phraseSevere                      // 激しさ (暴風・強く降る)
+	phraseConnector                   // つながり (のち・時々・一時・所により・から)
+	phraseTime                        // 時間帯 (朝の内・夕方・夜など)
+	phrasePlace                       // 地域 (山沿い・海上海岸)
+	phraseTopic                       // 「夜は」「午後は」の「は」
+	phraseFiller                      // 意味を持たない語 (か・で・を伴う・止む など)
+)
+
+// phrases は天気の表現に現れる語 (長い語から順に調べる)
+var phrases = []phrase{
+	{text: "雨か雷雨", kind: phraseCondition, condition: ConditionRain, thunder: true},
+	{text: "暴風雨", kind: phraseCondition, condition: ConditionRain, severe: true},
+	{text: "暴風雪", kind: phraseCondition, condition: ConditionSnow, severe: true},
+	{text: "風雪強い", kind: phraseCondition, condition: ConditionSnow, severe: true},
+	{text: "雨か雪", kind: phraseCondition, condition: ConditionSleet},
+	{text: "雪か雨", kind: phraseCondition, condition: ConditionSleet},
+	{text: "みぞれ", kind: phraseCondition, condition: ConditionSleet},
+	{text: "雷雨", kind: phraseCondition, condition: ConditionThunder, thunder: true},
+	{text: "大雨", kind: phraseCondition, condition: ConditionRain, severe: true},
+	{text: "大雪", kind: phraseCondition, condition: ConditionSnow, severe: true},
+	{text: "霧雨", kind: phraseCondition, condition: ConditionRain},
+	{text: "快晴", kind: phraseCondition, condition: ConditionClear},
+	{text: "晴れ", kind: phraseCondition, condition: ConditionClear},
+	{text: "曇り", kind: phraseCondition, condition: ConditionCloudy},
+	{text: "くもり", kind: phraseCondition, condition: ConditionCloudy},
+	{text: "晴", kind: phraseCondition, condition: ConditionClear},
+	{text: "曇", kind: phraseCondition, condition: ConditionCloudy},
+	{text: "霧", kind: phraseCondition, condition: ConditionFog},
+	{text: "雨", kind: phraseCondition, condition: ConditionRain},
+	{text: "雪", kind: phraseCondition, condition: ConditionSnow},
+	{text: "雷", kind: phraseThunder},
+	{text: "暴風", kind: phraseSevere},
+	{text: "強く降る", kind: phraseSevere},
+	{text: "所により", kind: phraseConnector, connector: ConnectorLocally},
+	{text: "時々", kind: phraseConnector, connector: ConnectorOccasionally},
+	{text: "一時", kind: phraseConnector, connector: ConnectorTemporarily},
+	{text: "のち", kind: phraseConnector, connector: ConnectorLater},
+	{text: "後", kind: phraseConnector, connector: ConnectorLater},
+	{text: "から", kind: phraseConnector, connector: ConnectorLater},
+	{text: "朝の内", kind: phraseTime},
+	{text: "明け方", kind: phraseTime},
+	{text: "朝夕", kind: phraseTime},
+	{text: "朝晩", kind: phraseTime},
+	{text: "昼頃", kind: phraseTime},
+	{text: "夕方", kind: phraseTime},
+	{text: "日中", kind: phraseTime},
+	{text: "午後", kind: phraseTime},
+	{text: "夜", kind: phraseTime},
+	{text: "海上海岸", kind: phrasePlace},
+	{text: "山沿い", kind: phrasePlace},
+	{text: "は", kind: phraseTopic},
+	{text: "を伴う", kind: phraseFiller},
+	{text: "止む", kind: phraseFiller},
+	{text: "か", kind: phraseFiller},
+	{text: "で", kind: phraseFiller},
+}
+
+// Parse は天気の表現を分解する
+// 最初の天気を主な天気、つながりの後で主な天気と異なる最初の天気を副の天気とする
+// つながりのない「朝夕曇」「山沿い雷雨」は、時間帯なら一時、地域なら所によりとして扱う
+func Parse(text string) Telop {
+	telop := Telop{Text: text}
+	pending := ConnectorNone // 次の天気に付けるつながり
+	afterTime := false       // 直前が時間帯の語かどうか
+
+	rest := strings.TrimSpace(text)
+	for rest != "" {
+		p, ok := matchPhrase(rest)
+		if !ok {
+			// 知らない文字は読み飛ばす
+			_, size := utf8.DecodeRuneInString(rest)
+			rest = rest[size:]
+			afterTime = false
+			continue
+		}
+		rest = rest[len(p.text):]
+
+		switch p.kind {
+		case phraseCondition:
+			telop.addCondition(p.condition, pending)
+			if p.severe {
+				telop.Severe = true
+			}
+			if p.thunder {
+				telop.Thunder = true
+			}
+			pending = ConnectorNone
+		case phraseThunder:
+			telop.Thunder = true
+		case phraseSevere:
+			telop.Severe = true
+		case phraseConnector:
+			pending = p.connector
+		case phraseTime:
+			if pending == ConnectorNone {
+				pending = ConnectorTemporarily
+			}
+		case phrasePlace:
+			pending = ConnectorLocally
+		case phraseTopic:
+			// 「夜は雨」のように時間帯の後の「は」は、その時間帯から天気が変わることを表す
+			if afterTime {
+				pending = ConnectorLater
+			}
+		}
+		afterTime = p.kind == phraseTime
+	}
+	return telop
+}
+
+// addCondition は読み取った天気を主な天気・副の天気に割り当てる
+func (t *Telop) addCondition(condition Condition, connector Connector) {
+	switch {
+	case t.Primary == ConditionNone:
+		t.Primary = condition
+	case connector == ConnectorNone:
+		// つながりのない天気 (例: 雨か雷雨の雷雨) は主な天気に含める
+	case t.Secondary == ConditionNone && condition != t.Primary:
+		t.Secondary = condition
+		t.Connector = connector
+	}
+	if condition.IsPrecipitation() {
+		t.hasMorePrecipitation = true
+	}
+}
+
+// matchPhrase は text の先頭に一致する語を返す
+func matchPhrase(text string) (phrase, bool) {
+	for _, p := range phrases {
+		if strings.HasPrefix(text, p.text) {
+			return p, true
+		}
+	}
+	return phrase{}, false
+}
@@ -0,0 +1,185 @@
+// Package telop は天気予報の天気 (テロップ。例: 晴時々曇、曇のち一時雨) を扱う。
+// 気象庁の天気の表現を主な天気・つながり (のち・時々・一時・所により)・副の天気に分解し、
+// アイコンや降水の有無、正規化した天気コードを構造から求める。
+package telop
+
+import "strings"
+
+// Condition は天気の種類
+type Condition int
+
+const (
+	ConditionNone    Condition = iota // なし (読み取れない場合・副の天気がない場合)
+	ConditionClear                    // 晴れ
+	ConditionCloudy                   // 曇り
+	ConditionFog                      // 霧
+	ConditionRain                     // 雨
+	ConditionSleet                    // 雨か雪・みぞれ
+	ConditionSnow                     // 雪
+	ConditionThunder                  // 雷雨
+)
+
+// conditionKeys は天気コードに使う天気の種類の名前
+var conditionKeys = map[Condition]string{
+	ConditionClear:   "clear",
+	ConditionCloudy:  "cloudy",
+	ConditionFog:     "fog",
+	ConditionRain:    "rain",
+	ConditionSleet:   "sleet",
+	ConditionSnow:    "snow",
+	ConditionThunder: "thunder",
+}
+
+// Key は天気コードに使う名前 (例: clear) を返す
+func (c Condition) Key() string {
+	return conditionKeys[c]
+}
+
+// IsPrecipitation は降水 (雨・雪など) の天気かどうかを返す
+func (c Condition) IsPrecipitation() bool {
+	return c == ConditionRain || c == ConditionSleet || c == ConditionSnow || c == ConditionThunder
+}
+
+// Connector は主な天気と副の天気のつながり
+type Connector int
+
+const (
+	ConnectorNone         Connector = iota // 副の天気がない
+	ConnectorLater                         // のち (後)
+	ConnectorOccasionally                  // 時々
+	ConnectorTemporarily                   // 一時
+	ConnectorLocally                       // 所により
+)
+
+// Label はつながりの表記を返す
+func (c Connector) Label() string {
+	switch c {
+	case ConnectorLater:
+		return "のち"
+	case ConnectorOccasionally:
+		return "時々"
+	case ConnectorTemporarily:
+		return "一時"
+	case ConnectorLocally:
+		return "所により"
+	default:
+		return ""
+	}
+}
+
+// Severity は天気の荒れ具合
+type Severity int
+
+const (
+	SeverityCalm          Severity = iota // 降水なし
+	SeverityChance                        // 時々・一時・所により降水がある
+	SeverityPrecipitation                 // 降水がある (主な天気、またはのちの天気)
+	SeveritySevere                        // 大雨・大雪・暴風・雷を伴う
+)
+
+// Telop は天気の表現を分解した結果
+type Telop struct {
+	Text      string    // 元の表現
+	Primary   Condition // 主な天気
+	Connector Connector // 主な天気と副の天気のつながり
+	Secondary Condition // 副の天気 (ない場合は ConditionNone)
+	Thunder   bool      // 雷を伴うかどうか
+	Severe    bool      // 大雨・大雪・暴風などの激しい天気を含むかどうか
+
+	hasMorePrecipitation bool // 主な天気・副の天気以外 (例: 霧か霧雨の霧雨) も含めて降水があるかどうか
+}
+
+// Code は正規化した天気コードを返す
+// 主な天気と副の天気の名前を「-」でつなぎ、雷を伴う場合は「-thunder」を付ける (例: clear-cloudy、cloudy-rain-thunder)
+// 読み取れない場合は unknown を返す
+func (t Telop) Code() string {
+	if t.Primary == ConditionNone {
+		return "unknown"
+	}
+	parts := []string{t.Primary.Key()}
+	if t.Secondary != ConditionNone {
+		parts = append(parts, t.Secondary.Key())
+	}
+	if t.Thunder && t.Primary != ConditionThunder && t.Secondary != ConditionThunder {
+		parts = append(parts, ConditionThunder.Key())
+	}
+	return strings.Join(parts, "-")
+}
+
+// HasPrecipitation は降水 (雨・雪など) を含むかどうかを返す
+func (t Telop) HasPrecipitation() bool {
+	return t.Primary.IsPrecipitation() || t.Secondary.IsPrecipitation() || t.hasMorePrecipitation
+}
+
+// Severity は天気の荒れ具合を返す
+func (t Telop) Severity() Severity {
+	switch {
+	case t.Severe || t.Thunder:
+		return SeveritySevere
+	case t.Primary.IsPrecipitation():
+		return SeverityPrecipitation
+	case t.Secondary.IsPrecipitation() && t.Connector == ConnectorLater:
+		return SeverityPrecipitation
+	case t.HasPrecipitation():
+		return SeverityChance
+	default:
+		return SeverityCalm
+	}
+}
+
+// Emoji は天気を表す絵文字を返す
+// 主な天気と副の天気の組み合わせで選び、読み取れない場合は 🌡️ を返す
+func (t Telop) Emoji() string {
+	if t.Thunder && t.Primary.IsPrecipitation() {
+		return "⚡"
+	}
+	switch t.Primary {
+	case ConditionClear:
+		switch {
+		case t.Secondary == ConditionCloudy:
+			return "🌤️"
+		case t.Secondary == ConditionSnow || t.Secondary == ConditionSleet:
+			return "🌨️"
+		case t.Secondary.IsPrecipitation():
+			return "🌦️"
+		}
+		return "☀️"
+	case ConditionCloudy:
+		switch {
+		case t.Secondary == ConditionClear:
+			return "⛅"
+		case t.Secondary == ConditionSnow || t.Secondary == ConditionSleet:
+			return "🌨️"
+		case t.Secondary.IsPrecipitation():
+			return "🌧️"
+		}
+		return "☁️"
+	case ConditionFog:
+		return "🌫️"
+	case ConditionRain:
+		return "☔"
+	case ConditionSleet:
+		return "🌨️"
+	case ConditionSnow:
+		return "⛄"
+	case ConditionThunder:
+		return "⚡"
+	default:
+		return "🌡️"
+	}
+}
+
+// NightEmoji は夜の天気を表す絵文字を返す
+// 太陽を含む絵文字は月や雲の絵文字に替え、それ以外は Emoji と同じものを返す
+func (t Telop) NightEmoji() string {
+	switch emoji := t.Emoji(); emoji {
+	case "☀️":
+		return "🌙"
+	case "🌤️", "⛅":
+		return "☁️"
+	case "🌦️":
+		return "🌧️"
+	default:
+		return emoji
+	}
+}
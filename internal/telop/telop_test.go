@@ -0,0 +1,238 @@
+package telop
+
+import "testing"
+
+// Parse のテスト (気象庁の天気予報の天気の一覧と、天気予報APIの表記)
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text      string
+		code      string
+		connector Connector
+		severity  Severity
+	}{
+		// 晴れ (100番台)
+		{"晴", "clear", ConnectorNone, SeverityCalm},
+		{"晴時々曇", "clear-cloudy", ConnectorOccasionally, SeverityCalm},
+		{"晴一時雨", "clear-rain", ConnectorTemporarily, SeverityChance},
+		{"晴時々雨", "clear-rain", ConnectorOccasionally, SeverityChance},
+		{"晴一時雪", "clear-snow", ConnectorTemporarily, SeverityChance},
+		{"晴時々雪", "clear-snow", ConnectorOccasionally, SeverityChance},
+		{"晴一時雨か雪", "clear-sleet", ConnectorTemporarily, SeverityChance},
+		{"晴時々雨か雪", "clear-sleet", ConnectorOccasionally, SeverityChance},
+		{"晴一時雨か雷雨", "clear-rain-thunder", ConnectorTemporarily, SeveritySevere},
+		{"晴時々雨か雷雨", "clear-rain-thunder", ConnectorOccasionally, SeveritySevere},
+		{"晴後時々曇", "clear-cloudy", ConnectorOccasionally, SeverityCalm},
+		{"晴後曇", "clear-cloudy", ConnectorLater, SeverityCalm},
+		{"晴後一時雨", "clear-rain", ConnectorTemporarily, SeverityChance},
+		{"晴後時々雨", "clear-rain", ConnectorOccasionally, SeverityChance},
+		{"晴後雨", "clear-rain", ConnectorLater, SeverityPrecipitation},
+		{"晴後一時雪", "clear-snow", ConnectorTemporarily, SeverityChance},
+		{"晴後時々雪", "clear-snow", ConnectorOccasionally, SeverityChance},
+		{"晴後雪", "clear-snow", ConnectorLater, SeverityPrecipitation},
+		{"晴後雨か雪", "clear-sleet", ConnectorLater, SeverityPrecipitation},
+		{"晴後雨か雷雨", "clear-rain-thunder", ConnectorLater, SeveritySevere},
+		{"晴朝夕一時雨", "clear-rain", ConnectorTemporarily, SeverityChance},
+		{"晴朝の内一時雨", "clear-rain", ConnectorTemporarily, SeverityChance},
+		{"晴夕方一時雨", "clear-rain", ConnectorTemporarily, SeverityChance},
+		{"晴山沿い雷雨", "clear-thunder", ConnectorLocally, SeveritySevere},
+		{"晴山沿い雪", "clear-snow", ConnectorLocally, SeverityChance},
+		{"晴午後は雷雨", "clear-thunder", ConnectorLater, SeveritySevere},
+		{"晴昼頃から雨", "clear-rain", ConnectorLater, SeverityPrecipitation},
+		{"晴夕方から雨", "clear-rain", ConnectorLater, SeverityPrecipitation},
+		{"晴夜は雨", "clear-rain", ConnectorLater, SeverityPrecipitation},
+		{"朝の内霧後晴", "fog-clear", ConnectorLater, SeverityCalm},
+		{"晴明け方霧", "clear-fog", ConnectorTemporarily, SeverityCalm},
+		{"晴朝夕曇", "clear-cloudy", ConnectorTemporarily, SeverityCalm},
+		{"晴時々雨で雷を伴う", "clear-rain-thunder", ConnectorOccasionally, SeveritySevere},
+		{"晴一時雪か雨", "clear-sleet", ConnectorTemporarily, SeverityChance},
+		{"晴時々雪か雨", "clear-sleet", ConnectorOccasionally, SeverityChance},
+		{"晴後雪か雨", "clear-sleet", ConnectorLater, SeverityPrecipitation},
+		// 曇り (200番台)
+		{"曇", "cloudy", ConnectorNone, SeverityCalm},
+		{"曇時々晴", "cloudy-clear", ConnectorOccasionally, SeverityCalm},
+		{"曇一時雨", "cloudy-rain", ConnectorTemporarily, SeverityChance},
+		{"曇時々雨", "cloudy-rain", ConnectorOccasionally, SeverityChance},
+		{"曇一時雪", "cloudy-snow", ConnectorTemporarily, SeverityChance},
+		{"曇時々雪", "cloudy-snow", ConnectorOccasionally, SeverityChance},
+		{"曇一時雨か雪", "cloudy-sleet", ConnectorTemporarily, SeverityChance},
+		{"曇時々雨か雪", "cloudy-sleet", ConnectorOccasionally, SeverityChance},
+		{"曇一時雨か雷雨", "cloudy-rain-thunder", ConnectorTemporarily, SeveritySevere},
+		{"霧", "fog", ConnectorNone, SeverityCalm},
+		{"曇後時々晴", "cloudy-clear", ConnectorOccasionally, SeverityCalm},
+		{"曇後晴", "cloudy-clear", ConnectorLater, SeverityCalm},
+		{"曇後一時雨", "cloudy-rain", ConnectorTemporarily, SeverityChance},
+		{"曇後時々雨", "cloudy-rain", ConnectorOccasionally, SeverityChance},
+		{"曇後雨", "cloudy-rain", ConnectorLater, SeverityPrecipitation},
+		{"曇後一時雪", "cloudy-snow", ConnectorTemporarily, SeverityChance},
+		{"曇後時々雪", "cloudy-snow", ConnectorOccasionally, SeverityChance},
+		{"曇後雪", "cloudy-snow", ConnectorLater, SeverityPrecipitation},
+		{"曇後雨か雪", "cloudy-sleet", ConnectorLater, SeverityPrecipitation},
+		{"曇後雨か雷雨", "cloudy-rain-thunder", ConnectorLater, SeveritySevere},
+		{"曇朝夕一時雨", "cloudy-rain", ConnectorTemporarily, SeverityChance},
+		{"曇朝の内一時雨", "cloudy-rain", ConnectorTemporarily, SeverityChance},
+		{"曇夕方一時雨", "cloudy-rain", ConnectorTemporarily, SeverityChance},
+		{"曇日中時々晴", "cloudy-clear", ConnectorOccasionally, SeverityCalm},
+		{"曇昼頃から雨", "cloudy-rain", ConnectorLater, SeverityPrecipitation},
+		{"曇夕方から雨", "cloudy-rain", ConnectorLater, SeverityPrecipitation},
+		{"曇夜は雨", "cloudy-rain", ConnectorLater, SeverityPrecipitation},
+		{"曇昼頃から雪", "cloudy-snow", ConnectorLater, SeverityPrecipitation},
+		{"曇夕方から雪", "cloudy-snow", ConnectorLater, SeverityPrecipitation},
+		{"曇夜は雪", "cloudy-snow", ConnectorLater, SeverityPrecipitation},
+		{"曇海上海岸は霧か霧雨", "cloudy-fog", ConnectorLocally, SeverityChance},
+		{"曇時々雨で雷を伴う", "cloudy-rain-thunder", ConnectorOccasionally, SeveritySevere},
+		{"曇時々雪で雷を伴う", "cloudy-snow-thunder", ConnectorOccasionally, SeveritySevere},
+		{"曇一時雪か雨", "cloudy-sleet", ConnectorTemporarily, SeverityChance},
+		{"曇時々雪か雨", "cloudy-sleet", ConnectorOccasionally, SeverityChance},
+		{"曇後雪か雨", "cloudy-sleet", ConnectorLater, SeverityPrecipitation},
+		// 雨 (300番台)
+		{"雨", "rain", ConnectorNone, SeverityPrecipitation},
+		{"雨時々晴", "rain-clear", ConnectorOccasionally, SeverityPrecipitation},
+		{"雨時々止む", "rain", ConnectorNone, SeverityPrecipitation},
+		{"雨時々雪", "rain-snow", ConnectorOccasionally, SeverityPrecipitation},
+		{"雨か雪", "sleet", ConnectorNone, SeverityPrecipitation},
+		{"大雨", "rain", ConnectorNone, SeveritySevere},
+		{"雨で暴風を伴う", "rain", ConnectorNone, SeveritySevere},
+		{"雨一時雪", "rain-snow", ConnectorTemporarily, SeverityPrecipitation},
+		{"雨後晴", "rain-clear", ConnectorLater, SeverityPrecipitation},
+		{"雨後曇", "rain-cloudy", ConnectorLater, SeverityPrecipitation},
+		{"雨後時々雪", "rain-snow", ConnectorOccasionally, SeverityPrecipitation},
+		{"雨後雪", "rain-snow", ConnectorLater, SeverityPrecipitation},
+		{"雨か雪後晴", "sleet-clear", ConnectorLater, SeverityPrecipitation},
+		{"雨か雪後曇", "sleet-cloudy", ConnectorLater, SeverityPrecipitation},
+		{"朝の内雨後晴", "rain-clear", ConnectorLater, SeverityPrecipitation},
+		{"朝の内雨後曇", "rain-cloudy", ConnectorLater, SeverityPrecipitation},
+		{"雨朝晩一時雪", "rain-snow", ConnectorTemporarily, SeverityPrecipitation},
+		{"雨昼頃から晴", "rain-clear", ConnectorLater, SeverityPrecipitation},
+		{"雨夕方から晴", "rain-clear", ConnectorLater, SeverityPrecipitation},
+		{"雨夜は晴", "rain-clear", ConnectorLater, SeverityPrecipitation},
+		{"雨夕方から雪", "rain-snow", ConnectorLater, SeverityPrecipitation},
+		{"雨夜は雪", "rain-snow", ConnectorLater, SeverityPrecipitation},
+		{"雨一時強く降る", "rain", ConnectorNone, SeveritySevere},
+		{"雨一時みぞれ", "rain-sleet", ConnectorTemporarily, SeverityPrecipitation},
+		{"雪か雨", "sleet", ConnectorNone, SeverityPrecipitation},
+		{"雨で雷を伴う", "rain-thunder", ConnectorNone, SeveritySevere},
+		{"雪か雨後晴", "sleet-clear", ConnectorLater, SeverityPrecipitation},
+		{"雪か雨後曇", "sleet-cloudy", ConnectorLater, SeverityPrecipitation},
+		// 雪 (400番台)
+		{"雪", "snow", ConnectorNone, SeverityPrecipitation},
+		{"雪時々晴", "snow-clear", ConnectorOccasionally, SeverityPrecipitation},
+		{"雪時々止む", "snow", ConnectorNone, SeverityPrecipitation},
+		{"雪時々雨", "snow-rain", ConnectorOccasionally, SeverityPrecipitation},
+		{"大雪", "snow", ConnectorNone, SeveritySevere},
+		{"風雪強い", "snow", ConnectorNone, SeveritySevere},
+		{"暴風雪", "snow", ConnectorNone, SeveritySevere},
+		{"雪一時雨", "snow-rain", ConnectorTemporarily, SeverityPrecipitation},
+		{"雪後晴", "snow-clear", ConnectorLater, SeverityPrecipitation},
+		{"雪後曇", "snow-cloudy", ConnectorLater, SeverityPrecipitation},
+		{"雪後雨", "snow-rain", ConnectorLater, SeverityPrecipitation},
+		{"朝の内雪後晴", "snow-clear", ConnectorLater, SeverityPrecipitation},
+		{"朝の内雪後曇", "snow-cloudy", ConnectorLater, SeverityPrecipitation},
+		{"雪昼頃から雨", "snow-rain", ConnectorLater, SeverityPrecipitation},
+		{"雪夕方から雨", "snow-rain", ConnectorLater, SeverityPrecipitation},
+		{"雪一時強く降る", "snow", ConnectorNone, SeveritySevere},
+		{"雪後みぞれ", "snow-sleet", ConnectorLater, SeverityPrecipitation},
+		{"雪一時みぞれ", "snow-sleet", ConnectorTemporarily, SeverityPrecipitation},
+		{"雪で雷を伴う", "snow-thunder", ConnectorNone, SeveritySevere},
+		// 天気予報APIの表記など
+		{"晴れ", "clear", ConnectorNone, SeverityCalm},
+		{"曇り", "cloudy", ConnectorNone, SeverityCalm},
+		{"晴のち曇", "clear-cloudy", ConnectorLater, SeverityCalm},
+		{"曇のち晴", "cloudy-clear", ConnectorLater, SeverityCalm},
+		{"曇のち一時雨", "cloudy-rain", ConnectorTemporarily, SeverityChance},
+		{"くもり", "cloudy", ConnectorNone, SeverityCalm},
+		{"快晴", "clear", ConnectorNone, SeverityCalm},
+		{"雷雨", "thunder", ConnectorNone, SeveritySevere},
+		{"暴風雨", "rain", ConnectorNone, SeveritySevere},
+		{"", "unknown", ConnectorNone, SeverityCalm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			actual := Parse(tt.text)
+			if actual.Code() != tt.code || actual.Connector != tt.connector || actual.Severity() != tt.severity {
+				t.Errorf("期待: %s (%s, 荒れ具合 %d), 実際: %s (%s, 荒れ具合 %d)",
+					tt.code, tt.connector.Label(), tt.severity, actual.Code(), actual.Connector.Label(), actual.Severity())
+			}
+		})
+	}
+}
+
+// Emoji のテスト
+func TestEmoji(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		// 「晴れ時々曇り」は晴れだけのアイコンにならない
+		{"晴れ時々曇り", "🌤️"},
+		{"曇り時々晴れ", "⛅"},
+		{"曇りのち雨", "🌧️"},
+		{"晴時々雨", "🌦️"},
+		{"曇一時雪", "🌨️"},
+		{"晴れ", "☀️"},
+		{"曇り", "☁️"},
+		{"雨", "☔"},
+		{"雪", "⛄"},
+		{"雨か雪", "🌨️"},
+		{"雨で雷を伴う", "⚡"},
+		{"霧", "🌫️"},
+		{"不明", "🌡️"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if actual := Parse(tt.text).Emoji(); actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// NightEmoji のテスト
+func TestNightEmoji(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"晴れ", "🌙"},
+		{"晴れ時々曇り", "☁️"},
+		{"曇り時々晴れ", "☁️"},
+		{"晴時々雨", "🌧️"},
+		// 太陽を含まない絵文字は昼と同じ
+		{"晴一時雪", "🌨️"},
+		{"曇り", "☁️"},
+		{"雨", "☔"},
+		{"不明", "🌡️"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if actual := Parse(tt.text).NightEmoji(); actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// HasPrecipitation のテスト
+func TestHasPrecipitation(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{"晴時々曇", false},
+		{"曇一時雨", true},
+		{"晴後雪", true},
+		// 3つ目の天気 (霧雨) の降水も含める
+		{"曇海上海岸は霧か霧雨", true},
+		{"霧", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if actual := Parse(tt.text).HasPrecipitation(); actual != tt.expected {
+				t.Errorf("期待: %v, 実際: %v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package transit
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ProviderDelayList は鉄道遅延情報のJSON (tetsudo.rti-giken.jp) の取得元の名前
+const ProviderDelayList = "delaylist"
+
+// DelayListURL は鉄道遅延情報のJSONのURL
+const DelayListURL = "https://tetsudo.rti-giken.jp/free/delay.json"
+
+// DelayListProvider は遅延している路線の一覧 (JSON) から運行状況を判定する
+// 一覧にない路線は平常運転とみなす。遅延と運転見合わせの区別はない
+type DelayListProvider struct{}
+
+// delayListEntry は遅延している路線の一覧の1件
+type delayListEntry struct {
+	Name          string `json:"name"`           // 路線名
+	Company       string `json:"company"`        // 事業者名
+	LastUpdateGMT int64  `json:"lastupdate_gmt"` // 更新日時 (UNIX時間)
+	Source        string `json:"source"`         // 情報源
+}
+
+// Name は取得元の名前を返す
+func (DelayListProvider) Name() string {
+	return ProviderDelayList
+}
+
+// URL は運行情報を取得するURLを返す
+func (DelayListProvider) URL() string {
+	return DelayListURL
+}
+
+// Parse は遅延している路線の一覧から各路線の運行状況を返す
+func (DelayListProvider) Parse(body []byte, lines []Line) ([]LineStatus, error) {
+	var entries []delayListEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("遅延情報のパースに失敗しました: %w", err)
+	}
+
+	statuses := make([]LineStatus, len(lines))
+	for i, line := range lines {
+		statuses[i] = LineStatus{Line: line, Status: StatusNormal}
+		for _, entry := range entries {
+			if entry.Name != line.Name || (line.Company != "" && entry.Company != line.Company) {
+				continue
+			}
+			statuses[i].Status = StatusDelayed
+			if entry.LastUpdateGMT > 0 {
+				statuses[i].UpdatedAt = time.Unix(entry.LastUpdateGMT, 0)
+			}
+			break
+		}
+	}
+	return statuses, nil
+}
@@ -0,0 +1,75 @@
+package transit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ProviderODPT は公共交通オープンデータセンター (ODPT) の列車運行情報の取得元の名前
+const ProviderODPT = "odpt"
+
+// ODPTTrainInformationURL は ODPT の列車運行情報APIのURL
+const ODPTTrainInformationURL = "https://api.odpt.org/api/v4/odpt:TrainInformation"
+
+// ODPTProvider は ODPT の列車運行情報から運行状況を判定する
+// 路線は Line.ODPTRailway (odpt.Railway: を除いた路線ID) で照合する
+type ODPTProvider struct {
+	ConsumerKey string // アクセストークン
+}
+
+// odptTrainInformation は列車運行情報の1件
+type odptTrainInformation struct {
+	Date    string            `json:"dc:date"`                     // 更新日時 (ISO 8601)
+	Railway string            `json:"odpt:railway"`                // 路線ID (例: odpt.Railway:JR-East.Yamanote)
+	Status  map[string]string `json:"odpt:trainInformationStatus"` // 運行状況 (平常時は省略される)
+	Text    map[string]string `json:"odpt:trainInformationText"`   // 運行情報の本文
+}
+
+// Name は取得元の名前を返す
+func (ODPTProvider) Name() string {
+	return ProviderODPT
+}
+
+// URL は運行情報を取得するURLを返す
+func (p ODPTProvider) URL() string {
+	return ODPTTrainInformationURL + "?acl:consumerKey=" + url.QueryEscape(p.ConsumerKey)
+}
+
+// Parse は列車運行情報から各路線の運行状況を返す
+// 一覧にない路線は情報なしとする (ODPT は対応する事業者の路線のみを提供するため)
+func (ODPTProvider) Parse(body []byte, lines []Line) ([]LineStatus, error) {
+	var informations []odptTrainInformation
+	if err := json.Unmarshal(body, &informations); err != nil {
+		return nil, fmt.Errorf("列車運行情報のパースに失敗しました: %w", err)
+	}
+
+	statuses := Unknown(lines)
+	for i, line := range lines {
+		if line.ODPTRailway == "" {
+			continue
+		}
+		for _, information := range informations {
+			if strings.TrimPrefix(information.Railway, "odpt.Railway:") != strings.TrimPrefix(line.ODPTRailway, "odpt.Railway:") {
+				continue
+			}
+			text := information.Text["ja"]
+			if status, ok := information.Status["ja"]; ok && status != "" {
+				// 本文には経緯 (「〜で運転を見合わせていましたが」など) が含まれるため、運行状況の文言だけで判定する
+				statuses[i].Status = Classify(status)
+			} else {
+				statuses[i].Status = StatusNormal
+			}
+			if statuses[i].Status.IsProblem() {
+				statuses[i].Detail = text
+			}
+			if t, err := time.Parse(time.RFC3339, information.Date); err == nil {
+				statuses[i].UpdatedAt = t
+			}
+			break
+		}
+	}
+	return statuses, nil
+}
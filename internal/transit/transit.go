@@ -0,0 +1,115 @@
+// Package transit は鉄道の運行情報を取得元 (Provider) ごとの形式からパースし、
+// 設定された路線の運行状況 (平常運転・遅延・運転見合わせ) にまとめる。
+// 運行状況は運行情報の文言から判定し、判定できない文言は何らかの乱れ (遅延) とみなす。
+package transit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Status は路線の運行状況
+type Status int
+
+const (
+	StatusUnknown   Status = iota // 情報を取得できなかった
+	StatusNormal                  // 平常運転
+	StatusDelayed                 // 遅延
+	StatusSuspended               // 運転見合わせ
+)
+
+// Label は運行状況の表示名を返す
+func (s Status) Label() string {
+	switch s {
+	case StatusNormal:
+		return "平常運転"
+	case StatusDelayed:
+		return "遅延"
+	case StatusSuspended:
+		return "運転見合わせ"
+	default:
+		return "情報なし"
+	}
+}
+
+// IsProblem は遅延・運転見合わせかどうかを返す
+func (s Status) IsProblem() bool {
+	return s == StatusDelayed || s == StatusSuspended
+}
+
+// Line は運行状況を表示する路線の設定
+type Line struct {
+	Name        string // 路線名 (例: 山手線)
+	Company     string // 事業者名 (例: JR東日本)。同じ名前の路線を区別する場合に指定する
+	ODPTRailway string // 公共交通オープンデータの路線ID (例: JR-East.Yamanote)
+}
+
+// LineStatus は路線の運行状況
+type LineStatus struct {
+	Line      Line
+	Status    Status
+	Detail    string    // 運行情報の本文 (取得元が提供する場合のみ)
+	UpdatedAt time.Time // 情報の更新日時 (取得元が提供する場合のみ)
+}
+
+// Provider は運行情報の取得元
+type Provider interface {
+	// Name は取得元の名前を返す
+	Name() string
+	// URL は運行情報を取得するURLを返す
+	URL() string
+	// Parse は取得した内容から各路線の運行状況を返す (lines と同じ順)
+	Parse(body []byte, lines []Line) ([]LineStatus, error)
+}
+
+// NewProvider は名前から取得元を生成する
+// odpt は公共交通オープンデータセンターのAPIで、consumerKey (アクセストークン) が必要
+func NewProvider(name string, consumerKey string) (Provider, error) {
+	switch name {
+	case "", ProviderDelayList:
+		return DelayListProvider{}, nil
+	case ProviderODPT:
+		if consumerKey == "" {
+			return nil, fmt.Errorf("取得元 %s にはアクセストークン (ODPT_CONSUMER_KEY) が必要です", name)
+		}
+		return ODPTProvider{ConsumerKey: consumerKey}, nil
+	default:
+		return nil, fmt.Errorf("運行情報の取得元が不正です: %q (%s または %s を指定してください)", name, ProviderDelayList, ProviderODPT)
+	}
+}
+
+// Classify は運行情報の文言から運行状況を判定する
+func Classify(text string) Status {
+	switch {
+	case containsAny(text, "見合わせ", "運休", "運転中止", "運転を中止"):
+		return StatusSuspended
+	case containsAny(text, "遅延", "遅れ", "ダイヤが乱れ", "ダイヤ乱れ", "運転再開", "運転を再開"):
+		return StatusDelayed
+	case containsAny(text, "平常", "平常どおり", "平常通り"):
+		return StatusNormal
+	case strings.TrimSpace(text) == "":
+		return StatusUnknown
+	default:
+		// 判定できない文言でも運行情報が出ている場合は何らかの乱れとみなす
+		return StatusDelayed
+	}
+}
+
+// Unknown はすべての路線を「情報なし」とした運行状況を返す (取得に失敗した場合に使う)
+func Unknown(lines []Line) []LineStatus {
+	statuses := make([]LineStatus, len(lines))
+	for i, line := range lines {
+		statuses[i] = LineStatus{Line: line, Status: StatusUnknown}
+	}
+	return statuses
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
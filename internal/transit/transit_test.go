@@ -0,0 +1,125 @@
+package transit
+
+import (
+	"os"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// DelayListProvider のテスト
+func TestDelayListProviderParse(t *testing.T) {
+	lines := []Line{
+		{Name: "山手線"},
+		{Name: "本線", Company: "京急電鉄"},
+		{Name: "本線", Company: "京成電鉄"},
+		{Name: "東海道線"},
+	}
+	statuses, err := DelayListProvider{}.Parse(readFixture(t, "delay.json"), lines)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+
+	expected := []Status{StatusDelayed, StatusDelayed, StatusNormal, StatusNormal}
+	for i, status := range statuses {
+		if status.Status != expected[i] {
+			t.Errorf("%s (%s): 期待=%s, 実際=%s", lines[i].Name, lines[i].Company, expected[i].Label(), status.Status.Label())
+		}
+	}
+	if statuses[0].UpdatedAt.Unix() != 1760745000 {
+		t.Errorf("更新日時: 期待=%d, 実際=%d", 1760745000, statuses[0].UpdatedAt.Unix())
+	}
+
+	if _, err := (DelayListProvider{}).Parse([]byte("<html>"), lines); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// ODPTProvider のテスト
+func TestODPTProviderParse(t *testing.T) {
+	lines := []Line{
+		{Name: "山手線", ODPTRailway: "JR-East.Yamanote"},
+		{Name: "中央線快速", ODPTRailway: "odpt.Railway:JR-East.ChuoRapid"},
+		{Name: "銀座線", ODPTRailway: "TokyoMetro.Ginza"},
+		{Name: "京急本線", ODPTRailway: "Keikyu.Main"},
+		{Name: "路線IDなし"},
+	}
+	statuses, err := ODPTProvider{ConsumerKey: "test"}.Parse(readFixture(t, "odpt_train_information.json"), lines)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+
+	expected := []Status{StatusSuspended, StatusDelayed, StatusNormal, StatusUnknown, StatusUnknown}
+	for i, status := range statuses {
+		if status.Status != expected[i] {
+			t.Errorf("%s: 期待=%s, 実際=%s", lines[i].Name, expected[i].Label(), status.Status.Label())
+		}
+	}
+	if statuses[0].Detail == "" {
+		t.Error("運転見合わせの路線に運行情報の本文がありません")
+	}
+	if statuses[2].Detail != "" {
+		t.Errorf("平常運転の路線の本文: 期待=空, 実際=%q", statuses[2].Detail)
+	}
+}
+
+// Classify のテスト
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Status
+	}{
+		{"運転見合わせ", StatusSuspended},
+		{"一部運休", StatusSuspended},
+		{"直通運転中止", StatusSuspended},
+		{"遅延", StatusDelayed},
+		{"運転再開", StatusDelayed},
+		{"ダイヤ乱れ", StatusDelayed},
+		{"平常運転", StatusNormal},
+		{"その他", StatusDelayed},
+		{"", StatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if actual := Classify(tt.text); actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected.Label(), actual.Label())
+			}
+		})
+	}
+}
+
+// NewProvider のテスト
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name        string
+		consumerKey string
+		expected    string
+		hasError    bool
+	}{
+		{"", "", ProviderDelayList, false},
+		{ProviderDelayList, "", ProviderDelayList, false},
+		{ProviderODPT, "key", ProviderODPT, false},
+		{ProviderODPT, "", "", true},
+		{"unknown", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewProvider(tt.name, tt.consumerKey)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("エラー: 期待=%v, 実際=%v", tt.hasError, err)
+			}
+			if err == nil && provider.Name() != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, provider.Name())
+			}
+		})
+	}
+}
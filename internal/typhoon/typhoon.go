@@ -0,0 +1,223 @@
+// Package typhoon は気象庁防災情報 (bosai) の台風情報をパースし、
+// 台風の現在の勢力・位置と、予報進路から求めた指定地点への最接近の時刻・距離を計算する。
+// 最接近は予報進路の各時刻の中心位置を直線で補間して求めるため、おおよその目安になる。
+package typhoon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/city"
+)
+
+// TargetListURL は発表中の台風・熱帯低気圧の一覧 (JSON) のURL
+const TargetListURL = "https://www.jma.go.jp/bosai/typhoon/data/targetTc.json"
+
+// SpecificationsURL は台風の諸元 (実況と予報) のURLを返す
+func SpecificationsURL(id string) string {
+	return "https://www.jma.go.jp/bosai/typhoon/data/" + id + "/specifications.json"
+}
+
+// approachStep は最接近を求めるときに予報進路をたどる間隔
+const approachStep = 10 * time.Minute
+
+// Typhoon は台風の実況と予報進路
+type Typhoon struct {
+	ID         string       // 識別子 (例: TC2610)
+	Number     int          // 台風番号 (例: 10。熱帯低気圧の場合は 0)
+	Name       string       // 名前 (例: ハギビス)
+	Category   string       // 種別 (例: 台風)
+	Intensity  string       // 強さ (例: 非常に強い)
+	Size       string       // 大きさ (例: 大型)
+	Location   string       // 存在地域 (例: 沖縄の南)
+	ObservedAt time.Time    // 実況の時刻
+	Latitude   float64      // 中心の緯度
+	Longitude  float64      // 中心の経度
+	Pressure   int          // 中心気圧 (hPa)
+	MaxWind    int          // 最大風速 (m/s、不明の場合は 0)
+	Course     string       // 進行方向 (例: 北北東、ほとんど停滞)
+	SpeedKmh   int          // 速さ (km/h、不明の場合は 0)
+	Track      []TrackPoint // 実況と予報の中心位置 (時刻順)
+}
+
+// TrackPoint は進路上の中心位置
+type TrackPoint struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+}
+
+// Approach は台風の中心が指定した地点に最も近づく時刻と距離
+type Approach struct {
+	Time       time.Time
+	DistanceKm float64
+}
+
+// IsTyphoon は台風 (番号の付いた熱帯低気圧) かどうかを返す
+func (t Typhoon) IsTyphoon() bool {
+	return t.Number > 0
+}
+
+// DistanceFrom は指定した地点から台風の中心までの現在の距離 (km) を返す
+func (t Typhoon) DistanceFrom(origin city.Area) float64 {
+	return origin.DistanceTo(t.Latitude, t.Longitude)
+}
+
+// ClosestApproach は予報進路を直線で補間し、中心が origin に最も近づく時刻と距離を返す
+// 予報進路がない場合は false を返す
+func (t Typhoon) ClosestApproach(origin city.Area) (Approach, bool) {
+	if len(t.Track) < 2 {
+		return Approach{}, false
+	}
+
+	best := Approach{Time: t.Track[0].Time, DistanceKm: origin.DistanceTo(t.Track[0].Latitude, t.Track[0].Longitude)}
+	for i := 1; i < len(t.Track); i++ {
+		from, to := t.Track[i-1], t.Track[i]
+		span := to.Time.Sub(from.Time)
+		if span <= 0 {
+			continue
+		}
+		for elapsed := approachStep; elapsed <= span; elapsed += approachStep {
+			ratio := float64(elapsed) / float64(span)
+			latitude := from.Latitude + (to.Latitude-from.Latitude)*ratio
+			longitude := from.Longitude + (to.Longitude-from.Longitude)*ratio
+			if distance := origin.DistanceTo(latitude, longitude); distance < best.DistanceKm {
+				best = Approach{Time: from.Time.Add(elapsed), DistanceKm: distance}
+			}
+		}
+	}
+	return best, true
+}
+
+// ParseTargets は発表中の台風・熱帯低気圧の一覧から識別子を返す
+func ParseTargets(body []byte) ([]string, error) {
+	var targets []struct {
+		TropicalCyclone string `json:"tropicalCyclone"`
+	}
+	if err := json.Unmarshal(body, &targets); err != nil {
+		return nil, fmt.Errorf("台風情報の一覧のパースに失敗しました: %w", err)
+	}
+
+	var ids []string
+	for _, target := range targets {
+		if target.TropicalCyclone != "" {
+			ids = append(ids, target.TropicalCyclone)
+		}
+	}
+	return ids, nil
+}
+
+// specification は台風の諸元の1要素 (先頭は発表の情報、以降は実況と予報)
+type specification struct {
+	TropicalCyclone string         `json:"tropicalCyclone"`
+	TyphoonNumber   flexibleString `json:"typhoonNumber"`
+	Name            localized      `json:"name"`
+	Part            localized      `json:"part"`
+	ValidTime       struct {
+		JST string `json:"JST"`
+	} `json:"validtime"`
+	Category  localized      `json:"category"`
+	Intensity flexibleString `json:"intensity"`
+	Size      flexibleString `json:"size"`
+	Location  flexibleString `json:"location"`
+	Position  struct {
+		Deg []float64 `json:"deg"`
+	} `json:"position"`
+	Course      flexibleString            `json:"course"`
+	Speed       map[string]flexibleString `json:"speed"`
+	Pressure    flexibleString            `json:"pressure"`
+	MaximumWind struct {
+		Sustained map[string]flexibleString `json:"sustained"`
+	} `json:"maximumWind"`
+}
+
+// localized は日本語と英語の表記
+type localized struct {
+	JP string `json:"jp"`
+}
+
+// flexibleString は文字列または数値で書かれた値
+type flexibleString string
+
+// UnmarshalJSON は文字列・数値のどちらも受け付ける
+func (f *flexibleString) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*f = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*f = flexibleString(s)
+		return nil
+	}
+	*f = flexibleString(data)
+	return nil
+}
+
+// int は数値に変換する (変換できない場合は 0)
+func (f flexibleString) int() int {
+	n, err := strconv.Atoi(strings.TrimSpace(string(f)))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// ParseSpecifications は台風の諸元をパースする
+func ParseSpecifications(body []byte) (Typhoon, error) {
+	var specs []specification
+	if err := json.Unmarshal(body, &specs); err != nil {
+		return Typhoon{}, fmt.Errorf("台風情報のパースに失敗しました: %w", err)
+	}
+
+	var typhoon Typhoon
+	hasAnalysis := false
+	for _, spec := range specs {
+		if spec.TropicalCyclone != "" && typhoon.ID == "" {
+			typhoon.ID = spec.TropicalCyclone
+		}
+		if spec.TyphoonNumber != "" && typhoon.Number == 0 {
+			// 台風番号は西暦の下2桁と番号を続けた4桁 (例: 2610)
+			typhoon.Number = spec.TyphoonNumber.int() % 100
+		}
+		if spec.Name.JP != "" && typhoon.Name == "" {
+			typhoon.Name = spec.Name.JP
+		}
+
+		if len(spec.Position.Deg) < 2 {
+			continue
+		}
+		validTime, err := time.Parse(time.RFC3339, spec.ValidTime.JST)
+		if err != nil {
+			continue
+		}
+		typhoon.Track = append(typhoon.Track, TrackPoint{Time: validTime, Latitude: spec.Position.Deg[0], Longitude: spec.Position.Deg[1]})
+
+		if spec.Part.JP == "実況" && !hasAnalysis {
+			hasAnalysis = true
+			typhoon.ObservedAt = validTime
+			typhoon.Category = spec.Category.JP
+			typhoon.Intensity = string(spec.Intensity)
+			typhoon.Size = string(spec.Size)
+			typhoon.Location = string(spec.Location)
+			typhoon.Latitude = spec.Position.Deg[0]
+			typhoon.Longitude = spec.Position.Deg[1]
+			typhoon.Pressure = spec.Pressure.int()
+			typhoon.MaxWind = spec.MaximumWind.Sustained["m/s"].int()
+			typhoon.Course = string(spec.Course)
+			typhoon.SpeedKmh = spec.Speed["km/h"].int()
+		}
+	}
+
+	if !hasAnalysis {
+		return Typhoon{}, fmt.Errorf("台風情報に実況がありません: %s", typhoon.ID)
+	}
+	return typhoon, nil
+}
@@ -0,0 +1,131 @@
+package typhoon
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/city"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// ParseTargets のテスト
+func TestParseTargets(t *testing.T) {
+	ids, err := ParseTargets(readFixture(t, "targetTc.json"))
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "TC2610" || ids[1] != "TC2611" {
+		t.Errorf("期待: [TC2610 TC2611], 実際: %v", ids)
+	}
+
+	// 台風・熱帯低気圧がない場合は空の配列
+	ids, err = ParseTargets([]byte("[]"))
+	if err != nil || len(ids) != 0 {
+		t.Errorf("期待: 空, 実際: %v (%v)", ids, err)
+	}
+
+	if _, err := ParseTargets([]byte("{")); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// ParseSpecifications のテスト
+func TestParseSpecifications(t *testing.T) {
+	typhoon, err := ParseSpecifications(readFixture(t, "TC2610_specifications.json"))
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+
+	if typhoon.ID != "TC2610" || typhoon.Number != 10 || typhoon.Name != "ハギビス" || !typhoon.IsTyphoon() {
+		t.Errorf("台風の番号・名前が不正です: %+v", typhoon)
+	}
+	if typhoon.Category != "台風" || typhoon.Intensity != "強い" || typhoon.Size != "大型" || typhoon.Location != "八丈島の南" {
+		t.Errorf("台風の種別・強さ・大きさ・存在地域が不正です: %+v", typhoon)
+	}
+	if typhoon.Pressure != 965 || typhoon.MaxWind != 35 || typhoon.Course != "北北東" || typhoon.SpeedKmh != 25 {
+		t.Errorf("台風の勢力・進路が不正です: %+v", typhoon)
+	}
+	if typhoon.Latitude != 30.0 || typhoon.Longitude != 139.8 {
+		t.Errorf("中心位置: 期待=30.0,139.8, 実際=%v,%v", typhoon.Latitude, typhoon.Longitude)
+	}
+	if typhoon.ObservedAt.Format(time.RFC3339) != "2026-10-18T09:00:00+09:00" {
+		t.Errorf("実況の時刻: 期待=2026-10-18T09:00:00+09:00, 実際=%s", typhoon.ObservedAt.Format(time.RFC3339))
+	}
+	// 実況と予報 (数値で書かれた値を含む) の4点
+	if len(typhoon.Track) != 4 {
+		t.Errorf("進路の点数: 期待=4, 実際=%d", len(typhoon.Track))
+	}
+
+	// 熱帯低気圧は番号がない
+	depression, err := ParseSpecifications(readFixture(t, "TC2611_specifications.json"))
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	if depression.IsTyphoon() || depression.Category != "熱帯低気圧" || depression.MaxWind != 0 {
+		t.Errorf("熱帯低気圧が不正です: %+v", depression)
+	}
+
+	// 実況がない場合はエラー
+	if _, err := ParseSpecifications([]byte(`[{"tropicalCyclone": "TC2612"}]`)); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+	if _, err := ParseSpecifications([]byte("{")); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// ClosestApproach のテスト
+func TestClosestApproach(t *testing.T) {
+	typhoon, err := ParseSpecifications(readFixture(t, "TC2610_specifications.json"))
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		cityCode         string
+		expectedTime     string
+		expectedDistance float64
+	}{
+		// 予報進路 (33.0,139.6)→(36.0,141.0) の途中で東京に最も近づく
+		{"東京", "130010", "2026-10-19T06:30:00+09:00", 98},
+		// 温帯低気圧に変わる途中で最も近づく地点
+		{"釧路", "014020", "2026-10-20T02:30:00+09:00", 525},
+		// 実況の位置が最も近い地点
+		{"那覇", "471010", "2026-10-18T09:00:00+09:00", 1260},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin, ok := city.Lookup(tt.cityCode)
+			if !ok {
+				t.Fatalf("地点が見つかりません: %s", tt.cityCode)
+			}
+			approach, ok := typhoon.ClosestApproach(origin)
+			if !ok {
+				t.Fatal("期待: 最接近あり, 実際: なし")
+			}
+			if actual := approach.Time.Format(time.RFC3339); actual != tt.expectedTime {
+				t.Errorf("時刻: 期待=%s, 実際=%s", tt.expectedTime, actual)
+			}
+			if math.Abs(approach.DistanceKm-tt.expectedDistance) > 1 {
+				t.Errorf("距離: 期待=%.0fkm, 実際=%.1fkm", tt.expectedDistance, approach.DistanceKm)
+			}
+		})
+	}
+
+	// 予報進路がない場合は最接近を求めない
+	if _, ok := (Typhoon{Track: typhoon.Track[:1]}).ClosestApproach(city.Area{}); ok {
+		t.Error("期待: 最接近なし, 実際: あり")
+	}
+}
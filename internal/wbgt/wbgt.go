@@ -0,0 +1,125 @@
+// Package wbgt は暑さ指数 (WBGT) を扱う。
+// 環境省 熱中症予防情報サイトの暑さ指数の予測値 (CSV) のパースと、
+// 気温と湿度からの暑さ指数の推定、日常生活に関する指針の5段階の区分を行う。
+package wbgt
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ForecastURL は地点の暑さ指数の予測値 (CSV) のURLを返す
+// point はアメダスの観測所番号と共通の地点番号 (例: 44132)
+func ForecastURL(point string) string {
+	return "https://www.wbgt.env.go.jp/prev15WG/dl/yohou_" + point + ".csv"
+}
+
+// jst は予測値の時刻の表記に使う日本標準時
+var jst = time.FixedZone("JST", 9*60*60)
+
+// Level は日常生活に関する指針の暑さ指数の区分
+type Level int
+
+const (
+	LevelSafe      Level = iota + 1 // ほぼ安全 (21未満)
+	LevelCaution                    // 注意 (21以上25未満)
+	LevelWarning                    // 警戒 (25以上28未満)
+	LevelSevere                     // 厳重警戒 (28以上31未満)
+	LevelDangerous                  // 危険 (31以上)
+)
+
+// levelLabels は区分の表記 (Level の順)
+var levelLabels = [...]string{"", "ほぼ安全", "注意", "警戒", "厳重警戒", "危険"}
+
+// LevelOf は暑さ指数の区分を返す
+func LevelOf(value float64) Level {
+	switch {
+	case value >= 31:
+		return LevelDangerous
+	case value >= 28:
+		return LevelSevere
+	case value >= 25:
+		return LevelWarning
+	case value >= 21:
+		return LevelCaution
+	default:
+		return LevelSafe
+	}
+}
+
+// Label は区分の表記を返す (例: 厳重警戒)
+func (l Level) Label() string {
+	if l < LevelSafe || l > LevelDangerous {
+		return ""
+	}
+	return levelLabels[l]
+}
+
+// Forecast は暑さ指数の予測値
+type Forecast struct {
+	Time  time.Time
+	Value float64
+}
+
+// ParseForecast は暑さ指数の予測値のCSVをパースする
+// 1行目は予測の時刻 (YYYYMMDDHH)、2行目は地点番号・発表時刻に続けて暑さ指数を10倍した値が並ぶ
+func ParseForecast(body []byte) ([]Forecast, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("暑さ指数の予測値のパースに失敗しました: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("暑さ指数の予測値が含まれていません")
+	}
+
+	header, values := records[0], records[1]
+	var forecasts []Forecast
+	for i := 2; i < len(header) && i < len(values); i++ {
+		t, err := time.ParseInLocation("2006010215", strings.TrimSpace(header[i]), jst)
+		if err != nil {
+			continue
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(values[i]))
+		if err != nil {
+			// 予測値のない時刻は空欄
+			continue
+		}
+		forecasts = append(forecasts, Forecast{Time: t, Value: float64(value) / 10})
+	}
+	if len(forecasts) == 0 {
+		return nil, fmt.Errorf("暑さ指数の予測値が含まれていません")
+	}
+	return forecasts, nil
+}
+
+// DailyMax は指定した日 (t の地域の暦日) の予測値のうち最も高いものを返す
+// 同じ値の場合は早い時刻を返す。予測値がない場合は false を返す
+func DailyMax(forecasts []Forecast, t time.Time) (Forecast, bool) {
+	year, month, day := t.Date()
+	var max Forecast
+	found := false
+	for _, forecast := range forecasts {
+		y, m, d := forecast.Time.In(t.Location()).Date()
+		if y != year || m != month || d != day {
+			continue
+		}
+		if !found || forecast.Value > max.Value {
+			max, found = forecast, true
+		}
+	}
+	return max, found
+}
+
+// Estimate は気温 (℃) と相対湿度 (%) から暑さ指数を推定する
+// 日射の影響を含まない室内向けの推定式 (日本生気象学会) を使うため、屋外の日なたでは実際より低くなる
+func Estimate(temperature, humidity float64) float64 {
+	value := 0.725*temperature + 0.0368*humidity + 0.00364*temperature*humidity - 3.246
+	return math.Round(value*10) / 10
+}
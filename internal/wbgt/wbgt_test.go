@@ -0,0 +1,115 @@
+package wbgt
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// ParseForecast のテスト
+func TestParseForecast(t *testing.T) {
+	body, err := os.ReadFile("testdata/yohou_44132.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	forecasts, err := ParseForecast(body)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+	// 空欄の時刻は除く
+	if len(forecasts) != 15 {
+		t.Fatalf("予測値の数: 期待=15, 実際=%d", len(forecasts))
+	}
+	if forecasts[0].Time.Format(time.RFC3339) != "2026-07-19T03:00:00+09:00" || forecasts[0].Value != 25.5 {
+		t.Errorf("最初の予測値が不正です: %+v", forecasts[0])
+	}
+
+	if _, err := ParseForecast([]byte("<html>\n")); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+	if _, err := ParseForecast([]byte(",,2026071903\n44132,2026/07/19 02:00,\n")); err == nil {
+		t.Error("期待: エラー, 実際: nil")
+	}
+}
+
+// DailyMax のテスト
+func TestDailyMax(t *testing.T) {
+	body, err := os.ReadFile("testdata/yohou_44132.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	forecasts, err := ParseForecast(body)
+	if err != nil {
+		t.Fatalf("期待: エラーなし, 実際: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		day           time.Time
+		expectedTime  string
+		expectedValue float64
+		found         bool
+	}{
+		{"今日", time.Date(2026, 7, 19, 6, 0, 0, 0, jst), "2026-07-19T15:00:00+09:00", 31.8, true},
+		{"明日 (空欄の時刻を除く)", time.Date(2026, 7, 20, 0, 0, 0, 0, jst), "2026-07-20T12:00:00+09:00", 30.5, true},
+		{"予測値のない日", time.Date(2026, 7, 22, 0, 0, 0, 0, jst), "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			max, found := DailyMax(forecasts, tt.day)
+			if found != tt.found {
+				t.Fatalf("期待: %v, 実際: %v", tt.found, found)
+			}
+			if !found {
+				return
+			}
+			if max.Time.Format(time.RFC3339) != tt.expectedTime || max.Value != tt.expectedValue {
+				t.Errorf("期待: %s %.1f, 実際: %s %.1f", tt.expectedTime, tt.expectedValue, max.Time.Format(time.RFC3339), max.Value)
+			}
+		})
+	}
+}
+
+// LevelOf のテスト
+func TestLevelOf(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{18.0, "ほぼ安全"},
+		{20.9, "ほぼ安全"},
+		{21.0, "注意"},
+		{25.0, "警戒"},
+		{28.0, "厳重警戒"},
+		{30.9, "厳重警戒"},
+		{31.0, "危険"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if actual := LevelOf(tt.value).Label(); actual != tt.expected {
+				t.Errorf("%.1f: 期待=%s, 実際=%s", tt.value, tt.expected, actual)
+			}
+		})
+	}
+}
+
+// Estimate のテスト
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		temperature float64
+		humidity    float64
+		expected    float64
+	}{
+		{25, 50, 21.3},
+		{31, 60, 28.2},
+		{35, 70, 33.6},
+	}
+
+	for _, tt := range tests {
+		if actual := Estimate(tt.temperature, tt.humidity); actual != tt.expected {
+			t.Errorf("気温%.0f℃・湿度%.0f%%: 期待=%.1f, 実際=%.1f", tt.temperature, tt.humidity, tt.expected, actual)
+		}
+	}
+}
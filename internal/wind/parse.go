@@ -0,0 +1,190 @@
+package wind
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// strengthPhrases は風の強さの表現 (長い表現から順に調べる)
+var strengthPhrases = []struct {
+	phrase   string
+	strength Strength
+}{
+	{phrase: "非常に強く", strength: StrengthVeryStrong},
+	{phrase: "非常に強い", strength: StrengthVeryStrong},
+	{phrase: "やや強く", strength: StrengthSomewhatStrong},
+	{phrase: "やや強い", strength: StrengthSomewhatStrong},
+	{phrase: "猛烈な", strength: StrengthViolent},
+	{phrase: "猛烈に", strength: StrengthViolent},
+	{phrase: "強く", strength: StrengthStrong},
+	{phrase: "強い", strength: StrengthStrong},
+}
+
+// connectorPhrases はつながりの表現
+var connectorPhrases = []struct {
+	phrase    string
+	connector Connector
+}{
+	{phrase: "所により", connector: ConnectorLocally},
+	{phrase: "時々", connector: ConnectorOccasionally},
+	{phrase: "後", connector: ConnectorLater},
+}
+
+// calmPhrase は風向の定まらない弱い風の表現
+const calmPhrase = "風弱く"
+
+// exceptionMarker は一部の地域の風の始まりを示す表現 (例: 海上　では)
+const exceptionMarker = "では"
+
+// directionPhrases は「〜の風」の表現 (長い表現から順に調べる)
+var directionPhrases = func() []struct {
+	phrase    string
+	direction Direction
+} {
+	phrases := make([]struct {
+		phrase    string
+		direction Direction
+	}, len(directionLabels))
+	for i, label := range directionLabels {
+		phrases[i].phrase = label + "の風"
+		phrases[i].direction = Direction(i)
+	}
+	sort.SliceStable(phrases, func(i, j int) bool {
+		return len(phrases[i].phrase) > len(phrases[j].phrase)
+	})
+	return phrases
+}()
+
+// token は予報文を区切った1語
+type token struct {
+	text      string
+	kind      tokenKind
+	direction Direction
+	strength  Strength
+	connector Connector
+}
+
+type tokenKind int
+
+const (
+	tokenWord      tokenKind = iota // 地域・時間帯などのその他の語
+	tokenDirection                  // 〜の風
+	tokenCalm                       // 風弱く
+	tokenStrength                   // やや強く など
+	tokenConnector                  // 後・時々・所により
+	tokenException                  // では
+)
+
+// Parse は風の予報文を区切りに分解する
+// 読み取れない語は無視し、何も読み取れない場合は空の Forecast を返す
+func Parse(text string) Forecast {
+	var forecast Forecast
+	segments := &forecast.Segments
+	connector := ConnectorNone
+	when := ""
+	var pendingWord string
+
+	for _, tok := range tokenize(text) {
+		switch tok.kind {
+		case tokenDirection, tokenCalm:
+			if pendingWord != "" {
+				when = pendingWord
+				pendingWord = ""
+			}
+			direction := tok.direction
+			if tok.kind == tokenCalm {
+				direction = DirectionUnknown
+			}
+			*segments = append(*segments, Segment{Connector: connector, When: when, Direction: direction})
+			connector, when = ConnectorNone, ""
+		case tokenStrength:
+			if len(*segments) > 0 {
+				(*segments)[len(*segments)-1].Strength = tok.strength
+			}
+		case tokenConnector:
+			connector = tok.connector
+		case tokenException:
+			// 直前の語を地域とし、以降の区切りをその地域の風とする
+			forecast.Exceptions = append(forecast.Exceptions, Exception{Place: pendingWord})
+			segments = &forecast.Exceptions[len(forecast.Exceptions)-1].Segments
+			pendingWord, connector, when = "", ConnectorNone, ""
+		case tokenWord:
+			pendingWord = tok.text
+		}
+	}
+	return forecast
+}
+
+// tokenize は予報文を語に区切る
+// 予報文は全角空白で区切られていることが多いが、区切りがない場合も既知の表現で区切る
+func tokenize(text string) []token {
+	var tokens []token
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, token{text: word.String(), kind: tokenWord})
+			word.Reset()
+		}
+	}
+
+	rest := text
+	for rest != "" {
+		r, size := utf8.DecodeRuneInString(rest)
+		if unicode.IsSpace(r) {
+			flush()
+			rest = rest[size:]
+			continue
+		}
+		if tok, size, ok := matchPhrase(rest); ok {
+			flush()
+			tokens = append(tokens, tok)
+			rest = rest[size:]
+			continue
+		}
+		word.WriteRune(r)
+		rest = rest[size:]
+	}
+	flush()
+	return tokens
+}
+
+// matchPhrase は text の先頭が既知の表現かどうかを調べ、一致した語と長さ (バイト数) を返す
+func matchPhrase(text string) (token, int, bool) {
+	for _, p := range directionPhrases {
+		if strings.HasPrefix(text, p.phrase) {
+			return token{text: p.phrase, kind: tokenDirection, direction: p.direction}, len(p.phrase), true
+		}
+	}
+	if strings.HasPrefix(text, calmPhrase) {
+		return token{text: calmPhrase, kind: tokenCalm}, len(calmPhrase), true
+	}
+	for _, p := range strengthPhrases {
+		if strings.HasPrefix(text, p.phrase) {
+			return token{text: p.phrase, kind: tokenStrength, strength: p.strength}, len(p.phrase), true
+		}
+	}
+	for _, p := range connectorPhrases {
+		if strings.HasPrefix(text, p.phrase) && endsWord(text[len(p.phrase):]) {
+			return token{text: p.phrase, kind: tokenConnector, connector: p.connector}, len(p.phrase), true
+		}
+	}
+	if strings.HasPrefix(text, exceptionMarker) {
+		return token{text: exceptionMarker, kind: tokenException}, len(exceptionMarker), true
+	}
+	return token{}, 0, false
+}
+
+// endsWord は表現の直後 (rest) が語の区切りかどうかを返す
+// 「後志地方」のような地名の一部を、つながりの「後」と読み違えないために使う
+func endsWord(rest string) bool {
+	if rest == "" {
+		return true
+	}
+	if r, _ := utf8.DecodeRuneInString(rest); unicode.IsSpace(r) {
+		return true
+	}
+	_, _, ok := matchPhrase(rest)
+	return ok
+}
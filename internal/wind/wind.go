@@ -0,0 +1,173 @@
+// Package wind は天気予報の風の予報文 (例: 北の風　後　南の風　やや強く) を扱う。
+// 気象庁の予報文を風向 (16方位)・風の強さ・つながり (後・時々・所により) の区切りに分解し、
+// 表示用の矢印や体感温度の計算に使う代表的な風速を返す。
+package wind
+
+// Direction は風が吹いてくる方位 (16方位、北から時計回り)
+type Direction int
+
+const (
+	DirectionUnknown Direction = iota - 1 // 方位が分からない (例: 風弱く)
+	DirectionN
+	DirectionNNE
+	DirectionNE
+	DirectionENE
+	DirectionE
+	DirectionESE
+	DirectionSE
+	DirectionSSE
+	DirectionS
+	DirectionSSW
+	DirectionSW
+	DirectionWSW
+	DirectionW
+	DirectionWNW
+	DirectionNW
+	DirectionNNW
+)
+
+// directionLabels は16方位の表記 (DirectionN から順)
+var directionLabels = []string{
+	"北", "北北東", "北東", "東北東", "東", "東南東", "南東", "南南東",
+	"南", "南南西", "南西", "西南西", "西", "西北西", "北西", "北北西",
+}
+
+// arrows は8方位の風が吹いていく向きの矢印 (北の風は南へ吹くので ↓、北から時計回り)
+var arrows = []string{"↓", "↙", "←", "↖", "↑", "↗", "→", "↘"}
+
+// Label は方位の表記 (例: 北北東) を返す
+func (d Direction) Label() string {
+	if d < DirectionN || int(d) >= len(directionLabels) {
+		return ""
+	}
+	return directionLabels[d]
+}
+
+// Degrees は方位の角度 (北を0度として時計回り) を返す
+func (d Direction) Degrees() float64 {
+	return float64(d) * 22.5
+}
+
+// Arrow は風が吹いていく向きの矢印を返す
+// 矢印は8方位のため、北北東のような3文字の方位は最初の文字の方位 (北北東なら北) の矢印にする
+func (d Direction) Arrow() string {
+	if d < DirectionN || int(d) >= len(directionLabels) {
+		return ""
+	}
+	if d%2 == 0 {
+		return arrows[d/2]
+	}
+	// 前後の8方位のうち、東西南北の側に寄せる
+	if (d/2)%2 == 0 {
+		return arrows[d/2]
+	}
+	return arrows[(d/2+1)%8]
+}
+
+// Strength は風の強さ (気象庁の予報用語)
+type Strength int
+
+const (
+	StrengthNormal         Strength = iota // 強さの表現なし (10m/s未満)
+	StrengthSomewhatStrong                 // やや強く (10〜15m/s)
+	StrengthStrong                         // 強く (15〜20m/s)
+	StrengthVeryStrong                     // 非常に強く (20〜30m/s)
+	StrengthViolent                        // 猛烈な (30m/s以上)
+)
+
+// Label は風の強さの表示名を返す (強さの表現がない場合は空)
+func (s Strength) Label() string {
+	switch s {
+	case StrengthSomewhatStrong:
+		return "やや強い"
+	case StrengthStrong:
+		return "強い"
+	case StrengthVeryStrong:
+		return "非常に強い"
+	case StrengthViolent:
+		return "猛烈"
+	default:
+		return ""
+	}
+}
+
+// Speed は風の強さの代表的な風速 (m/s) を返す
+// 予報用語の風速の範囲の中央の値 (強さの表現がない場合は平均的な風として 3m/s)
+func (s Strength) Speed() float64 {
+	switch s {
+	case StrengthSomewhatStrong:
+		return 12.5
+	case StrengthStrong:
+		return 17.5
+	case StrengthVeryStrong:
+		return 25
+	case StrengthViolent:
+		return 30
+	default:
+		return 3
+	}
+}
+
+// Connector は前の区切りとのつながり
+type Connector int
+
+const (
+	ConnectorNone         Connector = iota // 最初の区切り
+	ConnectorLater                         // 後 (時間が経つと変わる)
+	ConnectorOccasionally                  // 時々
+	ConnectorLocally                       // 所により
+)
+
+// Label はつながりの表記を返す
+func (c Connector) Label() string {
+	switch c {
+	case ConnectorLater:
+		return "後"
+	case ConnectorOccasionally:
+		return "時々"
+	case ConnectorLocally:
+		return "所により"
+	default:
+		return ""
+	}
+}
+
+// Segment は風の予報文の1つの区切り (例: 後　南の風　やや強く)
+type Segment struct {
+	Connector Connector // 前の区切りとのつながり
+	When      string    // 時間帯の表現 (例: 日中、夜。なければ空)
+	Direction Direction
+	Strength  Strength
+}
+
+// Exception は「海上では」「三宅島では」のような一部の地域の風
+type Exception struct {
+	Place    string // 地域 (例: 海上)
+	Segments []Segment
+}
+
+// Forecast は風の予報文を分解した結果
+type Forecast struct {
+	Segments   []Segment   // 地域の指定のない (陸上の) 風
+	Exceptions []Exception // 一部の地域の風
+}
+
+// IsEmpty は区切りを1つも読み取れなかったかどうかを返す
+func (f Forecast) IsEmpty() bool {
+	return len(f.Segments) == 0 && len(f.Exceptions) == 0
+}
+
+// MaxStrength は地域の指定のない風のうち最も強い風の強さを返す
+// 区切りがない場合は false を返す
+func (f Forecast) MaxStrength() (Strength, bool) {
+	if len(f.Segments) == 0 {
+		return StrengthNormal, false
+	}
+	max := StrengthNormal
+	for _, segment := range f.Segments {
+		if segment.Strength > max {
+			max = segment.Strength
+		}
+	}
+	return max, true
+}
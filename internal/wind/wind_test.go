@@ -0,0 +1,139 @@
+package wind
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Parse のテスト
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected Forecast
+	}{
+		{
+			name:     "風向のみ",
+			text:     "北の風",
+			expected: Forecast{Segments: []Segment{{Direction: DirectionN}}},
+		},
+		{
+			name: "後で風向が変わる",
+			text: "北の風　後　南の風　やや強く",
+			expected: Forecast{Segments: []Segment{
+				{Direction: DirectionN},
+				{Connector: ConnectorLater, Direction: DirectionS, Strength: StrengthSomewhatStrong},
+			}},
+		},
+		{
+			name: "3文字の方位と強さ",
+			text: "北北西の風　強く　後　西北西の風　非常に強く",
+			expected: Forecast{Segments: []Segment{
+				{Direction: DirectionNNW, Strength: StrengthStrong},
+				{Connector: ConnectorLater, Direction: DirectionWNW, Strength: StrengthVeryStrong},
+			}},
+		},
+		{
+			name: "時々・所により",
+			text: "東の風　時々　北東の風　所により　南東の風",
+			expected: Forecast{Segments: []Segment{
+				{Direction: DirectionE},
+				{Connector: ConnectorOccasionally, Direction: DirectionNE},
+				{Connector: ConnectorLocally, Direction: DirectionSE},
+			}},
+		},
+		{
+			name: "時間帯の表現",
+			text: "北の風　日中　南の風",
+			expected: Forecast{Segments: []Segment{
+				{Direction: DirectionN},
+				{When: "日中", Direction: DirectionS},
+			}},
+		},
+		{
+			name: "海上と島の風",
+			text: "北の風　後　南の風　海上　では　南の風　やや強く　三宅島　では　南西の風　強く",
+			expected: Forecast{
+				Segments: []Segment{{Direction: DirectionN}, {Connector: ConnectorLater, Direction: DirectionS}},
+				Exceptions: []Exception{
+					{Place: "海上", Segments: []Segment{{Direction: DirectionS, Strength: StrengthSomewhatStrong}}},
+					{Place: "三宅島", Segments: []Segment{{Direction: DirectionSW, Strength: StrengthStrong}}},
+				},
+			},
+		},
+		{
+			name: "空白の区切りなし",
+			text: "西の風後北西の風やや強く",
+			expected: Forecast{Segments: []Segment{
+				{Direction: DirectionW},
+				{Connector: ConnectorLater, Direction: DirectionNW, Strength: StrengthSomewhatStrong},
+			}},
+		},
+		{
+			name: "地名の「後」はつながりにしない",
+			text: "南の風　後志地方　では　北の風",
+			expected: Forecast{
+				Segments:   []Segment{{Direction: DirectionS}},
+				Exceptions: []Exception{{Place: "後志地方", Segments: []Segment{{Direction: DirectionN}}}},
+			},
+		},
+		{
+			name:     "風弱く",
+			text:     "風弱く",
+			expected: Forecast{Segments: []Segment{{Direction: DirectionUnknown}}},
+		},
+		{name: "空文字", text: "", expected: Forecast{}},
+		{name: "読み取れない文", text: "波　1メートル", expected: Forecast{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := Parse(tt.text)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// Direction のテスト
+func TestDirection(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		label     string
+		arrow     string
+		degrees   float64
+	}{
+		{direction: DirectionN, label: "北", arrow: "↓", degrees: 0},
+		{direction: DirectionNNE, label: "北北東", arrow: "↓", degrees: 22.5},
+		{direction: DirectionENE, label: "東北東", arrow: "←", degrees: 67.5},
+		{direction: DirectionSE, label: "南東", arrow: "↖", degrees: 135},
+		{direction: DirectionSSW, label: "南南西", arrow: "↑", degrees: 202.5},
+		{direction: DirectionW, label: "西", arrow: "→", degrees: 270},
+		{direction: DirectionNNW, label: "北北西", arrow: "↓", degrees: 337.5},
+		{direction: DirectionUnknown, label: "", arrow: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			if tt.direction.Label() != tt.label || tt.direction.Arrow() != tt.arrow {
+				t.Errorf("期待: %s %s, 実際: %s %s", tt.arrow, tt.label, tt.direction.Arrow(), tt.direction.Label())
+			}
+			if tt.direction != DirectionUnknown && tt.direction.Degrees() != tt.degrees {
+				t.Errorf("角度: 期待=%v, 実際=%v", tt.degrees, tt.direction.Degrees())
+			}
+		})
+	}
+}
+
+// MaxStrength のテスト
+func TestMaxStrength(t *testing.T) {
+	// 海上の風は陸上の体感に関係しないため含めない
+	forecast := Parse("北の風　やや強く　後　南の風　海上　では　南の風　非常に強く")
+	if strength, ok := forecast.MaxStrength(); !ok || strength != StrengthSomewhatStrong || strength.Speed() != 12.5 {
+		t.Errorf("期待: やや強い (12.5m/s), 実際: %s (%v)", strength.Label(), ok)
+	}
+	if _, ok := Parse("").MaxStrength(); ok {
+		t.Error("期待: 風なし, 実際: あり")
+	}
+}
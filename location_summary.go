@@ -0,0 +1,92 @@
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+// LocationSummary は比較表示用の地点ごとの天気の要約
+type LocationSummary struct {
+	Location    string `json:"location"`    // 地点名
+	CityCode    string `json:"cityCode"`    // 都市コード
+	WeatherIcon string `json:"weatherIcon"` // 天気アイコン(絵文字)
+	WeatherCode string `json:"weatherCode"` // 正規化した天気コード (例: clear-cloudy)
+	Description string `json:"description"` // 天気概況
+	MaxTemp     int    `json:"maxTemp"`     // 最高気温
+	MinTemp     int    `json:"minTemp"`     // 最低気温
+	HasMaxTemp  bool   `json:"hasMaxTemp"`  // 最高気温データが有効かどうか
+	HasMinTemp  bool   `json:"hasMinTemp"`  // 最低気温データが有効かどうか
+	RainChance  string `json:"rainChance"`  // 降水確率(最大値)
+}
+
+// summarizeLocation は天気APIのレスポンスから今日の天気の要約を作成する
+func summarizeLocation(cityCode string, response TsukumijimaWeatherResponse) LocationSummary {
+	summary := LocationSummary{
+		Location: response.Location.City,
+		CityCode: cityCode,
+	}
+	if len(response.Forecasts) == 0 {
+		return summary
+	}
+
+	todayForecast := response.Forecasts[0]
+	summary.Description = todayForecast.Telop
+	summary.WeatherIcon = getWeatherIcon(todayForecast.Telop)
+	summary.WeatherCode = getWeatherCode(todayForecast.Telop)
+
+	if temp, err := parseTemperature(todayForecast.Temperature.Max.Celsius); err == nil {
+		summary.MaxTemp = temp
+		summary.HasMaxTemp = true
+	}
+	if temp, err := parseTemperature(todayForecast.Temperature.Min.Celsius); err == nil {
+		summary.MinTemp = temp
+		summary.HasMinTemp = true
+	}
+
+	summary.RainChance = getMaxRainChance([]string{
+		todayForecast.ChanceOfRain.T00_06,
+		todayForecast.ChanceOfRain.T06_12,
+		todayForecast.ChanceOfRain.T12_18,
+		todayForecast.ChanceOfRain.T18_24,
+	})
+
+	return summary
+}
+
+// fetchSecondaryLocations はカンマ区切りの都市コードそれぞれの天気の要約を取得する
+// 取得に失敗した地点は表示から除外する
+func fetchSecondaryLocations(cityCodes string) []LocationSummary {
+	var summaries []LocationSummary
+	for _, cityCode := range strings.Split(cityCodes, ",") {
+		cityCode = strings.TrimSpace(cityCode)
+		if cityCode == "" {
+			continue
+		}
+
+		response, err := fetchTsukumijimaForecast(cityCode)
+		if err != nil {
+			log.Printf("⚠️  地点 %s の天気データの取得に失敗しました: %v", cityCode, err)
+			continue
+		}
+		summaries = append(summaries, summarizeLocation(cityCode, response))
+	}
+	return summaries
+}
+
+// PrimarySummary はメインの地点の天気を比較表示用の要約として返す
+func (w *WeatherData) PrimarySummary() LocationSummary {
+	summary := LocationSummary{
+		Location:    w.Location,
+		WeatherIcon: w.WeatherIcon,
+		WeatherCode: w.WeatherCode,
+		Description: w.Description,
+		MaxTemp:     w.MaxTemp,
+		MinTemp:     w.MinTemp,
+		HasMaxTemp:  w.HasMaxTemp, // 今日の最高気温がない場合 MaxTemp は明日の値なので表示しない
+		HasMinTemp:  w.HasMinTemp,
+	}
+	if len(w.DailyForecasts) > 0 {
+		summary.RainChance = w.DailyForecasts[0].RainChance
+	}
+	return summary
+}
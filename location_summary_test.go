@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/forecast"
+)
+
+// summarizeLocation のテスト
+func TestSummarizeLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected LocationSummary
+	}{
+		{
+			name: "今日の気温と降水確率がある場合",
+			response: `{
+				"location": {"city": "大阪"},
+				"forecasts": [
+					{
+						"telop": "曇り",
+						"temperature": {"min": {"celsius": "18"}, "max": {"celsius": "24"}},
+						"chanceOfRain": {"T00_06": "10%", "T06_12": "30%", "T12_18": "50%", "T18_24": "20%"}
+					}
+				]
+			}`,
+			expected: LocationSummary{
+				Location:    "大阪",
+				CityCode:    "270000",
+				WeatherIcon: "☁️",
+				WeatherCode: "cloudy",
+				Description: "曇り",
+				MaxTemp:     24,
+				MinTemp:     18,
+				HasMaxTemp:  true,
+				HasMinTemp:  true,
+				RainChance:  "50%",
+			},
+		},
+		{
+			name: "今日の最低気温がnullの場合",
+			response: `{
+				"location": {"city": "東京"},
+				"forecasts": [
+					{
+						"telop": "晴れ",
+						"temperature": {"min": {"celsius": null}, "max": {"celsius": "28"}},
+						"chanceOfRain": {"T00_06": "--%", "T06_12": "0%", "T12_18": "10%", "T18_24": "10%"}
+					}
+				]
+			}`,
+			expected: LocationSummary{
+				Location:    "東京",
+				CityCode:    "270000",
+				WeatherIcon: "☀️",
+				WeatherCode: "clear",
+				Description: "晴れ",
+				MaxTemp:     28,
+				HasMaxTemp:  true,
+				HasMinTemp:  false,
+				RainChance:  "10%",
+			},
+		},
+		{
+			name:     "予報が空の場合",
+			response: `{"location": {"city": "札幌"}, "forecasts": []}`,
+			expected: LocationSummary{
+				Location: "札幌",
+				CityCode: "270000",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response TsukumijimaWeatherResponse
+			if err := json.Unmarshal([]byte(tt.response), &response); err != nil {
+				t.Fatalf("モックデータのUnmarshalに失敗: %v", err)
+			}
+
+			result := summarizeLocation("270000", response)
+			if result != tt.expected {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+// PrimarySummary のテスト
+func TestPrimarySummary(t *testing.T) {
+	data := &WeatherData{
+		Location:    "東京",
+		WeatherIcon: "☀️",
+		Description: "晴れ",
+		MaxTemp:     28,
+		HasMaxTemp:  true,
+		MinTemp:     18,
+		HasMinTemp:  true,
+		DailyForecasts: []DailyForecast{
+			{Date: "今日", RainChance: "20%"},
+		},
+	}
+
+	summary := data.PrimarySummary()
+	if summary.Location != "東京" || summary.MaxTemp != 28 || summary.MinTemp != 18 {
+		t.Errorf("要約の内容が不正です: %+v", summary)
+	}
+	if !summary.HasMaxTemp || !summary.HasMinTemp {
+		t.Errorf("気温の有効フラグが不正です: %+v", summary)
+	}
+	if summary.RainChance != "20%" {
+		t.Errorf("RainChance: 期待=20%%, 実際=%s", summary.RainChance)
+	}
+}
+
+// PrimarySummary のテスト (今日の最高気温が null)
+func TestPrimarySummaryWithoutTodayMaxTemp(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	f := forecast.Forecast{
+		Location: "東京",
+		Days: []forecast.Day{
+			{Date: "2026-10-18", Telop: "晴れ", MinTemp: 12, HasMinTemp: true},
+			{Date: "2026-10-19", Telop: "曇り", MaxTemp: 21, HasMaxTemp: true},
+		},
+	}
+	data := processWeatherData(f, time.Date(2026, 10, 18, 18, 0, 0, 0, jst))
+
+	// MaxTemp は明日の最高気温なので、比較表示では今日の最高気温を表示しない
+	summary := data.PrimarySummary()
+	if summary.HasMaxTemp {
+		t.Errorf("期待: 最高気温なし, 実際: %d℃", summary.MaxTemp)
+	}
+	if !summary.HasMinTemp || summary.MinTemp != 12 {
+		t.Errorf("最低気温: 期待=12℃, 実際=%d℃ (%v)", summary.MinTemp, summary.HasMinTemp)
+	}
+}
+
+// fetchSecondaryLocations のテスト
+func TestFetchSecondaryLocationsEmpty(t *testing.T) {
+	// 都市コードが指定されていない場合は通信せずに空を返す
+	if summaries := fetchSecondaryLocations(" , "); len(summaries) != 0 {
+		t.Errorf("期待: 0件, 実際: %d件", len(summaries))
+	}
+}
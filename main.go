@@ -1,18 +1,23 @@
 package main
 
 import (
-	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"html/template"
-	"io"
 	"log"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata" // 実行環境にタイムゾーンのデータベースがなくても TIMEZONE を読み込めるようにする
+
+	"kindle-tenki-dashboard/internal/calendar"
+	"kindle-tenki-dashboard/internal/city"
+	"kindle-tenki-dashboard/internal/fetch"
+	"kindle-tenki-dashboard/internal/forecast"
+	"kindle-tenki-dashboard/internal/telop"
+	"kindle-tenki-dashboard/internal/transit"
 )
 
 // 定数定義
@@ -21,43 +26,104 @@ const (
 	MaxNewsItems           = 5  // 主要ニュースの最大表示数
 	MaxEconomyNewsItems    = 10 // 経済ニュースの最大取得数(重複除外前)
 	HTTPClientTimeout      = 10 * time.Second
+	DefaultCacheDir        = ".cache"     // 取得したデータのキャッシュの保存先
+	DefaultTimeZone        = "Asia/Tokyo" // 地点のタイムゾーン (IANA のタイムゾーン名)
 )
 
+// fetcher は外部データの取得に使う (main でキャッシュの保存先を設定する)
+var fetcher = fetch.New("", HTTPClientTimeout)
+
+// fetchSource は外部データ (URL またはローカルファイル) を取得する
+// 取得に失敗して前回のキャッシュを使った場合はその旨をログに残す
+func fetchSource(label string, source string) ([]byte, error) {
+	result, err := fetchSourceResult(label, source)
+	if err != nil {
+		return nil, err
+	}
+	return result.Body, nil
+}
+
+// fetchSourceResult は fetchSource と同様に取得し、取得時刻やキャッシュを使ったかどうかも返す
+// 古いキャッシュを使ったことを画面に表示する場合に使う
+func fetchSourceResult(label string, source string) (fetch.Result, error) {
+	result, err := fetcher.Get(source)
+	if err != nil {
+		return fetch.Result{}, fmt.Errorf("%sの取得に失敗しました: %w", label, err)
+	}
+	if result.Stale {
+		log.Printf("⚠️  %sの取得に失敗しました: %v", label, result.Err)
+		log.Printf("   %s に取得したキャッシュを使用します", result.FetchedAt.Format("01/02 15:04"))
+	}
+	return result, nil
+}
+
 type WeatherData struct {
-	Location            string           `json:"location"`
-	Temperature         int              `json:"temperature"`
-	MinTemp             int              `json:"minTemp"`
-	MaxTemp             int              `json:"maxTemp"`
-	FeelsLike           int              `json:"feelsLike"`
-	Description         string           `json:"description"`
-	WeatherIcon         string           `json:"weatherIcon"`    // 天気アイコン(絵文字)
-	Wind                string           `json:"wind"`
-	ChanceOfRain        []string         `json:"chanceOfRain"` // 6時間ごとの降水確率
-	UpdateTime          string           `json:"updateTime"`
-	HourlyForecast      []HourlyForecast `json:"hourlyForecast"`
-	News                []NewsItem       `json:"news"`
-	EconomyNews         []NewsItem       `json:"economyNews"`        // 経済ニュース
-	DailyForecasts      []DailyForecast  `json:"dailyForecasts"`     // 3日間の予報
-	IsUsingFallbackData bool             `json:"isUsingFallbackData"` // フォールバックデータを使用しているか
-	HasMinTemp          bool             `json:"hasMinTemp"`          // 最低気温データが有効かどうか
+	Location            string              `json:"location"`
+	Temperature         int                 `json:"temperature"` // 今日の予想最高気温 (今日の値がない場合は明日。現在の気温は Observation)
+	MinTemp             int                 `json:"minTemp"`
+	MaxTemp             int                 `json:"maxTemp"`
+	FeelsLike           int                 `json:"feelsLike"`
+	FeelsLikeNote       string              `json:"feelsLikeNote"` // 体感温度が気温と異なる理由 (例: 風による冷え)
+	HasFeelsLike        bool                `json:"hasFeelsLike"`  // 体感温度を計算できたかどうか (気温データが有効)
+	Description         string              `json:"description"`
+	WeatherIcon         string              `json:"weatherIcon"` // 天気アイコン(絵文字)
+	WeatherCode         string              `json:"weatherCode"` // 正規化した天気コード (例: clear-cloudy)
+	Wind                string              `json:"wind"`
+	WindParts           []WindPart          `json:"windParts"`    // 風の予報文を風向・強さに分解した表示内容
+	ChanceOfRain        []string            `json:"chanceOfRain"` // 6時間ごとの降水確率
+	UpdatedAt           time.Time           `json:"updatedAt"`    // 更新時刻 (地点のタイムゾーン)
+	HourlyForecast      []HourlyForecast    `json:"hourlyForecast"`
+	News                []NewsItem          `json:"news"`
+	EconomyNews         []NewsItem          `json:"economyNews"`         // 経済ニュース
+	DailyForecasts      []DailyForecast     `json:"dailyForecasts"`      // 3日間の予報
+	SecondaryLocations  []LocationSummary   `json:"secondaryLocations"`  // 比較表示する他の地点
+	Calendar            CalendarHeader      `json:"calendar"`            // 日付・祝日
+	MonthCalendar       calendar.Month      `json:"monthCalendar"`       // 月間カレンダー
+	Agenda              []AgendaDay         `json:"agenda"`              // 今日・明日の予定
+	HasAgenda           bool                `json:"hasAgenda"`           // 予定の ICS が設定されているかどうか
+	Garbage             GarbageInfo         `json:"garbage"`             // 今日・明日のゴミ出し
+	Transit             TransitInfo         `json:"transit"`             // 電車の運行情報
+	Quake               QuakeInfo           `json:"quake"`               // 地震情報
+	Typhoon             TyphoonInfo         `json:"typhoon"`             // 台風情報
+	WBGT                WBGTInfo            `json:"wbgt"`                // 暑さ指数
+	Health              HealthInfo          `json:"health"`              // 紫外線・花粉
+	Pressure            PressureInfo        `json:"pressure"`            // 気圧・湿度
+	Observation         ObservationInfo     `json:"observation"`         // 現在の観測値 (アメダス)
+	History             HistoryInfo         `json:"history"`             // 過去の気温との比較
+	Daylight            []Daylight          `json:"daylight"`            // 時間別予報の期間の日の出・日の入り
+	IsUsingFallbackData bool                `json:"isUsingFallbackData"` // フォールバックデータを使用しているか
+	HasMaxTemp          bool                `json:"hasMaxTemp"`          // 今日の最高気温データが有効かどうか (false の場合 MaxTemp は明日の最高気温)
+	HasMinTemp          bool                `json:"hasMinTemp"`          // 最低気温データが有効かどうか
+	WeatherProviders    []string            `json:"weatherProviders"`    // 予報をまとめた取得元 (設定の順)
+	ForecastNote        string              `json:"forecastNote"`        // 一部の取得元の予報を取得できなかった場合の注記
+	Disagreement        string              `json:"disagreement"`        // 今日の予報が取得元の間で分かれている内容 (例: 最高20〜24℃)
+	ProviderForecasts   []forecast.Forecast `json:"-"`                   // 取得元ごとの予報 (天気の記録に使う)
 }
 
 type DailyForecast struct {
-	Date        string `json:"date"`        // 日付ラベル(今日/明日/明後日)
-	WeatherIcon string `json:"weatherIcon"` // 天気アイコン(絵文字)
-	Description string `json:"description"` // 天気概況
-	MaxTemp     int    `json:"maxTemp"`     // 最高気温
-	MinTemp     int    `json:"minTemp"`     // 最低気温
-	RainChance  string `json:"rainChance"`  // 降水確率(最大値)
+	Date          string `json:"date"`          // 日付ラベル(今日/明日/明後日)
+	WeatherIcon   string `json:"weatherIcon"`   // 天気アイコン(絵文字)
+	WeatherCode   string `json:"weatherCode"`   // 正規化した天気コード (例: clear-cloudy)
+	Description   string `json:"description"`   // 天気概況
+	MaxTemp       int    `json:"maxTemp"`       // 最高気温
+	HasMaxTemp    bool   `json:"hasMaxTemp"`    // 最高気温データが有効かどうか
+	MinTemp       int    `json:"minTemp"`       // 最低気温
+	HasMinTemp    bool   `json:"hasMinTemp"`    // 最低気温データが有効かどうか
+	RainChance    string `json:"rainChance"`    // 降水確率(最大値)
+	HasRainChance bool   `json:"hasRainChance"` // 降水確率データが1つ以上あるかどうか
+	Disagreement  string `json:"disagreement"`  // 予報が取得元の間で分かれている内容 (例: 降水30〜70%)
 }
 
 type HourlyForecast struct {
-	Time        string `json:"time"`
-	Temp        int    `json:"temp"`
-	Desc        string `json:"desc"`
-	WeatherIcon string `json:"weatherIcon"` // 天気アイコン(絵文字)
-	RainChance  string `json:"rainChance"`  // 降水確率
-	ChartHeight int    `json:"chartHeight"` // グラフ表示用の高さ(%)
+	At             time.Time `json:"at"`      // 予報の時刻 (地点のタイムゾーン)
+	IsNight        bool      `json:"isNight"` // 日の入りから日の出までの時刻かどうか
+	Temp           int       `json:"temp"`
+	Desc           string    `json:"desc"`
+	WeatherIcon    string    `json:"weatherIcon"`    // 天気アイコン(絵文字)
+	WeatherCode    string    `json:"weatherCode"`    // 正規化した天気コード (例: clear-cloudy)
+	RainChance     string    `json:"rainChance"`     // 降水確率
+	RainPercent    int       `json:"rainPercent"`    // 降水確率(%)
+	HasRainPercent bool      `json:"hasRainPercent"` // 降水確率データが有効かどうか
 }
 
 type NewsItem struct {
@@ -70,10 +136,10 @@ type NewsItem struct {
 type NHKNewsRSS struct {
 	XMLName xml.Name `xml:"rss"`
 	Channel struct {
-		Title       string     `xml:"title"`
-		Description string     `xml:"description"`
-		Link        string     `xml:"link"`
-		Items       []RSSItem  `xml:"item"`
+		Title       string    `xml:"title"`
+		Description string    `xml:"description"`
+		Link        string    `xml:"link"`
+		Items       []RSSItem `xml:"item"`
 	} `xml:"channel"`
 }
 
@@ -133,81 +199,36 @@ func getEnv(key, defaultValue string) string {
 }
 
 // getWeatherIcon は天気の説明文から絵文字アイコンを返す
+// 「晴時々曇」のような説明文を主な天気と副の天気に分解し、その組み合わせで選ぶ
 func getWeatherIcon(description string) string {
-	// 天気の説明文に基づいて絵文字を返す
-	switch {
-	case containsAny(description, []string{"晴", "快晴"}):
-		return "☀️"
-	case containsAny(description, []string{"曇", "くもり"}):
-		return "☁️"
-	case containsAny(description, []string{"雨", "雨天", "大雨", "豪雨"}):
-		return "☔"
-	case containsAny(description, []string{"雪", "大雪"}):
-		return "⛄"
-	case containsAny(description, []string{"雷", "雷雨"}):
-		return "⚡"
-	case containsAny(description, []string{"霧"}):
-		return "🌫️"
-	case containsAny(description, []string{"晴れ時々曇り", "晴れのち曇り", "晴時々曇"}):
-		return "🌤️"
-	case containsAny(description, []string{"曇り時々晴れ", "曇りのち晴れ", "曇時々晴"}):
-		return "⛅"
-	case containsAny(description, []string{"曇り時々雨", "曇りのち雨", "曇時々雨"}):
-		return "🌧️"
-	default:
-		return "🌡️"
-	}
+	return telop.Parse(description).Emoji()
 }
 
-// containsAny は文字列に指定されたいずれかの部分文字列が含まれるかチェックする
-func containsAny(s string, substrs []string) bool {
-	for _, substr := range substrs {
-		if strings.Contains(s, substr) {
-			return true
-		}
-	}
-	return false
+// getNightWeatherIcon は天気の説明文から夜の絵文字アイコンを返す
+// 太陽を含む絵文字は月や雲の絵文字にする
+func getNightWeatherIcon(description string) string {
+	return telop.Parse(description).NightEmoji()
 }
 
-func fetchWeatherData() (*WeatherData, error) {
-	cityCode := getEnv("CITY_CODE", "130010") // 東京のデフォルト
-	weatherURL := fmt.Sprintf("https://weather.tsukumijima.net/api/forecast/city/%s", cityCode)
-
-	// HTTPクライアントにタイムアウトを設定
-	client := &http.Client{
-		Timeout: HTTPClientTimeout,
-	}
-
-	// 天気データを取得
-	resp, err := client.Get(weatherURL)
-	if err != nil {
-		log.Printf("⚠️  天気APIの取得に失敗しました: %v", err)
-		log.Println("   サンプルデータを使用します")
-		return getSampleData()
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("⚠️  天気API Error: %d", resp.StatusCode)
-		log.Println("   サンプルデータを使用します")
-		return getSampleData()
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("⚠️  天気データの読み込みに失敗しました: %v", err)
-		log.Println("   サンプルデータを使用します")
-		return getSampleData()
-	}
+// getWeatherCode は天気の説明文から正規化した天気コード (例: clear-cloudy) を返す
+// SVGアイコンの選択に使う
+func getWeatherCode(description string) string {
+	return telop.Parse(description).Code()
+}
 
-	var weatherResponse TsukumijimaWeatherResponse
-	if err := json.Unmarshal(body, &weatherResponse); err != nil {
-		log.Printf("⚠️  天気データのパースに失敗しました: %v", err)
+// fetchWeatherData は天気予報とニュースを取得する
+// 天気予報は取得元ごとに取得してまとめ、いずれの取得元からも取得できない場合はサンプルデータを使う
+// now は地点のタイムゾーンの現在時刻
+func fetchWeatherData(providers []forecast.Provider, now time.Time) (*WeatherData, error) {
+	forecasts, failed := fetchForecasts(providers, now)
+	if len(forecasts) == 0 {
 		log.Println("   サンプルデータを使用します")
-		return getSampleData()
+		return getSampleData(now)
 	}
 
-	weatherData := processWeatherData(weatherResponse)
+	fused := forecast.Fuse(forecasts)
+	weatherData := processWeatherData(fused.Forecast, now)
+	applyForecastSources(weatherData, fused, forecasts, failed)
 
 	// ニュースデータを取得して追加
 	news, err := fetchNewsData()
@@ -230,254 +251,227 @@ func fetchWeatherData() (*WeatherData, error) {
 		weatherData.EconomyNews = filterDuplicateNews(economyNews, weatherData.News)
 	}
 
+	// 比較表示する他の地点の天気を取得して追加
+	weatherData.SecondaryLocations = fetchSecondaryLocations(os.Getenv("SECONDARY_CITY_CODES"))
+
 	return weatherData, nil
 }
 
-func processWeatherData(response TsukumijimaWeatherResponse) *WeatherData {
-	now := time.Now()
+// fetchTsukumijimaForecast は指定された都市コードの天気予報を取得する
+func fetchTsukumijimaForecast(cityCode string) (TsukumijimaWeatherResponse, error) {
+	provider := tsukumijimaProvider{cityCode: cityCode}
+	body, err := fetchSource("天気API", provider.URL())
+	if err != nil {
+		return TsukumijimaWeatherResponse{}, err
+	}
+	return parseTsukumijimaResponse(body, cityCode)
+}
 
+// processWeatherData は日ごとの予報を表示用のデータにする
+// 先頭の日を今日の予報とし (過ぎた日は fetchForecasts で除く)、now は地点のタイムゾーンの現在時刻で、時間別予報の時刻と更新時刻に使う
+func processWeatherData(f forecast.Forecast, now time.Time) *WeatherData {
 	// 今日の天気情報（最初の予報データを使用）
-	var todayForecast = response.Forecasts[0]
+	var today forecast.Day
+	if len(f.Days) > 0 {
+		today = f.Days[0]
+	}
 
-	// 温度の処理（文字列から数値に変換）
+	// 温度の処理
 	// 今日のデータがnullの場合は明日のデータを使用
 	temperature := 0
 	minTemp := 0
 	maxTemp := 0
-	feelsLike := 0
+	hasTemperature := false
+	hasMaxTemp := false
 	hasMinTemp := false
 
-	if todayForecast.Temperature.Max.Celsius != "" {
-		if temp, err := parseTemperature(todayForecast.Temperature.Max.Celsius); err == nil {
-			temperature = temp
-			maxTemp = temp
-			feelsLike = temp // 体感温度は最高気温で代用
-		}
-	} else if len(response.Forecasts) >= 2 && response.Forecasts[1].Temperature.Max.Celsius != "" {
+	if today.HasMaxTemp {
+		temperature = today.MaxTemp
+		maxTemp = today.MaxTemp
+		hasTemperature = true
+		hasMaxTemp = true
+	} else if len(f.Days) >= 2 && f.Days[1].HasMaxTemp {
 		// 今日のデータがない場合は明日の最高気温を使用
-		if temp, err := parseTemperature(response.Forecasts[1].Temperature.Max.Celsius); err == nil {
-			temperature = temp
-			maxTemp = temp
-			feelsLike = temp
-		}
+		temperature = f.Days[1].MaxTemp
+		maxTemp = f.Days[1].MaxTemp
+		hasTemperature = true
 	}
 
-	if todayForecast.Temperature.Min.Celsius != "" {
-		if temp, err := parseTemperature(todayForecast.Temperature.Min.Celsius); err == nil {
-			minTemp = temp
-			hasMinTemp = true // 最低気温データが有効
-		}
+	if today.HasMinTemp {
+		minTemp = today.MinTemp
+		hasMinTemp = true // 最低気温データが有効
 	}
 
 	// 風の情報
-	wind := todayForecast.Detail.Wind
+	wind := today.Wind
 
-	// 降水確率（6時間ごと）
-	chanceOfRain := []string{
-		todayForecast.ChanceOfRain.T06_12,
-		todayForecast.ChanceOfRain.T12_18,
-		todayForecast.ChanceOfRain.T18_24,
+	// 体感温度 (湿度は天気APIにないため、気圧・湿度の予報を取得した後に計算し直す)
+	feelsLike, feelsLikeNote := 0, ""
+	if hasTemperature {
+		feelsLike, feelsLikeNote = feelsLikeTemperature(temperature, wind, 0, false)
 	}
 
-	// 時間別予報を生成（現在時刻以降の予報のみ表示）
-	var hourlyForecast []HourlyForecast
-	currentHour := now.Hour()
-
-	if len(response.Forecasts) >= 2 {
-		tomorrowForecast := response.Forecasts[1]
-		var tomorrowMinTemp, tomorrowMaxTemp int
-		if tomorrowForecast.Temperature.Min.Celsius != "" {
-			if minTemp, err := parseTemperature(tomorrowForecast.Temperature.Min.Celsius); err == nil {
-				tomorrowMinTemp = minTemp
-			}
-		}
-		if tomorrowForecast.Temperature.Max.Celsius != "" {
-			if maxTemp, err := parseTemperature(tomorrowForecast.Temperature.Max.Celsius); err == nil {
-				tomorrowMaxTemp = maxTemp
-			}
-		}
-
-		// 予報時刻のスロット（3時間ごと、48時間後まで）
-		var forecastTimes []struct {
-			hour  int
-			label string
-		}
-
-		// 現在時刻から48時間後までの3時間ごとのスロットを生成
-		for h := 0; h <= 72; h += 3 {
-			hourInDay := h % 24
-			forecastTimes = append(forecastTimes, struct {
-				hour  int
-				label string
-			}{
-				hour:  h,
-				label: fmt.Sprintf("%02d:00", hourInDay),
-			})
-		}
-
-		for _, ft := range forecastTimes {
-			// 現在時刻以降の予報のみ追加
-			if ft.hour > currentHour {
-				var temp int
-				var desc string
-				var rainChance string
-
-				// 24時以降は明日の予報
-				if ft.hour >= 24 {
-					// 明日の予報：時間帯によって気温を調整
-					hourInDay := ft.hour % 24
-					if hourInDay >= 0 && hourInDay < 6 {
-						temp = tomorrowMinTemp
-						rainChance = tomorrowForecast.ChanceOfRain.T00_06
-					} else if hourInDay >= 6 && hourInDay < 12 {
-						temp = tomorrowMaxTemp
-						rainChance = tomorrowForecast.ChanceOfRain.T06_12
-					} else if hourInDay >= 12 && hourInDay < 18 {
-						temp = tomorrowMaxTemp - 2
-						rainChance = tomorrowForecast.ChanceOfRain.T12_18
-					} else {
-						temp = tomorrowMinTemp + 2
-						rainChance = tomorrowForecast.ChanceOfRain.T18_24
-					}
-					desc = tomorrowForecast.Telop
-				} else {
-					// 今日の予報
-					hourInDay := ft.hour
-					// 時間帯によって気温と降水確率を調整
-					if hourInDay >= 0 && hourInDay < 6 {
-						temp = temperature - 4
-						rainChance = todayForecast.ChanceOfRain.T00_06
-					} else if hourInDay >= 6 && hourInDay < 12 {
-						temp = temperature
-						rainChance = todayForecast.ChanceOfRain.T06_12
-					} else if hourInDay >= 12 && hourInDay < 18 {
-						temp = temperature
-						rainChance = todayForecast.ChanceOfRain.T12_18
-					} else {
-						temp = temperature - 2
-						rainChance = todayForecast.ChanceOfRain.T18_24
-					}
-					desc = todayForecast.Telop
-				}
-
-				hourlyForecast = append(hourlyForecast, HourlyForecast{
-					Time:        ft.label,
-					Temp:        temp,
-					Desc:        desc,
-					WeatherIcon: getWeatherIcon(desc),
-					RainChance:  rainChance,
-				})
-
-				// 48時間後まで（最大件数）
-				if len(hourlyForecast) >= MaxHourlyForecastItems {
-					break
-				}
-			}
-		}
+	// 降水確率（6時間ごと）
+	chanceOfRain := []string{
+		formatRainChance(today.RainChances[1]),
+		formatRainChance(today.RainChances[2]),
+		formatRainChance(today.RainChances[3]),
 	}
 
-	// グラフ表示用の高さを計算
-	if len(hourlyForecast) > 0 {
-		minTemp := hourlyForecast[0].Temp
-		maxTemp := hourlyForecast[0].Temp
-		for _, hf := range hourlyForecast {
-			if hf.Temp < minTemp {
-				minTemp = hf.Temp
-			}
-			if hf.Temp > maxTemp {
-				maxTemp = hf.Temp
-			}
-		}
-
-		// SVGのY座標系に合わせて計算 (上が小さい値、下が大きい値)
-		// 最高気温を上部(y=20)、最低気温を下部(y=75)に配置
-		tempRange := maxTemp - minTemp
-		if tempRange == 0 {
-			// 全て同じ気温の場合は中央に配置
-			for i := range hourlyForecast {
-				hourlyForecast[i].ChartHeight = 47 // (75 + 20) / 2
-			}
-		} else {
-			for i := range hourlyForecast {
-				// 最低気温 → heightPercent=75(下部), 最高気温 → heightPercent=20(上部)
-				// Y座標は上が小さいので、温度が高いほど小さいY値にする
-				heightPercent := 75 - ((hourlyForecast[i].Temp-minTemp)*55)/tempRange
-				hourlyForecast[i].ChartHeight = heightPercent
-			}
-		}
-	}
+	// 時間別予報を生成（現在時刻より後の予報のみ表示）
+	hourlyForecast := buildHourlyForecast(f.Days, temperature, now)
 
 	// 3日間の予報を生成
 	var dailyForecasts []DailyForecast
 	dateLabels := []string{"今日", "明日", "明後日"}
-	for i := 0; i < 3 && i < len(response.Forecasts); i++ {
-		forecast := response.Forecasts[i]
-
-		// 最高気温と最低気温を取得
-		var dailyMaxTemp, dailyMinTemp int
-		if forecast.Temperature.Max.Celsius != "" {
-			if temp, err := parseTemperature(forecast.Temperature.Max.Celsius); err == nil {
-				dailyMaxTemp = temp
-			}
-		}
-		if forecast.Temperature.Min.Celsius != "" {
-			if temp, err := parseTemperature(forecast.Temperature.Min.Celsius); err == nil {
-				dailyMinTemp = temp
-			}
-		}
+	for i := 0; i < 3 && i < len(f.Days); i++ {
+		day := f.Days[i]
 
 		// 降水確率の最大値を取得
-		rainChances := []string{
-			forecast.ChanceOfRain.T00_06,
-			forecast.ChanceOfRain.T06_12,
-			forecast.ChanceOfRain.T12_18,
-			forecast.ChanceOfRain.T18_24,
-		}
-		maxRainChance := "0%"
-		maxPercent := 0
-		for _, rc := range rainChances {
-			if rc != "" && rc != "-" {
-				// %を除去して数値として比較
-				percentStr := rc
-				if len(rc) > 0 && rc[len(rc)-1] == '%' {
-					percentStr = rc[:len(rc)-1]
-				}
-				currentPercent, err := strconv.Atoi(percentStr)
-				if err == nil && currentPercent > maxPercent {
-					maxPercent = currentPercent
-					maxRainChance = rc
-				}
-			}
+		var rainChances []string
+		for _, rainChance := range day.RainChances {
+			rainChances = append(rainChances, formatRainChance(rainChance))
 		}
+		_, hasRainChance := day.MaxRainChance()
 
 		dailyForecasts = append(dailyForecasts, DailyForecast{
-			Date:        dateLabels[i],
-			WeatherIcon: getWeatherIcon(forecast.Telop),
-			Description: forecast.Telop,
-			MaxTemp:     dailyMaxTemp,
-			MinTemp:     dailyMinTemp,
-			RainChance:  maxRainChance,
+			Date:          dateLabels[i],
+			WeatherIcon:   getWeatherIcon(day.Telop),
+			WeatherCode:   getWeatherCode(day.Telop),
+			Description:   day.Telop,
+			MaxTemp:       day.MaxTemp,
+			HasMaxTemp:    day.HasMaxTemp,
+			MinTemp:       day.MinTemp,
+			HasMinTemp:    day.HasMinTemp,
+			RainChance:    getMaxRainChance(rainChances),
+			HasRainChance: hasRainChance,
 		})
 	}
 
 	return &WeatherData{
-		Location:       response.Location.City,
+		Location:       f.Location,
 		Temperature:    temperature,
 		MinTemp:        minTemp,
 		MaxTemp:        maxTemp,
 		FeelsLike:      feelsLike,
-		Description:    todayForecast.Telop,
-		WeatherIcon:    getWeatherIcon(todayForecast.Telop),
+		FeelsLikeNote:  feelsLikeNote,
+		HasFeelsLike:   hasTemperature,
+		Description:    today.Telop,
+		WeatherIcon:    getWeatherIcon(today.Telop),
+		WeatherCode:    getWeatherCode(today.Telop),
 		Wind:           wind,
+		WindParts:      buildWindParts(wind),
 		ChanceOfRain:   chanceOfRain,
-		UpdateTime:     now.Format("2006/01/02 15:04"),
+		UpdatedAt:      now,
 		HourlyForecast: hourlyForecast,
 		News:           []NewsItem{}, // 後で設定
 		DailyForecasts: dailyForecasts,
+		HasMaxTemp:     hasMaxTemp,
 		HasMinTemp:     hasMinTemp,
 	}
 }
 
+// buildHourlyForecast は日ごとの予報から3時間ごとの時間別予報を生成する (現在時刻より後の時刻のみ)
+// 各時刻は now のタイムゾーンの暦の日付・時刻で作り、その日付の予報 (Day.Date) を使う
+// 予報の日付が今日と一致しない場合 (日付のない場合を含む) は、最初の予報を今日の予報とみなす
+// 今日の気温は temperature (今日の最高気温) から、明日以降はその日の最高・最低気温から時間帯で推定する
+func buildHourlyForecast(days []forecast.Day, temperature int, now time.Time) []HourlyForecast {
+	todayIndex := 0
+	for i, day := range days {
+		if day.Date == now.Format("2006-01-02") {
+			todayIndex = i
+			break
+		}
+	}
+
+	var hourlyForecast []HourlyForecast
+	for offset := 0; todayIndex+offset < len(days); offset++ {
+		day := days[todayIndex+offset]
+		dayMinTemp, dayMaxTemp := day.MinTemp, day.MaxTemp
+
+		for hour := 0; hour < 24; hour += 3 {
+			// 夏時間のある地域でも暦の時刻になるよう、時刻は日付と時から作る
+			at := time.Date(now.Year(), now.Month(), now.Day()+offset, hour, 0, 0, 0, now.Location())
+			if !at.After(now) {
+				continue
+			}
+
+			// 時間帯によって気温と降水確率を調整
+			var temp int
+			rainChance := day.RainChances[hour/(24/forecast.RainSlots)]
+			if offset == 0 {
+				switch {
+				case hour < 6:
+					temp = temperature - 4
+				case hour < 18:
+					temp = temperature
+				default:
+					temp = temperature - 2
+				}
+			} else {
+				switch {
+				case hour < 6:
+					temp = dayMinTemp
+				case hour < 12:
+					temp = dayMaxTemp
+				case hour < 18:
+					temp = dayMaxTemp - 2
+				default:
+					temp = dayMinTemp + 2
+				}
+			}
+
+			hourlyForecast = append(hourlyForecast, HourlyForecast{
+				At:             at,
+				Temp:           temp,
+				Desc:           day.Telop,
+				WeatherIcon:    getWeatherIcon(day.Telop),
+				WeatherCode:    getWeatherCode(day.Telop),
+				RainChance:     formatRainChance(rainChance),
+				RainPercent:    rainChance.Percent,
+				HasRainPercent: rainChance.Has,
+			})
+
+			// 最大件数まで
+			if len(hourlyForecast) >= MaxHourlyForecastItems {
+				return hourlyForecast
+			}
+		}
+	}
+	return hourlyForecast
+}
+
+// getMaxRainChance は時間帯ごとの降水確率から最大値を返す
+func getMaxRainChance(rainChances []string) string {
+	maxRainChance := "0%"
+	maxPercent := 0
+	for _, rc := range rainChances {
+		currentPercent, err := parseRainChance(rc)
+		if err == nil && currentPercent > maxPercent {
+			maxPercent = currentPercent
+			maxRainChance = rc
+		}
+	}
+	return maxRainChance
+}
+
+// parseRainChance は "30%" 形式の降水確率を数値に変換する
+// 発表のない時間帯 ("--%", "-", 空文字列) はエラーを返す
+func parseRainChance(rainChance string) (int, error) {
+	percentStr := strings.TrimSuffix(strings.TrimSpace(rainChance), "%")
+	if percentStr == "" || strings.Trim(percentStr, "-") == "" {
+		return 0, fmt.Errorf("empty rain chance")
+	}
+	percent, err := strconv.Atoi(percentStr)
+	if err != nil {
+		return 0, err
+	}
+	if percent < 0 || percent > 100 {
+		return 0, fmt.Errorf("rain chance out of range: %d", percent)
+	}
+	return percent, nil
+}
+
 func parseTemperature(tempStr string) (int, error) {
 	if tempStr == "" || tempStr == "null" {
 		return 0, fmt.Errorf("empty temperature")
@@ -489,18 +483,24 @@ func parseTemperature(tempStr string) (int, error) {
 	return temp, nil
 }
 
-func getSampleData() (*WeatherData, error) {
+// getSampleData は天気データを取得できない場合のサンプルデータを返す
+// 時間別予報は now の日付の12時から21時まで
+func getSampleData(now time.Time) (*WeatherData, error) {
+	at := func(hour int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	}
 	return &WeatherData{
-		Location:            "東京",
-		Temperature:         22,
-		FeelsLike:           25,
-		Description:         "晴れ",
-		UpdateTime:          time.Now().Format("2006/01/02 15:04"),
+		Location:     "東京",
+		Temperature:  22,
+		FeelsLike:    25,
+		HasFeelsLike: true,
+		Description:  "晴れ",
+		UpdatedAt:    now,
 		HourlyForecast: []HourlyForecast{
-			{Time: "12:00", Temp: 23, Desc: "晴れ"},
-			{Time: "15:00", Temp: 25, Desc: "晴れ"},
-			{Time: "18:00", Temp: 21, Desc: "曇り"},
-			{Time: "21:00", Temp: 19, Desc: "曇り"},
+			{At: at(12), Temp: 23, Desc: "晴れ"},
+			{At: at(15), Temp: 25, Desc: "晴れ"},
+			{At: at(18), Temp: 21, Desc: "曇り"},
+			{At: at(21), Temp: 19, Desc: "曇り"},
 		},
 		News:                getSampleNews(),
 		IsUsingFallbackData: true, // フォールバックデータを使用していることを示す
@@ -510,24 +510,9 @@ func getSampleData() (*WeatherData, error) {
 func fetchNewsData() ([]NewsItem, error) {
 	url := "https://www3.nhk.or.jp/rss/news/cat0.xml"
 
-	// HTTPクライアントにタイムアウトを設定
-	client := &http.Client{
-		Timeout: HTTPClientTimeout,
-	}
-
-	resp, err := client.Get(url)
+	body, err := fetchSource("ニュースRSS", url)
 	if err != nil {
-		return nil, fmt.Errorf("ニュースRSSの取得に失敗しました: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("ニュースRSS API Error: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("ニュースRSSの読み込みに失敗しました: %w", err)
+		return nil, err
 	}
 
 	var rss NHKNewsRSS
@@ -566,24 +551,9 @@ func fetchNewsData() ([]NewsItem, error) {
 func fetchEconomyNewsData() ([]NewsItem, error) {
 	url := "https://www3.nhk.or.jp/rss/news/cat5.xml" // 経済ニュースRSS
 
-	// HTTPクライアントにタイムアウトを設定
-	client := &http.Client{
-		Timeout: HTTPClientTimeout,
-	}
-
-	resp, err := client.Get(url)
+	body, err := fetchSource("経済ニュースRSS", url)
 	if err != nil {
-		return nil, fmt.Errorf("経済ニュースRSSの取得に失敗しました: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("経済ニュースRSS API Error: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("経済ニュースRSSの読み込みに失敗しました: %w", err)
+		return nil, err
 	}
 
 	var rss NHKNewsRSS
@@ -663,7 +633,14 @@ func getSampleNews() []NewsItem {
 	}
 }
 
-func generateHTML(data *WeatherData) error {
+// PageData はテンプレートに渡す1ページ分のデータ
+type PageData struct {
+	*WeatherData
+	Device     DeviceProfile // 表示する端末のプロファイル
+	StylesPath string        // CSSファイルへの相対パス
+}
+
+func generateHTML(data *WeatherData, devices []DeviceProfile) error {
 	// テンプレートファイルを読み込み
 	templatePath := filepath.Join("src", "templates", "index.html")
 	tmplContent, err := os.ReadFile(templatePath)
@@ -671,31 +648,36 @@ func generateHTML(data *WeatherData) error {
 		return fmt.Errorf("テンプレートファイルの読み込みに失敗しました: %w", err)
 	}
 
-	// Go のhtml/template でパース（算術関数を追加）
-	tmpl, err := template.New("index").Funcs(template.FuncMap{
-		"mul": func(a, b int) int { return a * b },
-		"sub": func(a, b int) int { return a - b },
-	}).Parse(string(tmplContent))
+	// Go のhtml/template でパース
+	tmpl, err := template.New("index").Funcs(templateFuncs).Parse(string(tmplContent))
 	if err != nil {
 		return fmt.Errorf("テンプレートのパースに失敗しました: %w", err)
 	}
 
-	// distディレクトリを作成
+	// 端末を特定しない汎用ページを生成
 	distDir := "dist"
-	if err := os.MkdirAll(distDir, 0755); err != nil {
-		return fmt.Errorf("distディレクトリの作成に失敗しました: %w", err)
-	}
-
-	// HTMLファイルを生成
 	outputPath := filepath.Join(distDir, "index.html")
-	outputFile, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("出力ファイルの作成に失敗しました: %w", err)
-	}
-	defer outputFile.Close()
-
-	if err := tmpl.Execute(outputFile, data); err != nil {
-		return fmt.Errorf("テンプレートの実行に失敗しました: %w", err)
+	page := PageData{
+		WeatherData: data,
+		Device:      genericDeviceProfile(),
+		StylesPath:  "styles/kindle.css",
+	}
+	if err := renderPage(tmpl, page, outputPath); err != nil {
+		return err
+	}
+
+	// 端末プロファイルごとのページを生成
+	for _, device := range devices {
+		devicePath := filepath.Join(distDir, "devices", device.Name, "index.html")
+		page := PageData{
+			WeatherData: data,
+			Device:      device,
+			StylesPath:  "../../styles/kindle.css",
+		}
+		if err := renderPage(tmpl, page, devicePath); err != nil {
+			return fmt.Errorf("端末 %s のページ生成に失敗しました: %w", device.Name, err)
+		}
+		log.Printf("端末 %s (%dx%d) のページを生成しました: %s", device.Name, device.ViewportWidth(), device.ViewportHeight(), devicePath)
 	}
 
 	// CSSファイルをコピー
@@ -709,6 +691,26 @@ func generateHTML(data *WeatherData) error {
 	return nil
 }
 
+// renderPage はテンプレートを実行して1ページ分のHTMLファイルを書き出す
+func renderPage(tmpl *template.Template, page PageData, outputPath string) error {
+	// 出力先ディレクトリを作成
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("出力ディレクトリの作成に失敗しました: %w", err)
+	}
+
+	outputFile, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("出力ファイルの作成に失敗しました: %w", err)
+	}
+	defer outputFile.Close()
+
+	if err := tmpl.Execute(outputFile, page); err != nil {
+		return fmt.Errorf("テンプレートの実行に失敗しました: %w", err)
+	}
+
+	return nil
+}
+
 func copyCSS() error {
 	srcPath := filepath.Join("src", "styles", "kindle.css")
 	destDir := filepath.Join("dist", "styles")
@@ -734,16 +736,165 @@ func copyCSS() error {
 }
 
 func main() {
+	// サブコマンドが指定された場合はそれを実行して終了
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:], os.Stdout); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	}
+
+	cacheDir := getEnv("CACHE_DIR", DefaultCacheDir)
+	fetcher = fetch.New(cacheDir, HTTPClientTimeout)
+
+	if err := validateCityCodes(getEnv("CITY_CODE", "130010"), os.Getenv("SECONDARY_CITY_CODES")); err != nil {
+		log.Fatalf("❌ 設定が不正です: %v", err)
+	}
+
+	// 日付・時刻は実行環境の TZ ではなく、地点のタイムゾーンで扱う
+	location, err := time.LoadLocation(getEnv("TIMEZONE", DefaultTimeZone))
+	if err != nil {
+		log.Fatalf("❌ タイムゾーンの設定が不正です: %v", err)
+	}
+
+	config, err := loadConfig(getEnv("CONFIG_PATH", DefaultConfigPath))
+	if err != nil {
+		log.Fatalf("❌ 設定の読み込みに失敗しました: %v", err)
+	}
+
+	devices, err := resolveDeviceProfiles(config, os.Getenv("DEVICES"))
+	if err != nil {
+		log.Fatalf("❌ 端末プロファイルの設定が不正です: %v", err)
+	}
+
+	events, err := config.calendarEvents()
+	if err != nil {
+		log.Fatalf("❌ 予定の設定が不正です: %v", err)
+	}
+
+	garbageSchedules, err := config.garbageSchedules()
+	if err != nil {
+		log.Fatalf("❌ ゴミ出しの収集日の設定が不正です: %v", err)
+	}
+
+	transitLines, err := config.transitLines()
+	if err != nil {
+		log.Fatalf("❌ 運行情報の設定が不正です: %v", err)
+	}
+	transitProvider, err := transit.NewProvider(config.Transit.Provider, os.Getenv("ODPT_CONSUMER_KEY"))
+	if err != nil {
+		log.Fatalf("❌ 運行情報の設定が不正です: %v", err)
+	}
+
+	origin, _ := city.Lookup(getEnv("CITY_CODE", "130010"))
+	quakeFilter, err := config.quakeFilter(origin)
+	if err != nil {
+		log.Fatalf("❌ 地震情報の設定が不正です: %v", err)
+	}
+	typhoonApproachKm, err := config.typhoonApproachKm()
+	if err != nil {
+		log.Fatalf("❌ 台風情報の設定が不正です: %v", err)
+	}
+	wbgtStation, hasWBGTStation, err := config.wbgtStation(origin)
+	if err != nil {
+		log.Fatalf("❌ 暑さ指数の設定が不正です: %v", err)
+	}
+	observationStation, hasObservationStation, err := config.observationStation(origin)
+	if err != nil {
+		log.Fatalf("❌ 現在の観測値の設定が不正です: %v", err)
+	}
+	wbgtMonths, err := config.wbgtMonths()
+	if err != nil {
+		log.Fatalf("❌ 暑さ指数の設定が不正です: %v", err)
+	}
+	healthSources, err := config.healthSources(origin)
+	if err != nil {
+		log.Fatalf("❌ 紫外線・花粉の設定が不正です: %v", err)
+	}
+	pressureProvider, pressureRule, err := config.pressureSettings(origin)
+	if err != nil {
+		log.Fatalf("❌ 気圧の設定が不正です: %v", err)
+	}
+	historyStore, historyDays, err := config.historySettings(cacheDir)
+	if err != nil {
+		log.Fatalf("❌ 天気の記録の設定が不正です: %v", err)
+	}
+	weatherProviders, err := config.weatherProviders(getEnv("CITY_CODE", "130010"), origin)
+	if err != nil {
+		log.Fatalf("❌ 天気予報の設定が不正です: %v", err)
+	}
+
 	log.Println("天気データを取得中...")
 
-	data, err := fetchWeatherData()
+	now := time.Now().In(location)
+	data, err := fetchWeatherData(weatherProviders, now)
 	if err != nil {
 		log.Fatalf("❌ 天気データの取得に失敗しました: %v", err)
 	}
+	if origin.Code != "" {
+		applyDaylight(data, origin)
+	}
+	if len(transitLines) > 0 {
+		log.Println("運行情報を取得中...")
+		data.Transit = fetchTransitInfo(transitProvider, transitLines)
+	}
 
-	if err := generateHTML(data); err != nil {
+	if !config.Quake.Disabled {
+		log.Println("地震情報を取得中...")
+		data.Quake = fetchQuakeInfo(quakeFilter, now)
+	}
+	if !config.Typhoon.Disabled {
+		log.Println("台風情報を取得中...")
+		data.Typhoon = fetchTyphoonInfo(origin, typhoonApproachKm, now)
+	}
+	if !config.WBGT.Disabled && inSeason(wbgtMonths, now) {
+		if hasWBGTStation {
+			log.Println("暑さ指数を取得中...")
+			data.WBGT = fetchWBGTInfo(wbgtStation, now)
+		} else {
+			log.Printf("⚠️  %dkm以内に暑さ指数の地点がないため、暑さ指数を表示しません (wbgt.point で地点番号を指定できます)", MaxStationDistanceKm)
+		}
+	}
+	if len(healthSources) > 0 {
+		log.Println("紫外線・花粉の情報を取得中...")
+		data.Health = fetchHealthInfo(healthSources, now)
+	}
+	if !config.Pressure.Disabled {
+		log.Println("気圧・湿度の予報を取得中...")
+		data.Pressure = fetchPressureInfo(pressureProvider, pressureRule, now)
+		if data.HasFeelsLike && data.Pressure.HasHumidity {
+			data.FeelsLike, data.FeelsLikeNote = feelsLikeTemperature(data.Temperature, data.Wind, data.Pressure.HumidityValue, true)
+		}
+	}
+	if !config.Observation.Disabled {
+		if hasObservationStation {
+			log.Println("現在の観測値を取得中...")
+			data.Observation = fetchObservationInfo(observationStation, now)
+			applyObservation(data)
+		} else {
+			log.Printf("⚠️  %dkm以内にアメダスの観測所がないため、現在の観測値を表示しません (observation.station で観測所番号を指定できます)", MaxStationDistanceKm)
+		}
+	}
+	if !config.History.Disabled {
+		data.History = updateHistory(historyStore, data, getEnv("CITY_CODE", "130010"), historyDays)
+	}
+
+	if sources := os.Getenv("ICS_SOURCES"); sources != "" {
+		log.Println("予定を取得中...")
+		from, to := agendaRange(now)
+		occurrences := fetchCalendarOccurrences(sources, from, to)
+		data.Agenda = buildAgenda(occurrences, now)
+		data.HasAgenda = true
+		events = append(events, agendaCalendarEvents(occurrences, now.Location())...)
+	}
+
+	data.Calendar = buildCalendarHeader(now, os.Getenv("SHOW_ROKUYO") == "true")
+	data.MonthCalendar = calendar.NewMonth(now, events)
+	data.Garbage = buildGarbageInfo(garbageSchedules, now)
+
+	if err := generateHTML(data, devices); err != nil {
 		log.Fatalf("❌ HTMLファイルの生成に失敗しました: %v", err)
 	}
 
 	log.Println("✅ ビルドが完了しました")
-}
\ No newline at end of file
+}
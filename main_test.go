@@ -80,6 +80,79 @@ func TestParseTemperature(t *testing.T) {
 	}
 }
 
+// getMaxRainChance のテスト
+func TestGetMaxRainChance(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+	}{
+		{
+			name:     "数値として比較する",
+			input:    []string{"9%", "10%", "0%", "5%"},
+			expected: "10%",
+		},
+		{
+			name:     "欠損値を無視する",
+			input:    []string{"--%", "-", "", "30%"},
+			expected: "30%",
+		},
+		{
+			name:     "すべて欠損の場合は0%",
+			input:    []string{"--%", "--%"},
+			expected: "0%",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getMaxRainChance(tt.input)
+			if result != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+// parseRainChance のテスト
+func TestParseRainChance(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+		hasError bool
+	}{
+		{name: "正常な値", input: "30%", expected: 30},
+		{name: "0%", input: "0%", expected: 0},
+		{name: "100%", input: "100%", expected: 100},
+		{name: "%なし", input: "70", expected: 70},
+		{name: "発表なし", input: "--%", hasError: true},
+		{name: "ハイフン", input: "-", hasError: true},
+		{name: "空文字列", input: "", hasError: true},
+		{name: "範囲外", input: "120%", hasError: true},
+		{name: "不正な文字列", input: "abc%", hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseRainChance(tt.input)
+
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("期待: エラー, 実際: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("期待: エラーなし, 実際: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("期待: %d, 実際: %d", tt.expected, result)
+			}
+		})
+	}
+}
+
 // getEnv のテスト
 func TestGetEnv(t *testing.T) {
 	tests := []struct {
@@ -236,6 +309,14 @@ func TestProcessWeatherData(t *testing.T) {
 				if data.Description != "晴れ" {
 					t.Errorf("Description: 期待=晴れ, 実際=%s", data.Description)
 				}
+				if !data.HasMaxTemp {
+					t.Error("HasMaxTemp: 期待=true, 実際=false")
+				}
+				if len(data.DailyForecasts) != 2 || !data.DailyForecasts[1].HasMaxTemp || !data.DailyForecasts[1].HasMinTemp {
+					t.Errorf("DailyForecasts の最高・最低気温が有効になっていません: %+v", data.DailyForecasts)
+				} else if data.DailyForecasts[1].HasRainChance {
+					t.Error("降水確率のない予報の HasRainChance が true です")
+				}
 				if len(data.HourlyForecast) == 0 {
 					t.Error("HourlyForecast が空です")
 				}
@@ -330,13 +411,18 @@ func TestProcessWeatherData(t *testing.T) {
 				if data.Temperature != 30 {
 					t.Errorf("Temperature: 期待=30, 実際=%d", data.Temperature)
 				}
+				// 明日の最高気温は今日の最高気温として扱わない (記録に残さない)
+				if data.HasMaxTemp {
+					t.Error("HasMaxTemp: 期待=false, 実際=true")
+				}
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := processWeatherData(tt.response)
+			now := time.Date(2025, 10, 2, 11, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+			result := processWeatherData(tsukumijimaForecast(tt.response), now)
 
 			if result == nil {
 				t.Fatal("processWeatherData が nil を返しました")
@@ -347,9 +433,92 @@ func TestProcessWeatherData(t *testing.T) {
 	}
 }
 
+// buildHourlyForecast のテスト
+func TestBuildHourlyForecast(t *testing.T) {
+	var response TsukumijimaWeatherResponse
+	err := json.Unmarshal([]byte(`{"forecasts": [
+		{"date": "2026-10-17", "telop": "雨", "chanceOfRain": {"T18_24": "90%"}},
+		{"date": "2026-10-18", "telop": "晴れ", "temperature": {"min": {"celsius": "12"}, "max": {"celsius": "20"}},
+			"chanceOfRain": {"T00_06": "0%", "T06_12": "10%", "T12_18": "20%", "T18_24": "30%"}},
+		{"date": "2026-10-19", "telop": "曇り", "temperature": {"min": {"celsius": "14"}, "max": {"celsius": "22"}},
+			"chanceOfRain": {"T00_06": "40%", "T06_12": "50%", "T12_18": "60%", "T18_24": "--%"}}
+	]}`), &response)
+	if err != nil {
+		t.Fatalf("テスト用のレスポンスを読み込めません: %v", err)
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("タイムゾーンを読み込めません: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		firstAt   time.Time
+		firstDesc string
+		firstRain string
+		count     int
+	}{
+		{
+			// 予報の先頭が昨日の日付でも、今日の日付の予報から始める
+			name:      "昼の更新",
+			now:       time.Date(2026, 10, 18, 11, 0, 0, 0, tokyo),
+			firstAt:   time.Date(2026, 10, 18, 12, 0, 0, 0, tokyo),
+			firstDesc: "晴れ",
+			firstRain: "20%",
+			count:     12,
+		},
+		{
+			name:      "23時台の更新は翌日の0時から",
+			now:       time.Date(2026, 10, 18, 22, 30, 0, 0, tokyo),
+			firstAt:   time.Date(2026, 10, 19, 0, 0, 0, 0, tokyo),
+			firstDesc: "曇り",
+			firstRain: "40%",
+			count:     8,
+		},
+		{
+			name:      "予報の時刻ちょうどの更新はその時刻を含めない",
+			now:       time.Date(2026, 10, 18, 12, 0, 0, 0, tokyo),
+			firstAt:   time.Date(2026, 10, 18, 15, 0, 0, 0, tokyo),
+			firstDesc: "晴れ",
+			firstRain: "20%",
+			count:     11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildHourlyForecast(tsukumijimaForecast(response).Days, 18, tt.now)
+			if len(result) != tt.count {
+				t.Fatalf("件数: 期待=%d, 実際=%d", tt.count, len(result))
+			}
+
+			first := result[0]
+			if !first.At.Equal(tt.firstAt) || first.At.Location() != tokyo {
+				t.Errorf("最初の時刻: 期待=%v, 実際=%v", tt.firstAt, first.At)
+			}
+			if first.Desc != tt.firstDesc {
+				t.Errorf("最初の天気: 期待=%s, 実際=%s", tt.firstDesc, first.Desc)
+			}
+			if first.RainChance != tt.firstRain {
+				t.Errorf("最初の降水確率: 期待=%s, 実際=%s", tt.firstRain, first.RainChance)
+			}
+
+			// 時刻は3時間おきに並ぶ
+			for i := 1; i < len(result); i++ {
+				if diff := result[i].At.Sub(result[i-1].At); diff != 3*time.Hour {
+					t.Errorf("HourlyForecast[%d] の間隔: 期待=3h, 実際=%v", i, diff)
+				}
+			}
+		})
+	}
+}
+
 // getSampleData のテスト
 func TestGetSampleData(t *testing.T) {
-	data, err := getSampleData()
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	data, err := getSampleData(now)
 
 	if err != nil {
 		t.Fatalf("getSampleData がエラーを返しました: %v", err)
@@ -375,10 +544,14 @@ func TestGetSampleData(t *testing.T) {
 		t.Error("News が空です")
 	}
 
-	// UpdateTime が正しいフォーマットかチェック
-	_, err = time.Parse("2006/01/02 15:04", data.UpdateTime)
-	if err != nil {
-		t.Errorf("UpdateTime のフォーマットが不正: %s, エラー: %v", data.UpdateTime, err)
+	// 更新時刻と時間別予報の時刻が now のタイムゾーンの時刻になっているかチェック
+	if !data.UpdatedAt.Equal(now) {
+		t.Errorf("UpdatedAt: 期待=%v, 実際=%v", now, data.UpdatedAt)
+	}
+	for i, hf := range data.HourlyForecast {
+		if hf.At.Location() != now.Location() || !sameDate(hf.At, now) {
+			t.Errorf("HourlyForecast[%d].At が今日の時刻ではありません: %v", i, hf.At)
+		}
 	}
 }
 
@@ -448,7 +621,7 @@ func TestFetchWeatherDataIntegration(t *testing.T) {
 			t.Fatalf("モックデータのUnmarshalに失敗: %v", err)
 		}
 
-		data := processWeatherData(weatherResponse)
+		data := processWeatherData(tsukumijimaForecast(weatherResponse), time.Date(2025, 10, 2, 11, 0, 0, 0, time.FixedZone("JST", 9*60*60)))
 		if data == nil {
 			t.Fatal("data が nil です")
 		}
@@ -462,7 +635,7 @@ func TestFetchWeatherDataIntegration(t *testing.T) {
 
 	t.Run("APIエラー時のフォールバック", func(t *testing.T) {
 		// getSampleData() が正常に動作することを確認
-		data, err := getSampleData()
+		data, err := getSampleData(time.Now())
 		if err != nil {
 			t.Fatalf("getSampleData がエラーを返しました: %v", err)
 		}
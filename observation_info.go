@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"kindle-tenki-dashboard/internal/amedas"
+	"kindle-tenki-dashboard/internal/feelslike"
+)
+
+// ObservationMaxAge は現在の観測値として表示する観測時刻の古さの上限
+// 取得に失敗して古いキャッシュを使った場合に、古い観測値を現在の値として表示しないために使う
+const ObservationMaxAge = 3 * time.Hour
+
+// ObservationInfo は現在の観測値 (アメダス) の表示内容
+type ObservationInfo struct {
+	Enabled            bool      `json:"enabled"`            // 現在の観測値を表示するかどうか
+	Unavailable        bool      `json:"unavailable"`        // 取得できなかったかどうか
+	Station            string    `json:"station"`            // 観測所名 (例: 東京)
+	ObservedAt         time.Time `json:"observedAt"`         // 観測時刻 (地点のタイムゾーン)
+	Temperature        string    `json:"temperature"`        // 気温 (例: 18.3)
+	TemperatureValue   float64   `json:"temperatureValue"`   // 気温 (℃、体感温度の計算に使う)
+	HasTemperature     bool      `json:"hasTemperature"`     // 気温の観測値があるかどうか
+	Humidity           string    `json:"humidity"`           // 湿度 (例: 64%)
+	HumidityValue      float64   `json:"humidityValue"`      // 湿度 (%、体感温度の計算に使う)
+	HasHumidity        bool      `json:"hasHumidity"`        // 湿度の観測値があるかどうか
+	Wind               string    `json:"wind"`               // 風向・風速 (例: 北北西 3.4m/s、静穏)
+	WindArrow          string    `json:"windArrow"`          // 風が吹いていく向きの矢印 (風向がない場合は空)
+	WindSpeedValue     float64   `json:"windSpeedValue"`     // 風速 (m/s、体感温度の計算に使う)
+	HasWindSpeed       bool      `json:"hasWindSpeed"`       // 風速の観測値があるかどうか
+	Precipitation      string    `json:"precipitation"`      // 前1時間の降水量 (例: 1.5mm)
+	PrecipitationValue float64   `json:"precipitationValue"` // 前1時間の降水量 (mm、天気の記録に使う)
+	HasPrecipitation   bool      `json:"hasPrecipitation"`   // 降水量の観測値があるかどうか
+}
+
+// fetchObservationInfo は観測所の最新の観測値を取得し、表示内容を生成する
+func fetchObservationInfo(station amedas.Station, now time.Time) ObservationInfo {
+	unavailable := ObservationInfo{Enabled: true, Unavailable: true, Station: station.Name}
+
+	observation, err := fetchAmedasObservation(station)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return unavailable
+	}
+	if age := now.Sub(observation.Time); age > ObservationMaxAge {
+		log.Printf("⚠️  %s の観測値が古いため使用しません (%s 観測)", station.Name, observation.Time.In(now.Location()).Format("1/2 15:04"))
+		return unavailable
+	}
+	info, ok := buildObservationInfo(observation, now)
+	if !ok {
+		log.Printf("⚠️  %s の観測値がすべて欠測です", station.Name)
+		return unavailable
+	}
+	info.Station = station.Name
+	return info
+}
+
+// fetchAmedasObservation はアメダスの最新の観測値から観測所の値を取り出す
+func fetchAmedasObservation(station amedas.Station) (amedas.Observation, error) {
+	body, err := fetchSource("アメダスの観測時刻", amedas.LatestTimeURL)
+	if err != nil {
+		return amedas.Observation{}, err
+	}
+	latest, err := amedas.ParseLatestTime(body)
+	if err != nil {
+		return amedas.Observation{}, err
+	}
+	body, err = fetchSource("アメダスの観測値", amedas.MapURL(latest))
+	if err != nil {
+		return amedas.Observation{}, err
+	}
+	observations, err := amedas.ParseMap(body, latest)
+	if err != nil {
+		return amedas.Observation{}, err
+	}
+	observation, ok := observations[station.Code]
+	if !ok {
+		return amedas.Observation{}, fmt.Errorf("アメダスの観測値に %s (%s) がありません", station.Name, station.Code)
+	}
+	return observation, nil
+}
+
+// buildObservationInfo は観測値から表示内容を生成する
+// 気温・湿度・風速・降水量がすべて欠測の場合は false を返す
+func buildObservationInfo(observation amedas.Observation, now time.Time) (ObservationInfo, bool) {
+	info := ObservationInfo{Enabled: true, ObservedAt: observation.Time.In(now.Location())}
+	if observation.HasTemperature {
+		info.Temperature = fmt.Sprintf("%.1f", observation.Temperature)
+		info.TemperatureValue, info.HasTemperature = observation.Temperature, true
+	}
+	if observation.HasHumidity {
+		info.Humidity = fmt.Sprintf("%.0f%%", observation.Humidity)
+		info.HumidityValue, info.HasHumidity = observation.Humidity, true
+	}
+	if observation.HasWindSpeed {
+		info.Wind = observedWind(observation)
+		info.WindArrow = observation.WindDirection.Arrow()
+		info.WindSpeedValue, info.HasWindSpeed = observation.WindSpeed, true
+	}
+	if observation.HasPrecipitation1h {
+		info.Precipitation = fmt.Sprintf("%.1fmm", observation.Precipitation1h)
+		info.PrecipitationValue, info.HasPrecipitation = observation.Precipitation1h, true
+	}
+	if !info.HasTemperature && !info.HasHumidity && !info.HasWindSpeed && !info.HasPrecipitation {
+		return ObservationInfo{}, false
+	}
+	return info, true
+}
+
+// observedWind は観測した風向・風速を「北北西 3.4m/s」の形式で返す
+// 風向がない場合は、風速が0.2m/s以下なら「静穏」、それ以外は風速のみを返す
+func observedWind(observation amedas.Observation) string {
+	label := observation.WindDirection.Label()
+	if label == "" {
+		if observation.WindSpeed < 0.3 {
+			return "静穏"
+		}
+		return fmt.Sprintf("%.1fm/s", observation.WindSpeed)
+	}
+	return fmt.Sprintf("%s %.1fm/s", label, observation.WindSpeed)
+}
+
+// applyObservation は観測した気温から体感温度を計算し直す
+// 湿度の観測値がない場合は気圧・湿度の予報の湿度を使う (予報の最高・最低気温はそのまま表示する)
+func applyObservation(w *WeatherData) {
+	observation := w.Observation
+	if !observation.HasTemperature {
+		return
+	}
+	conditions := feelslike.Conditions{
+		Temperature:  observation.TemperatureValue,
+		Humidity:     observation.HumidityValue,
+		HasHumidity:  observation.HasHumidity,
+		WindSpeed:    observation.WindSpeedValue,
+		HasWindSpeed: observation.HasWindSpeed,
+	}
+	if !conditions.HasHumidity && w.Pressure.HasHumidity {
+		conditions.Humidity, conditions.HasHumidity = w.Pressure.HumidityValue, true
+	}
+	w.FeelsLike, w.FeelsLikeNote = feelsLike(conditions)
+	w.HasFeelsLike = true
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/amedas"
+	"kindle-tenki-dashboard/internal/wind"
+)
+
+// buildObservationInfo のテスト
+func TestBuildObservationInfo(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 18, 10, 5, 0, 0, jst)
+	observedAt := time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		observation amedas.Observation
+		expected    ObservationInfo
+		ok          bool
+	}{
+		{
+			name: "すべての観測値",
+			observation: amedas.Observation{
+				Time: observedAt, Temperature: 18.26, HasTemperature: true, Humidity: 64, HasHumidity: true,
+				WindSpeed: 3.4, HasWindSpeed: true, WindDirection: wind.DirectionNNW, Precipitation1h: 1.5, HasPrecipitation1h: true,
+			},
+			expected: ObservationInfo{
+				Enabled: true, ObservedAt: observedAt.In(jst),
+				Temperature: "18.3", TemperatureValue: 18.26, HasTemperature: true,
+				Humidity: "64%", HumidityValue: 64, HasHumidity: true,
+				Wind: "北北西 3.4m/s", WindArrow: "↓", WindSpeedValue: 3.4, HasWindSpeed: true,
+				Precipitation: "1.5mm", PrecipitationValue: 1.5, HasPrecipitation: true,
+			},
+			ok: true,
+		},
+		{
+			name:        "気温が欠測で静穏",
+			observation: amedas.Observation{Time: observedAt, HasWindSpeed: true, WindDirection: wind.DirectionUnknown, HasPrecipitation1h: true},
+			expected: ObservationInfo{
+				Enabled: true, ObservedAt: observedAt.In(jst),
+				Wind: "静穏", HasWindSpeed: true, Precipitation: "0.0mm", HasPrecipitation: true,
+			},
+			ok: true,
+		},
+		{
+			name:        "すべて欠測",
+			observation: amedas.Observation{Time: observedAt, WindDirection: wind.DirectionUnknown},
+			ok:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := buildObservationInfo(tt.observation, now)
+			if ok != tt.ok {
+				t.Fatalf("期待: %v, 実際: %v", tt.ok, ok)
+			}
+			if info != tt.expected {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, info)
+			}
+		})
+	}
+}
+
+// observedWind のテスト
+func TestObservedWind(t *testing.T) {
+	tests := []struct {
+		direction wind.Direction
+		speed     float64
+		expected  string
+	}{
+		{wind.DirectionS, 5.0, "南 5.0m/s"},
+		{wind.DirectionUnknown, 0.2, "静穏"},
+		// 風向が欠測の場合は風速のみ
+		{wind.DirectionUnknown, 2.1, "2.1m/s"},
+	}
+	for _, tt := range tests {
+		observation := amedas.Observation{WindDirection: tt.direction, WindSpeed: tt.speed, HasWindSpeed: true}
+		if actual := observedWind(observation); actual != tt.expected {
+			t.Errorf("期待: %s, 実際: %s", tt.expected, actual)
+		}
+	}
+}
+
+// applyObservation のテスト
+func TestApplyObservation(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         WeatherData
+		expected     int
+		expectedNote string
+	}{
+		{
+			name: "観測した気温と風速から計算する",
+			data: WeatherData{
+				Temperature: 12, FeelsLike: 12, HasFeelsLike: true,
+				Observation: ObservationInfo{TemperatureValue: 4.6, HasTemperature: true, WindSpeedValue: 8, HasWindSpeed: true},
+			},
+			expected:     0,
+			expectedNote: "風による冷え",
+		},
+		{
+			name: "湿度の観測値がない場合は予報の湿度を使う",
+			data: WeatherData{
+				Temperature: 30,
+				Observation: ObservationInfo{TemperatureValue: 32.4, HasTemperature: true},
+				Pressure:    PressureInfo{HumidityValue: 70, HasHumidity: true},
+			},
+			expected:     42,
+			expectedNote: "湿度による蒸し暑さ",
+		},
+		{
+			name: "過ごしやすい気温は観測した気温",
+			data: WeatherData{
+				Temperature: 25,
+				Observation: ObservationInfo{TemperatureValue: 19.6, HasTemperature: true, HumidityValue: 50, HasHumidity: true},
+			},
+			expected: 20,
+		},
+		{
+			name:         "気温の観測値がない場合は予報のまま",
+			data:         WeatherData{Temperature: 22, FeelsLike: 22, HasFeelsLike: true, Observation: ObservationInfo{Humidity: "60%", HumidityValue: 60, HasHumidity: true}},
+			expected:     22,
+			expectedNote: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data
+			applyObservation(&data)
+			if !data.HasFeelsLike || data.FeelsLike != tt.expected || data.FeelsLikeNote != tt.expectedNote {
+				t.Errorf("期待: %d℃ (%q), 実際: %d℃ (%q, %v)", tt.expected, tt.expectedNote, data.FeelsLike, data.FeelsLikeNote, data.HasFeelsLike)
+			}
+			if data.Temperature != tt.data.Temperature {
+				t.Errorf("予報の気温が変わっています: %d → %d", tt.data.Temperature, data.Temperature)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"math"
+	"time"
+
+	"kindle-tenki-dashboard/internal/chart"
+	"kindle-tenki-dashboard/internal/pressure"
+)
+
+// PressureChartLayout は気圧のスパークラインのレイアウト
+// 左右の余白に最初と最後の値 (例: 1015.1hPa) を表示する
+var PressureChartLayout = chart.Layout{Width: 400, Height: 44, PaddingTop: 6, PaddingRight: 56, PaddingBottom: 6, PaddingLeft: 56}
+
+// PressureInfo は気圧・湿度の表示内容
+type PressureInfo struct {
+	Enabled       bool            `json:"enabled"`       // 気圧・湿度を表示するかどうか
+	Unavailable   bool            `json:"unavailable"`   // 取得できなかったかどうか
+	StaleAt       string          `json:"staleAt"`       // 古いキャッシュを使った場合の取得時刻 (例: 10/18 09:00)
+	Current       string          `json:"current"`       // 現在の気圧 (例: 1015hPa)
+	Change        string          `json:"change"`        // 24時間後までの変化 (例: -8hPa)
+	Humidity      string          `json:"humidity"`      // 現在の湿度 (例: 64%)
+	HumidityValue float64         `json:"humidityValue"` // 現在の湿度 (%、体感温度の計算に使う)
+	HasHumidity   bool            `json:"hasHumidity"`   // 現在の湿度の予報があるかどうか
+	IsRapid       bool            `json:"isRapid"`       // 急な気圧の低下があるかどうか
+	Warning       string          `json:"warning"`       // 急な低下の説明 (例: 14時〜20時に8.4hPa低下)
+	Points        []PressurePoint `json:"points"`        // 24時間後までの1時間ごとの気圧
+	DropFrom      int             `json:"dropFrom"`      // 急な低下が始まる Points の位置
+	DropTo        int             `json:"dropTo"`        // 急な低下が終わる Points の位置
+}
+
+// PressurePoint は1時間ごとの気圧
+type PressurePoint struct {
+	Time     string  `json:"time"`     // 時刻 (例: 15:00)
+	Pressure float64 `json:"pressure"` // 海面気圧 (hPa)
+	HasValue bool    `json:"hasValue"` // 気圧の予報があるかどうか
+}
+
+// fetchPressureInfo は気圧・湿度の予報を取得し、表示内容を生成する
+func fetchPressureInfo(provider pressure.Provider, rule pressure.Rule, now time.Time) PressureInfo {
+	unavailable := PressureInfo{Enabled: true, Unavailable: true}
+
+	result, err := fetchSourceResult("気圧の予報", provider.URL(now))
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return unavailable
+	}
+	points, err := provider.Parse(result.Body)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return unavailable
+	}
+	info, ok := buildPressureInfo(points, rule, now)
+	if !ok {
+		log.Printf("⚠️  現在の気圧の予報がありません")
+		return unavailable
+	}
+	if result.Stale {
+		info.StaleAt = result.FetchedAt.In(now.Location()).Format("1/2 15:04")
+	}
+	return info
+}
+
+// buildPressureInfo は1時間ごとの予報から気圧の変化と急な低下を求め、表示内容を生成する
+// 現在の気圧の予報がない場合は false を返す
+func buildPressureInfo(points []pressure.Point, rule pressure.Rule, now time.Time) (PressureInfo, bool) {
+	trend, ok := pressure.Analyze(points, now, rule)
+	if !ok {
+		return PressureInfo{}, false
+	}
+
+	info := PressureInfo{
+		Enabled: true,
+		Current: fmt.Sprintf("%.0fhPa", trend.Current),
+		Change:  "±0hPa",
+		IsRapid: trend.IsRapid,
+	}
+	if change := math.Round(trend.Change); change != 0 {
+		info.Change = fmt.Sprintf("%+.0fhPa", change)
+	}
+	if trend.HasHumidity {
+		info.Humidity = fmt.Sprintf("%.0f%%", trend.Humidity)
+		info.HumidityValue = trend.Humidity
+		info.HasHumidity = true
+	}
+
+	location := now.Location()
+	for i, point := range trend.Points {
+		info.Points = append(info.Points, PressurePoint{
+			Time:     point.Time.In(location).Format("15:04"),
+			Pressure: point.Pressure,
+			HasValue: point.HasPressure,
+		})
+		if trend.IsRapid && point.Time.Equal(trend.DropFrom) {
+			info.DropFrom = i
+		}
+		if trend.IsRapid && point.Time.Equal(trend.DropTo) {
+			info.DropTo = i
+		}
+	}
+	if trend.IsRapid {
+		info.Warning = fmt.Sprintf("%s〜%sに%.1fhPa低下",
+			pressureHour(trend.DropFrom.In(location), now), pressureHour(trend.DropTo.In(location), now), trend.MaxDrop)
+	}
+	return info, true
+}
+
+// pressureHour は時刻を「15時」「明日3時」の形式で返す
+func pressureHour(t, now time.Time) string {
+	if t.YearDay() != now.YearDay() || t.Year() != now.Year() {
+		return fmt.Sprintf("明日%d時", t.Hour())
+	}
+	return fmt.Sprintf("%d時", t.Hour())
+}
+
+// PressureChart は今後24時間の気圧のスパークライン(SVG)を返す
+// 急な低下の区間は太線で強調する
+func (w *WeatherData) PressureChart() template.HTML {
+	if len(w.Pressure.Points) == 0 {
+		return ""
+	}
+
+	points := make([]chart.Point, len(w.Pressure.Points))
+	for i, point := range w.Pressure.Points {
+		points[i] = chart.Point{Label: point.Time, Value: point.Pressure, HasValue: point.HasValue}
+	}
+
+	sparkline := chart.Sparkline{
+		Title:  "24時間の気圧変化",
+		Class:  "pressure-chart",
+		Layout: PressureChartLayout,
+		Points: points,
+		Unit:   "hPa",
+	}
+	if w.Pressure.IsRapid {
+		sparkline.HighlightFrom = w.Pressure.DropFrom
+		sparkline.HighlightTo = w.Pressure.DropTo
+	}
+	return template.HTML(sparkline.SVG())
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/pressure"
+)
+
+// buildPressureInfo のテスト
+func TestBuildPressureInfo(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 18, 12, 30, 0, 0, jst)
+	rule := pressure.Rule{DropHPa: 6, Window: 6 * time.Hour, Horizon: 24 * time.Hour}
+
+	// 12時から1時間ごとの予報 (18時〜翌0時に1.5hPaずつ低下)
+	points := func(values ...float64) []pressure.Point {
+		result := make([]pressure.Point, len(values))
+		for i, value := range values {
+			result[i] = pressure.Point{
+				Time:        time.Date(2026, 10, 18, 12+i, 0, 0, 0, jst),
+				Pressure:    value,
+				HasPressure: value > 0,
+				Humidity:    70,
+				HasHumidity: i == 0,
+			}
+		}
+		return result
+	}
+
+	t.Run("急な低下あり", func(t *testing.T) {
+		info, ok := buildPressureInfo(points(1013.2, 1013, 1013, 1013, 1013, 1013, 1013, 1011.5, 1010, 1008.5, 1007, 1005.5, 1004, 1004.2), rule, now)
+		if !ok {
+			t.Fatal("期待: 表示内容あり, 実際: なし")
+		}
+		if info.Current != "1013hPa" || info.Change != "-9hPa" || info.Humidity != "70%" {
+			t.Errorf("現在の気圧・変化・湿度が不正です: %s, %s, %s", info.Current, info.Change, info.Humidity)
+		}
+		if !info.IsRapid || info.Warning != "18時〜明日0時に9.0hPa低下" {
+			t.Errorf("警告: 期待=18時〜明日0時に9.0hPa低下, 実際=%q (%v)", info.Warning, info.IsRapid)
+		}
+		if len(info.Points) != 14 || info.DropFrom != 6 || info.DropTo != 12 {
+			t.Errorf("強調する区間: 期待=6〜12 (14時刻), 実際=%d〜%d (%d時刻)", info.DropFrom, info.DropTo, len(info.Points))
+		}
+	})
+
+	t.Run("緩やかな変化", func(t *testing.T) {
+		info, ok := buildPressureInfo(points(1013.2, 1013, 0, 1013.4), rule, now)
+		if !ok {
+			t.Fatal("期待: 表示内容あり, 実際: なし")
+		}
+		if info.IsRapid || info.Warning != "" || info.Change != "±0hPa" {
+			t.Errorf("緩やかな変化の表示内容が不正です: %+v", info)
+		}
+		if info.Points[2].HasValue {
+			t.Error("期待: 値のない時刻, 実際: 値あり")
+		}
+	})
+
+	t.Run("現在の気圧がない", func(t *testing.T) {
+		if _, ok := buildPressureInfo(points(0, 1013), rule, now); ok {
+			t.Error("期待: 表示内容なし, 実際: あり")
+		}
+	})
+}
+
+// PressureChart のテスト
+func TestPressureChart(t *testing.T) {
+	data := &WeatherData{}
+	if data.PressureChart() != "" {
+		t.Error("予報がない場合は空文字を返すべきです")
+	}
+
+	data.Pressure = PressureInfo{
+		Points: []PressurePoint{
+			{Time: "12:00", Pressure: 1013, HasValue: true},
+			{Time: "13:00", Pressure: 1010, HasValue: true},
+			{Time: "14:00", Pressure: 1005, HasValue: true},
+		},
+		IsRapid:  true,
+		DropFrom: 0,
+		DropTo:   2,
+	}
+	svg := string(data.PressureChart())
+	for _, expected := range []string{`class="pressure-chart"`, "24時間の気圧変化", "1013hPa", "1005hPa", `stroke-width="4"`} {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("SVGに %q が含まれていません", expected)
+		}
+	}
+}
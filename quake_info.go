@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/quake"
+)
+
+// QuakeInfo は地震情報の表示内容
+type QuakeInfo struct {
+	Enabled     bool        `json:"enabled"`     // 地震情報を表示するかどうか
+	Unavailable bool        `json:"unavailable"` // 地震情報を取得できなかったかどうか
+	Condition   string      `json:"condition"`   // 表示条件 (例: 過去72時間・震度3以上)
+	Items       []QuakeItem `json:"items"`       // 条件に合う地震 (新しい順)
+}
+
+// QuakeItem は1つの地震の表示内容
+type QuakeItem struct {
+	Time              string `json:"time"`              // 発生時刻 (例: 10/18 07:03)
+	Epicenter         string `json:"epicenter"`         // 震央地名 (不明の場合は「調査中」)
+	Depth             string `json:"depth"`             // 深さ (例: 約70km、ごく浅い)
+	Distance          string `json:"distance"`          // 基準地点からの距離 (例: 約40km)
+	Magnitude         string `json:"magnitude"`         // マグニチュード (例: M4.8)
+	MaxIntensity      string `json:"maxIntensity"`      // 最大震度 (例: 5弱)
+	Tsunami           string `json:"tsunami"`           // 津波の状況
+	IsStrong          bool   `json:"isStrong"`          // 最大震度5弱以上かどうか
+	HasTsunamiWarning bool   `json:"hasTsunamiWarning"` // 津波警報・注意報を発表中かどうか
+}
+
+// fetchQuakeInfo は地震情報を取得し、条件に合う地震の表示内容を生成する
+// 津波の状況は絞り込みの前に調べ、震度が小さい地震や遠い地震でも津波警報・注意報を発表中なら表示する
+// 詳細を取得するのは条件に合う地震と津波の可能性がある地震 (quake.Filter.Candidates) だけで、取得に失敗した場合は津波の状況を表示しない
+func fetchQuakeInfo(filter quake.Filter, now time.Time) QuakeInfo {
+	info := QuakeInfo{Enabled: true, Condition: quakeCondition(filter)}
+
+	body, err := fetchSource("地震情報", quake.ListURL)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		info.Unavailable = true
+		return info
+	}
+	quakes, err := quake.ParseList(body)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		info.Unavailable = true
+		return info
+	}
+
+	quakes = filter.Candidates(quakes, now)
+	for i, q := range quakes {
+		if q.DetailFile == "" {
+			continue
+		}
+		detail, err := fetchSource("地震情報の詳細", quake.DetailURL(q.DetailFile))
+		if err != nil {
+			log.Printf("⚠️  %v", err)
+			continue
+		}
+		if tsunami, err := quake.ParseTsunami(detail); err == nil {
+			quakes[i].Tsunami = tsunami
+		}
+	}
+
+	info.Items = buildQuakeItems(filter.Apply(quakes, now), filter, now)
+	return info
+}
+
+// buildQuakeItems は地震の一覧から表示内容を生成する
+func buildQuakeItems(quakes []quake.Earthquake, filter quake.Filter, now time.Time) []QuakeItem {
+	var items []QuakeItem
+	for _, q := range quakes {
+		item := QuakeItem{
+			Time:              q.Time.In(now.Location()).Format("1/2 15:04"),
+			Epicenter:         "調査中",
+			Magnitude:         "M不明",
+			MaxIntensity:      q.MaxIntensity.Label(),
+			Tsunami:           q.Tsunami.Label(),
+			IsStrong:          q.MaxIntensity >= quake.Intensity5Lower,
+			HasTsunamiWarning: q.Tsunami == quake.TsunamiWarning,
+		}
+		if q.Epicenter != "" {
+			item.Epicenter = q.Epicenter
+		}
+		if q.HasMagnitude {
+			item.Magnitude = fmt.Sprintf("M%.1f", q.Magnitude)
+		}
+		if q.HasDepth {
+			if q.DepthKm == 0 {
+				item.Depth = "ごく浅い"
+			} else {
+				item.Depth = fmt.Sprintf("約%dkm", q.DepthKm)
+			}
+		}
+		if q.HasEpicenter && filter.Origin.Code != "" {
+			item.Distance = fmt.Sprintf("約%.0fkm", filter.Origin.DistanceTo(q.Latitude, q.Longitude))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// quakeCondition は表示条件の説明を返す (例: 過去72時間・震度3以上・東京から500km以内)
+func quakeCondition(filter quake.Filter) string {
+	var conditions []string
+	if filter.Period > 0 {
+		conditions = append(conditions, fmt.Sprintf("過去%.0f時間", filter.Period.Hours()))
+	}
+	conditions = append(conditions, fmt.Sprintf("震度%s以上", filter.MinIntensity.Label()))
+	if filter.MaxDistanceKm > 0 && filter.Origin.Name != "" {
+		conditions = append(conditions, fmt.Sprintf("%sから%.0fkm以内", filter.Origin.Name, filter.MaxDistanceKm))
+	}
+	return strings.Join(conditions, "・")
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/city"
+	"kindle-tenki-dashboard/internal/quake"
+)
+
+// buildQuakeItems のテスト
+func TestBuildQuakeItems(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 18, 8, 0, 0, 0, jst)
+	tokyo, _ := city.Lookup("130010")
+
+	quakes := []quake.Earthquake{
+		{
+			EventID: "1", Time: time.Date(2026, 10, 17, 22, 3, 0, 0, time.UTC), Epicenter: "千葉県北西部",
+			HasEpicenter: true, Latitude: 35.6, Longitude: 140.1, HasDepth: true, DepthKm: 70,
+			HasMagnitude: true, Magnitude: 4.8, MaxIntensity: quake.Intensity4, Tsunami: quake.TsunamiNone,
+		},
+		{
+			EventID: "2", Time: time.Date(2026, 10, 16, 3, 5, 0, 0, jst), Epicenter: "宮城県沖",
+			HasEpicenter: true, Latitude: 38.3, Longitude: 142.0, HasDepth: true, DepthKm: 0,
+			HasMagnitude: true, Magnitude: 6.1, MaxIntensity: quake.Intensity5Lower, Tsunami: quake.TsunamiWarning,
+		},
+		{EventID: "3", Time: time.Date(2026, 10, 15, 12, 0, 0, 0, jst), MaxIntensity: quake.Intensity3},
+	}
+	items := buildQuakeItems(quakes, quake.Filter{Origin: tokyo}, now)
+	expected := []QuakeItem{
+		{Time: "10/18 07:03", Epicenter: "千葉県北西部", Depth: "約70km", Distance: "約38km", Magnitude: "M4.8", MaxIntensity: "4", Tsunami: "津波の心配なし"},
+		{Time: "10/16 03:05", Epicenter: "宮城県沖", Depth: "ごく浅い", Distance: "約355km", Magnitude: "M6.1", MaxIntensity: "5弱", Tsunami: "津波警報・注意報", IsStrong: true, HasTsunamiWarning: true},
+		{Time: "10/15 12:00", Epicenter: "調査中", Magnitude: "M不明", MaxIntensity: "3"},
+	}
+	if len(items) != len(expected) {
+		t.Fatalf("件数: 期待=%d, 実際=%d", len(expected), len(items))
+	}
+	for i := range items {
+		if items[i] != expected[i] {
+			t.Errorf("期待: %+v, 実際: %+v", expected[i], items[i])
+		}
+	}
+}
+
+// quakeCondition のテスト
+func TestQuakeCondition(t *testing.T) {
+	tokyo, _ := city.Lookup("130010")
+
+	tests := []struct {
+		name     string
+		filter   quake.Filter
+		expected string
+	}{
+		{"期間と震度", quake.Filter{MinIntensity: quake.Intensity3, Period: 72 * time.Hour}, "過去72時間・震度3以上"},
+		{"距離あり", quake.Filter{MinIntensity: quake.Intensity5Lower, Period: 24 * time.Hour, MaxDistanceKm: 300, Origin: tokyo}, "過去24時間・震度5弱以上・東京から300km以内"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := quakeCondition(tt.filter); actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, actual)
+			}
+		})
+	}
+}
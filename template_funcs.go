@@ -0,0 +1,29 @@
+package main
+
+import (
+	"html/template"
+	"time"
+)
+
+// templateFuncs はテンプレートで使うヘルパー関数
+// 日付・時刻はデータに time.Time (地点のタイムゾーン) で持ち、表示の形式はテンプレートで決める
+var templateFuncs = template.FuncMap{
+	"clock":    formatClock,
+	"datetime": formatDateTime,
+}
+
+// formatClock は時刻を「15:04」の形式で返す (時刻がない場合は空)
+func formatClock(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("15:04")
+}
+
+// formatDateTime は日時を「2006/01/02 15:04」の形式で返す (時刻がない場合は空)
+func formatDateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006/01/02 15:04")
+}
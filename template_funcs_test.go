@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// formatClock / formatDateTime のテスト
+func TestFormatTime(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	at := time.Date(2026, 10, 18, 9, 5, 0, 0, jst)
+
+	tests := []struct {
+		name     string
+		format   func(time.Time) string
+		time     time.Time
+		expected string
+	}{
+		{"時刻", formatClock, at, "09:05"},
+		{"日時", formatDateTime, at, "2026/10/18 09:05"},
+		{"時刻のタイムゾーンで表示する", formatClock, at.UTC(), "00:05"},
+		{"時刻がない場合", formatClock, time.Time{}, ""},
+		{"日時がない場合", formatDateTime, time.Time{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := tt.format(tt.time); actual != tt.expected {
+				t.Errorf("期待: %s, 実際: %s", tt.expected, actual)
+			}
+		})
+	}
+}
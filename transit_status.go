@@ -0,0 +1,74 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"kindle-tenki-dashboard/internal/transit"
+)
+
+// TransitInfo は電車の運行情報の表示内容
+type TransitInfo struct {
+	HasLines    bool          `json:"hasLines"`    // 路線が設定されているかどうか
+	AllNormal   bool          `json:"allNormal"`   // すべての路線が平常運転かどうか (1行にまとめて表示する)
+	Unavailable bool          `json:"unavailable"` // 運行情報を取得できなかったかどうか
+	LineNames   string        `json:"lineNames"`   // 路線名 (例: 山手線・中央線)
+	Lines       []TransitLine `json:"lines"`       // 路線ごとの運行状況
+}
+
+// TransitLine は路線ごとの運行状況
+type TransitLine struct {
+	Name        string `json:"name"`        // 路線名
+	Status      string `json:"status"`      // 運行状況 (平常運転/遅延/運転見合わせ/情報なし)
+	Detail      string `json:"detail"`      // 運行情報の本文
+	IsProblem   bool   `json:"isProblem"`   // 遅延・運転見合わせかどうか
+	IsSuspended bool   `json:"isSuspended"` // 運転見合わせかどうか
+}
+
+// fetchTransitInfo は運行情報を取得して表示内容を生成する
+// 取得・パースに失敗した場合はすべての路線を「情報なし」とする
+func fetchTransitInfo(provider transit.Provider, lines []transit.Line) TransitInfo {
+	if len(lines) == 0 {
+		return TransitInfo{}
+	}
+
+	body, err := fetchSource("運行情報", provider.URL())
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return buildTransitInfo(transit.Unknown(lines))
+	}
+	statuses, err := provider.Parse(body, lines)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return buildTransitInfo(transit.Unknown(lines))
+	}
+	return buildTransitInfo(statuses)
+}
+
+// buildTransitInfo は路線ごとの運行状況から表示内容を生成する
+func buildTransitInfo(statuses []transit.LineStatus) TransitInfo {
+	if len(statuses) == 0 {
+		return TransitInfo{}
+	}
+
+	info := TransitInfo{HasLines: true, AllNormal: true, Unavailable: true}
+	var names []string
+	for _, status := range statuses {
+		names = append(names, status.Line.Name)
+		info.Lines = append(info.Lines, TransitLine{
+			Name:        status.Line.Name,
+			Status:      status.Status.Label(),
+			Detail:      status.Detail,
+			IsProblem:   status.Status.IsProblem(),
+			IsSuspended: status.Status == transit.StatusSuspended,
+		})
+		if status.Status != transit.StatusNormal {
+			info.AllNormal = false
+		}
+		if status.Status != transit.StatusUnknown {
+			info.Unavailable = false
+		}
+	}
+	info.LineNames = strings.Join(names, "・")
+	return info
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+
+	"kindle-tenki-dashboard/internal/transit"
+)
+
+// buildTransitInfo のテスト
+func TestBuildTransitInfo(t *testing.T) {
+	yamanote := transit.Line{Name: "山手線"}
+	chuo := transit.Line{Name: "中央線"}
+
+	tests := []struct {
+		name                string
+		statuses            []transit.LineStatus
+		expectedAllNormal   bool
+		expectedUnavailable bool
+		expectedProblems    int
+	}{
+		{
+			name: "すべて平常運転",
+			statuses: []transit.LineStatus{
+				{Line: yamanote, Status: transit.StatusNormal},
+				{Line: chuo, Status: transit.StatusNormal},
+			},
+			expectedAllNormal: true,
+		},
+		{
+			name: "一部で運転見合わせ",
+			statuses: []transit.LineStatus{
+				{Line: yamanote, Status: transit.StatusSuspended},
+				{Line: chuo, Status: transit.StatusNormal},
+			},
+			expectedProblems: 1,
+		},
+		{
+			name: "一部で情報なし",
+			statuses: []transit.LineStatus{
+				{Line: yamanote, Status: transit.StatusUnknown},
+				{Line: chuo, Status: transit.StatusDelayed},
+			},
+			expectedProblems: 1,
+		},
+		{
+			name:                "取得に失敗",
+			statuses:            transit.Unknown([]transit.Line{yamanote, chuo}),
+			expectedUnavailable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := buildTransitInfo(tt.statuses)
+			if !info.HasLines || info.LineNames != "山手線・中央線" {
+				t.Errorf("路線: 期待=山手線・中央線, 実際=%+v", info)
+			}
+			if info.AllNormal != tt.expectedAllNormal {
+				t.Errorf("AllNormal: 期待=%v, 実際=%v", tt.expectedAllNormal, info.AllNormal)
+			}
+			if info.Unavailable != tt.expectedUnavailable {
+				t.Errorf("Unavailable: 期待=%v, 実際=%v", tt.expectedUnavailable, info.Unavailable)
+			}
+			problems := 0
+			for _, line := range info.Lines {
+				if line.IsProblem {
+					problems++
+				}
+			}
+			if problems != tt.expectedProblems {
+				t.Errorf("遅延・運転見合わせ: 期待=%d件, 実際=%d件", tt.expectedProblems, problems)
+			}
+		})
+	}
+
+	t.Run("路線なし", func(t *testing.T) {
+		if info := buildTransitInfo(nil); info.HasLines {
+			t.Errorf("期待: HasLines=false, 実際: %+v", info)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/city"
+	"kindle-tenki-dashboard/internal/typhoon"
+)
+
+// TyphoonInfo は台風情報の表示内容
+// 発表中の台風がない場合は Items が空になり、セクションを表示しない
+type TyphoonInfo struct {
+	Enabled bool          `json:"enabled"` // 台風情報を表示するかどうか
+	Origin  string        `json:"origin"`  // 距離の基準地点名 (例: 東京)
+	Items   []TyphoonItem `json:"items"`   // 発表中の台風 (番号順)
+}
+
+// TyphoonItem は1つの台風の表示内容
+type TyphoonItem struct {
+	Title         string `json:"title"`         // 番号と名前 (例: 台風第10号 (ハギビス))
+	Strength      string `json:"strength"`      // 大きさと強さ (例: 大型で強い)
+	ObservedAt    string `json:"observedAt"`    // 実況の時刻 (例: 10/18 09:00)
+	Location      string `json:"location"`      // 存在地域と中心位置 (例: 八丈島の南 (北緯30.0度 東経139.8度))
+	Pressure      string `json:"pressure"`      // 中心気圧 (例: 965hPa)
+	MaxWind       string `json:"maxWind"`       // 最大風速 (例: 35m/s、不明の場合は空)
+	Movement      string `json:"movement"`      // 進行方向と速さ (例: 北北東 25km/h)
+	Distance      string `json:"distance"`      // 基準地点から中心までの現在の距離 (例: 約630km)
+	Approach      string `json:"approach"`      // 予報進路で最も近づく時刻と距離 (例: 10/19 6時頃 約98km)。遠ざかっている場合は空
+	IsApproaching bool   `json:"isApproaching"` // 最接近の距離が強調する距離以内かどうか
+}
+
+// fetchTyphoonInfo は発表中の台風の情報を取得し、表示内容を生成する
+// 台風ごとの情報の取得に失敗した場合はその台風を表示しない
+func fetchTyphoonInfo(origin city.Area, approachKm float64, now time.Time) TyphoonInfo {
+	info := TyphoonInfo{Enabled: true, Origin: origin.Name}
+
+	body, err := fetchSource("台風情報", typhoon.TargetListURL)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return info
+	}
+	ids, err := typhoon.ParseTargets(body)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return info
+	}
+
+	var typhoons []typhoon.Typhoon
+	for _, id := range ids {
+		body, err := fetchSource("台風情報の詳細", typhoon.SpecificationsURL(id))
+		if err != nil {
+			log.Printf("⚠️  %v", err)
+			continue
+		}
+		t, err := typhoon.ParseSpecifications(body)
+		if err != nil {
+			log.Printf("⚠️  %v", err)
+			continue
+		}
+		typhoons = append(typhoons, t)
+	}
+
+	info.Items = buildTyphoonItems(typhoons, origin, approachKm, now)
+	return info
+}
+
+// buildTyphoonItems は台風の一覧から表示内容を生成する
+// 番号の付いていない熱帯低気圧は表示しない
+func buildTyphoonItems(typhoons []typhoon.Typhoon, origin city.Area, approachKm float64, now time.Time) []TyphoonItem {
+	var items []TyphoonItem
+	for _, t := range typhoons {
+		if !t.IsTyphoon() {
+			continue
+		}
+
+		item := TyphoonItem{
+			Title:      fmt.Sprintf("台風第%d号", t.Number),
+			Strength:   typhoonStrength(t.Size, t.Intensity),
+			ObservedAt: t.ObservedAt.In(now.Location()).Format("1/2 15:04"),
+			Location:   fmt.Sprintf("北緯%.1f度 東経%.1f度", t.Latitude, t.Longitude),
+			Pressure:   fmt.Sprintf("%dhPa", t.Pressure),
+			Movement:   strings.TrimSpace(t.Course),
+		}
+		if t.Name != "" {
+			item.Title += " (" + t.Name + ")"
+		}
+		if t.Location != "" {
+			item.Location = t.Location + " (" + item.Location + ")"
+		}
+		if t.MaxWind > 0 {
+			item.MaxWind = fmt.Sprintf("%dm/s", t.MaxWind)
+		}
+		if t.SpeedKmh > 0 {
+			item.Movement = strings.TrimSpace(fmt.Sprintf("%s %dkm/h", item.Movement, t.SpeedKmh))
+		}
+
+		if origin.Code != "" {
+			item.Distance = fmt.Sprintf("約%.0fkm", t.DistanceFrom(origin))
+			// 実況の位置が最も近い場合は遠ざかっているため最接近を表示しない
+			if approach, ok := t.ClosestApproach(origin); ok && approach.Time.After(t.ObservedAt) {
+				at := approach.Time.In(now.Location())
+				item.Approach = fmt.Sprintf("%s %d時頃 約%.0fkm", at.Format("1/2"), at.Hour(), approach.DistanceKm)
+				item.IsApproaching = approach.DistanceKm <= approachKm
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// typhoonStrength は台風の大きさと強さの表記を返す (例: 大型で強い、強い、大型)
+func typhoonStrength(size, intensity string) string {
+	switch {
+	case size != "" && intensity != "":
+		return size + "で" + intensity
+	case size != "":
+		return size
+	default:
+		return intensity
+	}
+}
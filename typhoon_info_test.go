@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/city"
+	"kindle-tenki-dashboard/internal/typhoon"
+)
+
+// buildTyphoonItems のテスト
+func TestBuildTyphoonItems(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, jst)
+	observedAt := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	tokyo, _ := city.Lookup("130010")
+
+	typhoons := []typhoon.Typhoon{
+		{
+			Number: 10, Name: "ハギビス", Intensity: "強い", Size: "大型", Location: "八丈島の南",
+			ObservedAt: observedAt, Latitude: 30.0, Longitude: 139.8, Pressure: 965, MaxWind: 35, Course: "北北東", SpeedKmh: 25,
+			Track: []typhoon.TrackPoint{
+				{Time: observedAt, Latitude: 30.0, Longitude: 139.8},
+				{Time: observedAt.Add(12 * time.Hour), Latitude: 33.0, Longitude: 139.6},
+				{Time: observedAt.Add(24 * time.Hour), Latitude: 36.0, Longitude: 141.0},
+			},
+		},
+		// 熱帯低気圧は表示しない
+		{Category: "熱帯低気圧", ObservedAt: observedAt, Latitude: 14.0, Longitude: 132.0, Pressure: 1004},
+		// 遠ざかっている台風は最接近を表示しない
+		{
+			Number: 11, Location: "日本の東", ObservedAt: observedAt, Latitude: 38.0, Longitude: 150.0, Pressure: 990, Course: "東",
+			Track: []typhoon.TrackPoint{
+				{Time: observedAt, Latitude: 38.0, Longitude: 150.0},
+				{Time: observedAt.Add(24 * time.Hour), Latitude: 42.0, Longitude: 160.0},
+			},
+		},
+	}
+
+	items := buildTyphoonItems(typhoons, tokyo, DefaultTyphoonApproachKm, now)
+	expected := []TyphoonItem{
+		{
+			Title: "台風第10号 (ハギビス)", Strength: "大型で強い", ObservedAt: "10/18 09:00",
+			Location: "八丈島の南 (北緯30.0度 東経139.8度)", Pressure: "965hPa", MaxWind: "35m/s", Movement: "北北東 25km/h",
+			Distance: "約633km", Approach: "10/19 6時頃 約98km", IsApproaching: true,
+		},
+		{
+			Title: "台風第11号", ObservedAt: "10/18 09:00",
+			Location: "日本の東 (北緯38.0度 東経150.0度)", Pressure: "990hPa", Movement: "東",
+			Distance: "約952km",
+		},
+	}
+	if len(items) != len(expected) {
+		t.Fatalf("件数: 期待=%d, 実際=%d", len(expected), len(items))
+	}
+	for i := range items {
+		if items[i] != expected[i] {
+			t.Errorf("期待: %+v, 実際: %+v", expected[i], items[i])
+		}
+	}
+
+	// 強調する距離より遠くを通る場合は強調しない
+	if items := buildTyphoonItems(typhoons[:1], tokyo, 50, now); items[0].IsApproaching {
+		t.Error("期待: 強調なし, 実際: 強調あり")
+	}
+}
+
+// typhoonStrength のテスト
+func TestTyphoonStrength(t *testing.T) {
+	tests := []struct {
+		size      string
+		intensity string
+		expected  string
+	}{
+		{"大型", "非常に強い", "大型で非常に強い"},
+		{"", "強い", "強い"},
+		{"超大型", "", "超大型"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if actual := typhoonStrength(tt.size, tt.intensity); actual != tt.expected {
+				t.Errorf("期待: %q, 実際: %q", tt.expected, actual)
+			}
+		})
+	}
+}
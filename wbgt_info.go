@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"kindle-tenki-dashboard/internal/amedas"
+	"kindle-tenki-dashboard/internal/wbgt"
+)
+
+// WBGTInfo は暑さ指数 (WBGT) の表示内容
+type WBGTInfo struct {
+	Enabled     bool   `json:"enabled"`     // 暑さ指数を表示するかどうか (表示する月のみ)
+	Unavailable bool   `json:"unavailable"` // 予測値・観測値のどちらも取得できなかったかどうか
+	Point       string `json:"point"`       // 地点名 (例: 東京)
+	Value       string `json:"value"`       // 暑さ指数 (例: 31.8)
+	Level       string `json:"level"`       // 区分 (例: 危険)
+	LevelNumber int    `json:"levelNumber"` // 区分の段階 (1: ほぼ安全 〜 5: 危険)
+	Note        string `json:"note"`        // 値の説明 (例: 今日の最高 15時 (環境省の予測))
+	IsEstimate  bool   `json:"isEstimate"`  // 気温と湿度からの推定値かどうか
+	IsAlert     bool   `json:"isAlert"`     // 厳重警戒以上かどうか
+}
+
+// fetchWBGTInfo は地点の暑さ指数を取得し、表示内容を生成する
+// 環境省の予測値を取得できない場合は、アメダスの最新の気温と湿度から推定する
+func fetchWBGTInfo(station amedas.Station, now time.Time) WBGTInfo {
+	if body, err := fetchSource("暑さ指数", wbgt.ForecastURL(station.Code)); err != nil {
+		log.Printf("⚠️  %v", err)
+	} else if forecasts, err := wbgt.ParseForecast(body); err != nil {
+		log.Printf("⚠️  %v", err)
+	} else if info, ok := buildWBGTForecast(forecasts, now); ok {
+		info.Point = station.Name
+		return info
+	}
+
+	log.Println("   気温と湿度から暑さ指数を推定します")
+	if observation, err := fetchAmedasObservation(station); err != nil {
+		log.Printf("⚠️  %v", err)
+	} else if info, ok := buildWBGTEstimate(observation); ok {
+		info.Point = station.Name
+		return info
+	}
+
+	return WBGTInfo{Enabled: true, Unavailable: true, Point: station.Name}
+}
+
+// buildWBGTForecast は予測値から今日の最高の暑さ指数の表示内容を生成する
+// 今日の予測値がない場合は false を返す
+func buildWBGTForecast(forecasts []wbgt.Forecast, now time.Time) (WBGTInfo, bool) {
+	max, ok := wbgt.DailyMax(forecasts, now)
+	if !ok {
+		return WBGTInfo{}, false
+	}
+	note := fmt.Sprintf("今日の最高 %d時 (環境省の予測)", max.Time.In(now.Location()).Hour())
+	return newWBGTInfo(max.Value, note, false), true
+}
+
+// buildWBGTEstimate は観測した気温と湿度から現在の暑さ指数の表示内容を生成する
+// 気温または湿度が欠測の場合は false を返す
+func buildWBGTEstimate(observation amedas.Observation) (WBGTInfo, bool) {
+	if !observation.HasTemperature || !observation.HasHumidity {
+		return WBGTInfo{}, false
+	}
+	value := wbgt.Estimate(observation.Temperature, observation.Humidity)
+	note := fmt.Sprintf("現在の推定 (気温%.1f℃・湿度%.0f%%)", observation.Temperature, observation.Humidity)
+	return newWBGTInfo(value, note, true), true
+}
+
+// newWBGTInfo は暑さ指数の値から表示内容を生成する
+func newWBGTInfo(value float64, note string, isEstimate bool) WBGTInfo {
+	level := wbgt.LevelOf(value)
+	return WBGTInfo{
+		Enabled:     true,
+		Value:       fmt.Sprintf("%.1f", value),
+		Level:       level.Label(),
+		LevelNumber: int(level),
+		Note:        note,
+		IsEstimate:  isEstimate,
+		IsAlert:     level >= wbgt.LevelSevere,
+	}
+}
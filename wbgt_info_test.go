@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/amedas"
+	"kindle-tenki-dashboard/internal/wbgt"
+)
+
+// buildWBGTForecast のテスト
+func TestBuildWBGTForecast(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 7, 19, 7, 0, 0, 0, jst)
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 7, day, hour, 0, 0, 0, jst)
+	}
+
+	forecasts := []wbgt.Forecast{
+		{Time: at(19, 9), Value: 28.9},
+		{Time: at(19, 15), Value: 31.8},
+		{Time: at(19, 18), Value: 29.7},
+		{Time: at(20, 12), Value: 33.0},
+	}
+	info, ok := buildWBGTForecast(forecasts, now)
+	if !ok {
+		t.Fatal("期待: 予測値あり, 実際: なし")
+	}
+	expected := WBGTInfo{Enabled: true, Value: "31.8", Level: "危険", LevelNumber: 5, Note: "今日の最高 15時 (環境省の予測)", IsAlert: true}
+	if info != expected {
+		t.Errorf("期待: %+v, 実際: %+v", expected, info)
+	}
+
+	// 今日の予測値がない場合は使わない
+	if _, ok := buildWBGTForecast(forecasts[3:], now); ok {
+		t.Error("期待: 予測値なし, 実際: あり")
+	}
+}
+
+// buildWBGTEstimate のテスト
+func TestBuildWBGTEstimate(t *testing.T) {
+	tests := []struct {
+		name        string
+		observation amedas.Observation
+		expected    WBGTInfo
+		ok          bool
+	}{
+		{
+			name:        "厳重警戒",
+			observation: amedas.Observation{Temperature: 31.0, HasTemperature: true, Humidity: 60, HasHumidity: true},
+			expected:    WBGTInfo{Enabled: true, Value: "28.2", Level: "厳重警戒", LevelNumber: 4, Note: "現在の推定 (気温31.0℃・湿度60%)", IsEstimate: true, IsAlert: true},
+			ok:          true,
+		},
+		{
+			name:        "注意",
+			observation: amedas.Observation{Temperature: 25.0, HasTemperature: true, Humidity: 50, HasHumidity: true},
+			expected:    WBGTInfo{Enabled: true, Value: "21.3", Level: "注意", LevelNumber: 2, Note: "現在の推定 (気温25.0℃・湿度50%)", IsEstimate: true},
+			ok:          true,
+		},
+		{
+			name:        "湿度が欠測",
+			observation: amedas.Observation{Temperature: 31.0, HasTemperature: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := buildWBGTEstimate(tt.observation)
+			if ok != tt.ok {
+				t.Fatalf("期待: %v, 実際: %v", tt.ok, ok)
+			}
+			if info != tt.expected {
+				t.Errorf("期待: %+v, 実際: %+v", tt.expected, info)
+			}
+		})
+	}
+}
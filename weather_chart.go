@@ -0,0 +1,108 @@
+package main
+
+import (
+	"html/template"
+	"time"
+
+	"kindle-tenki-dashboard/internal/chart"
+)
+
+// 気温グラフと降水確率グラフのレイアウト
+// 左右の余白を揃えて、上下に並べたときに時刻の位置が一致するようにする
+var (
+	TemperatureChartLayout = chart.Layout{Width: 800, Height: 130, PaddingTop: 20, PaddingRight: 8, PaddingBottom: 22, PaddingLeft: 32}
+	RainChartLayout        = chart.Layout{Width: 800, Height: 70, PaddingTop: 14, PaddingRight: 8, PaddingBottom: 4, PaddingLeft: 32}
+)
+
+// dayLabels は日付の区切り線に添えるラベル
+var dayLabels = []string{"明日", "明後日", "3日後"}
+
+// TemperatureChart は時間別予報の気温グラフ(SVG)を返す
+func (w *WeatherData) TemperatureChart() template.HTML {
+	if len(w.HourlyForecast) == 0 {
+		return ""
+	}
+
+	points := make([]chart.Point, len(w.HourlyForecast))
+	for i, hf := range w.HourlyForecast {
+		points[i] = chart.Point{Label: formatClock(hf.At), Value: float64(hf.Temp), HasValue: true}
+	}
+
+	svg := chart.LineChart{
+		Title:          "48時間の気温変化",
+		Class:          "line-chart",
+		Layout:         TemperatureChartLayout,
+		Points:         points,
+		Unit:           "℃",
+		Smooth:         true,
+		AnnotateMinMax: true,
+		Separators:     daySeparators(w.HourlyForecast, w.UpdatedAt),
+		Bands:          nightBands(w.HourlyForecast, w.Daylight),
+	}.SVG()
+	return template.HTML(svg)
+}
+
+// RainChart は時間別予報の降水確率グラフ(SVG)を返す
+func (w *WeatherData) RainChart() template.HTML {
+	if len(w.HourlyForecast) == 0 {
+		return ""
+	}
+
+	points := make([]chart.Point, len(w.HourlyForecast))
+	for i, hf := range w.HourlyForecast {
+		points[i] = chart.Point{Label: formatClock(hf.At), Value: float64(hf.RainPercent), HasValue: hf.HasRainPercent}
+	}
+
+	svg := chart.BarChart{
+		Title:         "48時間の降水確率",
+		Class:         "line-chart rain-chart",
+		Layout:        RainChartLayout,
+		Points:        points,
+		Unit:          "%",
+		Min:           0,
+		Max:           100,
+		Gridlines:     []float64{0, 50, 100},
+		BarWidthRatio: 0.5,
+		Separators:    daySeparators(w.HourlyForecast, w.UpdatedAt),
+	}.SVG()
+	return template.HTML(svg)
+}
+
+// daySeparators は予報の日付が変わった位置に区切り線を置く
+// ラベルは now の日付から数えた日 (明日・明後日など) で、now がない場合は最初の予報の日付から数える
+func daySeparators(hourlyForecast []HourlyForecast, now time.Time) []chart.Separator {
+	if len(hourlyForecast) == 0 {
+		return nil
+	}
+	if now.IsZero() {
+		now = hourlyForecast[0].At
+	}
+
+	var separators []chart.Separator
+	for i := 1; i < len(hourlyForecast); i++ {
+		if sameDate(hourlyForecast[i-1].At, hourlyForecast[i].At) {
+			continue
+		}
+		label := ""
+		if days := daysBetween(now, hourlyForecast[i].At); days >= 1 && days <= len(dayLabels) {
+			label = dayLabels[days-1]
+		}
+		separators = append(separators, chart.Separator{Index: i, Label: label})
+	}
+	return separators
+}
+
+// sameDate は a と b が同じ日付かどうかを返す (それぞれのタイムゾーンの日付で比べる)
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// daysBetween は from の日付から to の日付までの日数を返す (to のタイムゾーンの暦で数える)
+func daysBetween(from, to time.Time) int {
+	from = from.In(to.Location())
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 12, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 12, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
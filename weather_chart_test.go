@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/chart"
+)
+
+// daySeparators のテスト
+func TestDaySeparators(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, jst)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		times    []time.Time
+		expected []chart.Separator
+	}{
+		{
+			name:     "日付をまたがない場合",
+			now:      at(18, 11),
+			times:    []time.Time{at(18, 12), at(18, 15), at(18, 18), at(18, 21)},
+			expected: nil,
+		},
+		{
+			name:  "日付を2回またぐ場合",
+			now:   at(18, 17),
+			times: []time.Time{at(18, 18), at(18, 21), at(19, 0), at(19, 3), at(19, 21), at(20, 0)},
+			expected: []chart.Separator{
+				{Index: 2, Label: "明日"},
+				{Index: 5, Label: "明後日"},
+			},
+		},
+		{
+			// 23時台の更新では最初の予報が明日になるため、最初の区切り線は明後日
+			name:     "最初の予報が明日の場合",
+			now:      at(18, 23),
+			times:    []time.Time{at(19, 0), at(19, 3), at(19, 21), at(20, 0)},
+			expected: []chart.Separator{{Index: 3, Label: "明後日"}},
+		},
+		{
+			name:     "更新時刻がない場合は最初の予報の日付から数える",
+			times:    []time.Time{at(18, 21), at(19, 0)},
+			expected: []chart.Separator{{Index: 1, Label: "明日"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hourly []HourlyForecast
+			for _, time := range tt.times {
+				hourly = append(hourly, HourlyForecast{At: time})
+			}
+
+			result := daySeparators(hourly, tt.now)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("期待: %d件, 実際: %d件 (%+v)", len(tt.expected), len(result), result)
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("区切り線[%d]: 期待=%+v, 実際=%+v", i, tt.expected[i], result[i])
+				}
+			}
+		})
+	}
+}
+
+// TemperatureChart / RainChart のテスト
+func TestWeatherCharts(t *testing.T) {
+	t.Run("時間別予報が空の場合はグラフを出力しない", func(t *testing.T) {
+		data := &WeatherData{}
+		if data.TemperatureChart() != "" || data.RainChart() != "" {
+			t.Error("期待: 空文字列")
+		}
+	})
+
+	t.Run("時間別予報からグラフを生成する", func(t *testing.T) {
+		jst := time.FixedZone("JST", 9*60*60)
+		data := &WeatherData{
+			UpdatedAt: time.Date(2026, 10, 18, 20, 0, 0, 0, jst),
+			HourlyForecast: []HourlyForecast{
+				{At: time.Date(2026, 10, 18, 21, 0, 0, 0, jst), Temp: 20, RainPercent: 30, HasRainPercent: true},
+				{At: time.Date(2026, 10, 19, 0, 0, 0, 0, jst), Temp: 18, RainPercent: 50, HasRainPercent: true},
+				{At: time.Date(2026, 10, 19, 3, 0, 0, 0, jst), Temp: 17, HasRainPercent: false},
+			},
+		}
+
+		temperature := string(data.TemperatureChart())
+		for _, expected := range []string{`class="line-chart"`, "▲20℃", "▼17℃", "明日", ">21:00<"} {
+			if !strings.Contains(temperature, expected) {
+				t.Errorf("気温グラフに %q が含まれていません", expected)
+			}
+		}
+
+		rain := string(data.RainChart())
+		if count := strings.Count(rain, "<rect"); count != 2 {
+			t.Errorf("棒の数: 期待=2, 実際=%d", count)
+		}
+		if !strings.Contains(rain, `class="line-chart rain-chart"`) {
+			t.Error("降水確率グラフのclass属性が不正です")
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"kindle-tenki-dashboard/internal/forecast"
+)
+
+// weatherProviderCredits は取得元ごとのフッターのクレジット
+var weatherProviderCredits = map[string]string{
+	forecast.ProviderTsukumijima: "天気予報 API (weather.tsukumijima.net)",
+	forecast.ProviderOpenMeteo:   "Open-Meteo",
+}
+
+// tsukumijimaProvider は天気予報 API (weather.tsukumijima.net) の取得元
+// レスポンスの型 (TsukumijimaWeatherResponse) は比較表示する他の地点の要約でも使う
+type tsukumijimaProvider struct {
+	cityCode string
+}
+
+// Name は取得元の名前を返す
+func (p tsukumijimaProvider) Name() string {
+	return forecast.ProviderTsukumijima
+}
+
+// URL は都市コードの天気予報を取得するURLを返す
+func (p tsukumijimaProvider) URL() string {
+	return fmt.Sprintf("https://weather.tsukumijima.net/api/forecast/city/%s", p.cityCode)
+}
+
+// Parse はレスポンスを日ごとの予報にする
+func (p tsukumijimaProvider) Parse(body []byte) (forecast.Forecast, error) {
+	response, err := parseTsukumijimaResponse(body, p.cityCode)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	return tsukumijimaForecast(response), nil
+}
+
+// parseTsukumijimaResponse は天気予報 API のレスポンスをパースする
+func parseTsukumijimaResponse(body []byte, cityCode string) (TsukumijimaWeatherResponse, error) {
+	var weatherResponse TsukumijimaWeatherResponse
+	if err := json.Unmarshal(body, &weatherResponse); err != nil {
+		return weatherResponse, fmt.Errorf("天気データのパースに失敗しました: %w", err)
+	}
+
+	if len(weatherResponse.Forecasts) == 0 {
+		return weatherResponse, fmt.Errorf("天気データに予報が含まれていません (都市コード: %s)", cityCode)
+	}
+
+	return weatherResponse, nil
+}
+
+// tsukumijimaForecast は天気予報 API のレスポンスを日ごとの予報にする
+// 気温・降水確率は文字列で、発表のない値 (null、"--%") は値なしとする
+func tsukumijimaForecast(response TsukumijimaWeatherResponse) forecast.Forecast {
+	f := forecast.Forecast{Provider: forecast.ProviderTsukumijima, Location: response.Location.City}
+	for _, item := range response.Forecasts {
+		day := forecast.Day{Date: item.Date, Telop: item.Telop, Wind: item.Detail.Wind}
+		if temp, err := parseTemperature(item.Temperature.Max.Celsius); err == nil {
+			day.MaxTemp, day.HasMaxTemp = temp, true
+		}
+		if temp, err := parseTemperature(item.Temperature.Min.Celsius); err == nil {
+			day.MinTemp, day.HasMinTemp = temp, true
+		}
+		slots := []string{item.ChanceOfRain.T00_06, item.ChanceOfRain.T06_12, item.ChanceOfRain.T12_18, item.ChanceOfRain.T18_24}
+		for i, slot := range slots {
+			if percent, err := parseRainChance(slot); err == nil {
+				day.RainChances[i] = forecast.RainChance{Percent: percent, Has: true}
+			}
+		}
+		f.Days = append(f.Days, day)
+	}
+	return f
+}
+
+// formatRainChance は降水確率を "30%" の形式で返す (発表のない時間帯は "--%")
+func formatRainChance(rainChance forecast.RainChance) string {
+	if !rainChance.Has {
+		return "--%"
+	}
+	return fmt.Sprintf("%d%%", rainChance.Percent)
+}
+
+// fetchForecasts は取得元ごとに天気予報を取得する
+// 古いキャッシュの予報から now の日付より前の日を除き、今日以降の日がない取得元は取得できなかったものとする
+// 取得できた予報 (取得元の順) と、取得できなかった取得元の名前を返す
+func fetchForecasts(providers []forecast.Provider, now time.Time) ([]forecast.Forecast, []string) {
+	today := now.Format("2006-01-02")
+	var forecasts []forecast.Forecast
+	var failed []string
+	for _, provider := range providers {
+		label := "天気API"
+		if len(providers) > 1 {
+			label = fmt.Sprintf("天気API (%s)", provider.Name())
+		}
+		var f forecast.Forecast
+		body, err := fetchSource(label, provider.URL())
+		if err == nil {
+			f, err = provider.Parse(body)
+		}
+		if err == nil {
+			f = f.Since(today)
+			if len(f.Days) == 0 {
+				err = fmt.Errorf("%s の予報に今日 (%s) 以降の日がありません", provider.Name(), today)
+			}
+		}
+		if err != nil {
+			log.Printf("⚠️  %v", err)
+			failed = append(failed, provider.Name())
+			continue
+		}
+		forecasts = append(forecasts, f)
+	}
+
+	if len(forecasts) > 0 && len(failed) > 0 {
+		var names []string
+		for _, f := range forecasts {
+			names = append(names, f.Provider)
+		}
+		log.Printf("   取得できた取得元 (%s) の予報を使用します", strings.Join(names, ", "))
+	}
+	return forecasts, failed
+}
+
+// applyForecastSources はまとめた予報の取得元と、取得元の間で予報が分かれている日の表示を設定する
+// 取得元ごとの予報は天気の記録 (予報の精度の集計) に使う
+func applyForecastSources(w *WeatherData, fused forecast.Fused, forecasts []forecast.Forecast, failed []string) {
+	w.WeatherProviders = fused.Providers
+	w.ProviderForecasts = forecasts
+	if len(failed) > 0 {
+		w.ForecastNote = fmt.Sprintf("%s の予報を取得できませんでした", strings.Join(failed, ", "))
+	}
+
+	for i := range w.DailyForecasts {
+		if i < len(fused.Spreads) {
+			w.DailyForecasts[i].Disagreement = spreadNote(fused.Spreads[i])
+		}
+	}
+	if len(w.DailyForecasts) > 0 {
+		w.Disagreement = w.DailyForecasts[0].Disagreement
+	}
+}
+
+// spreadNote は取得元の間で予報が分かれている内容を返す (例: 最高20〜24℃ / 雨の有無)
+// 分かれていない場合は空を返す
+func spreadNote(spread forecast.Spread) string {
+	var notes []string
+	if spread.MaxTemp.Disagrees(forecast.TempSpreadThreshold) {
+		notes = append(notes, fmt.Sprintf("最高%d〜%d℃", spread.MaxTemp.Low, spread.MaxTemp.High))
+	}
+	if spread.MinTemp.Disagrees(forecast.TempSpreadThreshold) {
+		notes = append(notes, fmt.Sprintf("最低%d〜%d℃", spread.MinTemp.Low, spread.MinTemp.High))
+	}
+	if spread.RainChance.Disagrees(forecast.RainSpreadThreshold) {
+		notes = append(notes, fmt.Sprintf("降水%d〜%d%%", spread.RainChance.Low, spread.RainChance.High))
+	}
+	if spread.Precipitation {
+		notes = append(notes, "雨の有無")
+	}
+	return strings.Join(notes, " / ")
+}
+
+// WeatherCredits はフッターに表示する天気予報の取得元のクレジットを返す
+// サンプルデータの場合は天気予報 API のクレジットを返す
+func (w *WeatherData) WeatherCredits() string {
+	var credits []string
+	for _, provider := range w.WeatherProviders {
+		if credit, ok := weatherProviderCredits[provider]; ok {
+			credits = append(credits, credit)
+		}
+	}
+	if len(credits) == 0 {
+		return weatherProviderCredits[forecast.ProviderTsukumijima]
+	}
+	return strings.Join(credits, " / ")
+}
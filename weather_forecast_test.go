@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"kindle-tenki-dashboard/internal/forecast"
+)
+
+// fileProvider はローカルファイルから天気予報を読み込むテスト用の取得元
+type fileProvider struct {
+	name string
+	path string
+}
+
+func (p fileProvider) Name() string { return p.name }
+func (p fileProvider) URL() string  { return p.path }
+func (p fileProvider) Parse(body []byte) (forecast.Forecast, error) {
+	f, err := tsukumijimaProvider{}.Parse(body)
+	f.Provider = p.name
+	return f, err
+}
+
+// tsukumijimaForecast のテスト
+func TestTsukumijimaForecast(t *testing.T) {
+	var response TsukumijimaWeatherResponse
+	err := json.Unmarshal([]byte(`{"location": {"city": "東京"}, "forecasts": [
+		{"date": "2026-10-18", "telop": "晴時々曇", "detail": {"wind": "北の風"},
+			"temperature": {"min": {"celsius": null}, "max": {"celsius": "22"}},
+			"chanceOfRain": {"T00_06": "--%", "T06_12": "10%", "T12_18": "20%", "T18_24": "0%"}}
+	]}`), &response)
+	if err != nil {
+		t.Fatalf("テスト用のレスポンスを読み込めません: %v", err)
+	}
+
+	f := tsukumijimaForecast(response)
+	if f.Provider != forecast.ProviderTsukumijima || f.Location != "東京" || len(f.Days) != 1 {
+		t.Fatalf("予報が不正です: %+v", f)
+	}
+	day := f.Days[0]
+	if day.Date != "2026-10-18" || day.Telop != "晴時々曇" || day.Wind != "北の風" {
+		t.Errorf("日付・天気・風が不正です: %+v", day)
+	}
+	if !day.HasMaxTemp || day.MaxTemp != 22 || day.HasMinTemp {
+		t.Errorf("気温: 期待=最高22℃・最低なし, 実際=%+v", day)
+	}
+	expected := [forecast.RainSlots]forecast.RainChance{{}, {Percent: 10, Has: true}, {Percent: 20, Has: true}, {Percent: 0, Has: true}}
+	if day.RainChances != expected {
+		t.Errorf("降水確率 期待: %+v, 実際: %+v", expected, day.RainChances)
+	}
+}
+
+// fetchForecasts と applyForecastSources のテスト
+func TestFetchForecasts(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	// primary は今日の最低気温が null
+	primary := write("primary.json", `{"location": {"city": "東京"}, "forecasts": [
+		{"date": "2026-10-18", "telop": "晴れ", "temperature": {"max": {"celsius": "20"}}, "chanceOfRain": {"T12_18": "10%"}},
+		{"date": "2026-10-19", "telop": "曇り", "temperature": {"min": {"celsius": "12"}, "max": {"celsius": "19"}}}
+	]}`)
+	secondary := write("secondary.json", `{"forecasts": [
+		{"date": "2026-10-18", "telop": "雨", "temperature": {"min": {"celsius": "13"}, "max": {"celsius": "24"}}, "chanceOfRain": {"T12_18": "60%"}},
+		{"date": "2026-10-19", "telop": "曇り", "temperature": {"min": {"celsius": "11"}, "max": {"celsius": "20"}}}
+	]}`)
+
+	providers := []forecast.Provider{
+		fileProvider{name: "primary", path: primary},
+		fileProvider{name: "missing", path: filepath.Join(dir, "missing.json")},
+		fileProvider{name: "secondary", path: secondary},
+	}
+	now := time.Date(2026, 10, 18, 11, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	forecasts, failed := fetchForecasts(providers, now)
+	if len(forecasts) != 2 || len(failed) != 1 || failed[0] != "missing" {
+		t.Fatalf("期待: 2件取得・missing が失敗, 実際: %d件取得・失敗 %v", len(forecasts), failed)
+	}
+
+	fused := forecast.Fuse(forecasts)
+	data := processWeatherData(fused.Forecast, now)
+	applyForecastSources(data, fused, forecasts, failed)
+
+	// 最低気温は null でない取得元の値を使う
+	if !data.HasMinTemp || data.MinTemp != 13 || data.MaxTemp != 22 || data.Location != "東京" {
+		t.Errorf("気温・地点: 期待=最低13℃・最高22℃・東京, 実際=最低%d℃ (%v)・最高%d℃・%s", data.MinTemp, data.HasMinTemp, data.MaxTemp, data.Location)
+	}
+	if data.ChanceOfRain[1] != "60%" {
+		t.Errorf("降水確率: 期待=60%%, 実際=%s", data.ChanceOfRain[1])
+	}
+	if data.Disagreement != "最高20〜24℃ / 降水10〜60% / 雨の有無" {
+		t.Errorf("今日の予報の差: %q", data.Disagreement)
+	}
+	if len(data.DailyForecasts) != 2 || data.DailyForecasts[1].Disagreement != "" {
+		t.Errorf("明日は予報が分かれていないはずです: %+v", data.DailyForecasts)
+	}
+	if data.ForecastNote != "missing の予報を取得できませんでした" || len(data.WeatherProviders) != 2 || len(data.ProviderForecasts) != 2 {
+		t.Errorf("取得元の表示が不正です: %q, %v", data.ForecastNote, data.WeatherProviders)
+	}
+}
+
+// fetchForecasts のテスト (古いキャッシュの取得元と新しい取得元)
+func TestFetchForecastsStale(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	// stale は昨日取得した予報 (昨日・今日・明日)、expired は一昨日までの予報のみ
+	stale := write("stale.json", `{"location": {"city": "東京"}, "forecasts": [
+		{"date": "2026-10-17", "telop": "雨", "temperature": {"min": {"celsius": "15"}, "max": {"celsius": "18"}}},
+		{"date": "2026-10-18", "telop": "晴れ", "temperature": {"min": {"celsius": "12"}, "max": {"celsius": "22"}}},
+		{"date": "2026-10-19", "telop": "曇り", "temperature": {"min": {"celsius": "13"}, "max": {"celsius": "20"}}}
+	]}`)
+	fresh := write("fresh.json", `{"location": {"city": "東京"}, "forecasts": [
+		{"date": "2026-10-18", "telop": "晴れ", "temperature": {"min": {"celsius": "12"}, "max": {"celsius": "22"}}},
+		{"date": "2026-10-19", "telop": "曇り", "temperature": {"min": {"celsius": "13"}, "max": {"celsius": "20"}}},
+		{"date": "2026-10-20", "telop": "晴れ", "temperature": {"min": {"celsius": "11"}, "max": {"celsius": "21"}}}
+	]}`)
+	expired := write("expired.json", `{"forecasts": [
+		{"date": "2026-10-16", "telop": "雨", "temperature": {"max": {"celsius": "17"}}}
+	]}`)
+
+	providers := []forecast.Provider{
+		fileProvider{name: "stale", path: stale},
+		fileProvider{name: "fresh", path: fresh},
+		fileProvider{name: "expired", path: expired},
+	}
+	now := time.Date(2026, 10, 18, 11, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	forecasts, failed := fetchForecasts(providers, now)
+	if len(forecasts) != 2 || len(failed) != 1 || failed[0] != "expired" {
+		t.Fatalf("期待: 2件取得・expired が失敗, 実際: %d件取得・失敗 %v", len(forecasts), failed)
+	}
+	if first := forecasts[0].Days[0].Date; first != "2026-10-18" {
+		t.Errorf("古いキャッシュの昨日の予報が残っています: %s", first)
+	}
+
+	fused := forecast.Fuse(forecasts)
+	data := processWeatherData(fused.Forecast, now)
+	applyForecastSources(data, fused, forecasts, failed)
+
+	// 今日は昨日の雨・18℃ ではなく今日の予報
+	if data.Description != "晴れ" || data.MaxTemp != 22 || !data.HasMaxTemp {
+		t.Errorf("今日の予報: 期待=晴れ・最高22℃, 実際=%s・最高%d℃", data.Description, data.MaxTemp)
+	}
+	expected := []string{"今日 晴れ", "明日 曇り", "明後日 晴れ"}
+	if len(data.DailyForecasts) != len(expected) {
+		t.Fatalf("日数: 期待=%d, 実際=%d", len(expected), len(data.DailyForecasts))
+	}
+	for i, daily := range data.DailyForecasts {
+		if actual := daily.Date + " " + daily.Description; actual != expected[i] {
+			t.Errorf("期待: %s, 実際: %s", expected[i], actual)
+		}
+		if daily.Disagreement != "" {
+			t.Errorf("%s の予報が分かれています: %s", daily.Date, daily.Disagreement)
+		}
+	}
+}
+
+// spreadNote のテスト
+func TestSpreadNote(t *testing.T) {
+	tests := []struct {
+		name     string
+		spread   forecast.Spread
+		expected string
+	}{
+		{
+			name:     "差が小さい",
+			spread:   forecast.Spread{MaxTemp: forecast.Range{Low: 20, High: 22, Count: 2}, RainChance: forecast.Range{Low: 10, High: 30, Count: 2}},
+			expected: "",
+		},
+		{
+			name:     "最低気温と降水確率",
+			spread:   forecast.Spread{MinTemp: forecast.Range{Low: 8, High: 11, Count: 3}, RainChance: forecast.Range{Low: 30, High: 70, Count: 2}},
+			expected: "最低8〜11℃ / 降水30〜70%",
+		},
+		{
+			name:     "1つの取得元の値のみ",
+			spread:   forecast.Spread{MaxTemp: forecast.Range{Low: 20, High: 20, Count: 1}},
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := spreadNote(tt.spread); result != tt.expected {
+				t.Errorf("期待: %q, 実際: %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+// WeatherCredits のテスト
+func TestWeatherCredits(t *testing.T) {
+	tests := []struct {
+		providers []string
+		expected  string
+	}{
+		{nil, "天気予報 API (weather.tsukumijima.net)"},
+		{[]string{"open-meteo"}, "Open-Meteo"},
+		{[]string{"tsukumijima", "open-meteo"}, "天気予報 API (weather.tsukumijima.net) / Open-Meteo"},
+	}
+	for _, tt := range tests {
+		data := &WeatherData{WeatherProviders: tt.providers}
+		if result := data.WeatherCredits(); result != tt.expected {
+			t.Errorf("%v 期待: %s, 実際: %s", tt.providers, tt.expected, result)
+		}
+	}
+}
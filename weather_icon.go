@@ -0,0 +1,25 @@
+package main
+
+import (
+	"html/template"
+
+	"kindle-tenki-dashboard/internal/icon"
+)
+
+// Icon は天気コードに対応する天気アイコンのHTMLを返す
+// 端末の設定が SVG の場合は同梱のモノクロSVGアイコンを埋め込み、
+// 絵文字の場合、または天気コードがない場合 (サンプルデータなど) は絵文字 (emoji) を返す
+func (p PageData) Icon(code, emoji string) template.HTML {
+	return p.DayNightIcon(code, emoji, false)
+}
+
+// DayNightIcon は Icon と同じく天気アイコンのHTMLを返す
+// night が true の場合は太陽を月に替えた夜のSVGアイコンにする (絵文字は呼び出し側で夜のものを渡す)
+func (p PageData) DayNightIcon(code, emoji string, night bool) template.HTML {
+	if p.Device.Icons != IconsEmoji && code != "" {
+		if svg, ok := icon.SVG(icon.Name(code, night)); ok {
+			return template.HTML(svg)
+		}
+	}
+	return template.HTML(template.HTMLEscapeString(emoji))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// PageData.Icon のテスト
+func TestPageDataIcon(t *testing.T) {
+	tests := []struct {
+		name     string
+		icons    string
+		code     string
+		emoji    string
+		contains string
+	}{
+		{"SVGアイコン", IconsSVG, "clear-cloudy", "🌤️", `class="icon icon-partly-cloudy"`},
+		{"天気が読み取れない場合もSVGアイコン", IconsSVG, "unknown", "🌡️", `class="icon icon-unknown"`},
+		{"絵文字の設定の場合は絵文字", IconsEmoji, "clear-cloudy", "🌤️", "🌤️"},
+		{"天気コードがない場合は絵文字", IconsSVG, "", "☀️", "☀️"},
+		{"絵文字はエスケープする", IconsEmoji, "clear", "<b>", "&lt;b&gt;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := PageData{Device: DeviceProfile{Icons: tt.icons}}
+			actual := string(page.Icon(tt.code, tt.emoji))
+			if !strings.Contains(actual, tt.contains) {
+				t.Errorf("期待: %s を含む, 実際: %s", tt.contains, actual)
+			}
+			if tt.icons == IconsEmoji && strings.Contains(actual, "<svg") {
+				t.Errorf("絵文字の設定でSVGが出力されています: %s", actual)
+			}
+		})
+	}
+}
+
+// PageData.DayNightIcon のテスト
+func TestPageDataDayNightIcon(t *testing.T) {
+	page := PageData{Device: DeviceProfile{Icons: IconsSVG}}
+	if actual := string(page.DayNightIcon("clear-cloudy", "☁️", true)); !strings.Contains(actual, `class="icon icon-partly-cloudy-night"`) {
+		t.Errorf("夜のアイコンになっていません: %s", actual)
+	}
+	if actual := string(page.DayNightIcon("clear-cloudy", "🌤️", false)); !strings.Contains(actual, `class="icon icon-partly-cloudy"`) {
+		t.Errorf("昼のアイコンになっていません: %s", actual)
+	}
+
+	page.Device.Icons = IconsEmoji
+	if actual := string(page.DayNightIcon("clear", "🌙", true)); actual != "🌙" {
+		t.Errorf("期待: 🌙, 実際: %s", actual)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import "kindle-tenki-dashboard/internal/wind"
+
+// WindPart は風の予報の地域ごとの表示内容
+type WindPart struct {
+	Place    string        `json:"place"`    // 地域 (地域の指定のない風は空、例: 海上)
+	Segments []WindSegment `json:"segments"` // 区切りごとの風
+}
+
+// WindSegment は風の予報の1つの区切りの表示内容
+type WindSegment struct {
+	Connector string `json:"connector"` // 前の区切りとのつながり (例: 後)
+	When      string `json:"when"`      // 時間帯 (例: 日中)
+	Arrow     string `json:"arrow"`     // 風が吹いていく向きの矢印 (例: ↓)
+	Direction string `json:"direction"` // 風向 (例: 北北東)
+	Strength  string `json:"strength"`  // 風の強さ (例: やや強い。強さの表現がない場合は空)
+	IsStrong  bool   `json:"isStrong"`  // 強い風 (15m/s以上) かどうか
+}
+
+// buildWindParts は風の予報文を風向・強さに分解し、表示内容を生成する
+// 地域の指定のない風を先頭に、「海上では」などの一部の地域の風を続ける
+func buildWindParts(text string) []WindPart {
+	forecast := wind.Parse(text)
+	var parts []WindPart
+	if len(forecast.Segments) > 0 {
+		parts = append(parts, WindPart{Segments: buildWindSegments(forecast.Segments)})
+	}
+	for _, exception := range forecast.Exceptions {
+		if len(exception.Segments) == 0 {
+			continue
+		}
+		parts = append(parts, WindPart{Place: exception.Place, Segments: buildWindSegments(exception.Segments)})
+	}
+	return parts
+}
+
+// buildWindSegments は区切りごとの表示内容を生成する
+func buildWindSegments(segments []wind.Segment) []WindSegment {
+	result := make([]WindSegment, len(segments))
+	for i, segment := range segments {
+		direction := segment.Direction.Label()
+		if direction == "" {
+			direction = "弱い風"
+		}
+		result[i] = WindSegment{
+			Connector: segment.Connector.Label(),
+			When:      segment.When,
+			Arrow:     segment.Direction.Arrow(),
+			Direction: direction,
+			Strength:  segment.Strength.Label(),
+			IsStrong:  segment.Strength >= wind.StrengthStrong,
+		}
+	}
+	return result
+}
+
+// estimateWindSpeed は「北の風 後 南の風 やや強く」のような風の予報文から代表的な風速 (m/s) を推定する
+// 「海上では」などの一部の地域の風は陸上の体感に関係しないため使わず、読み取れない場合は false を返す
+func estimateWindSpeed(text string) (float64, bool) {
+	strength, ok := wind.Parse(text).MaxStrength()
+	if !ok {
+		return 0, false
+	}
+	return strength.Speed(), true
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// buildWindParts のテスト
+func TestBuildWindParts(t *testing.T) {
+	parts := buildWindParts("北北東の風　後　南の風　強く　海上　では　南の風　非常に強く")
+	expected := []WindPart{
+		{Segments: []WindSegment{
+			{Arrow: "↓", Direction: "北北東"},
+			{Connector: "後", Arrow: "↑", Direction: "南", Strength: "強い", IsStrong: true},
+		}},
+		{Place: "海上", Segments: []WindSegment{
+			{Arrow: "↑", Direction: "南", Strength: "非常に強い", IsStrong: true},
+		}},
+	}
+	if !reflect.DeepEqual(parts, expected) {
+		t.Errorf("期待: %+v, 実際: %+v", expected, parts)
+	}
+
+	// 方位の定まらない風
+	parts = buildWindParts("風弱く")
+	if len(parts) != 1 || parts[0].Segments[0].Direction != "弱い風" || parts[0].Segments[0].Arrow != "" {
+		t.Errorf("弱い風の表示内容が不正です: %+v", parts)
+	}
+
+	if parts := buildWindParts(""); len(parts) != 0 {
+		t.Errorf("期待: 表示内容なし, 実際: %+v", parts)
+	}
+}
+
+// estimateWindSpeed のテスト
+func TestEstimateWindSpeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		wind     string
+		expected float64
+		ok       bool
+	}{
+		{name: "強さの表現なし", wind: "北の風　後　南の風", expected: 3, ok: true},
+		{name: "やや強く", wind: "北の風　やや強く", expected: 12.5, ok: true},
+		{name: "強く", wind: "北西の風　強く", expected: 17.5, ok: true},
+		{name: "非常に強く", wind: "南の風　非常に強く", expected: 25, ok: true},
+		{name: "海上の風は使わない", wind: "北の風　後　南の風　海上　では　南の風　やや強く", expected: 3, ok: true},
+		{name: "予報文なし", wind: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, ok := estimateWindSpeed(tt.wind)
+			if actual != tt.expected || ok != tt.ok {
+				t.Errorf("期待: %v (%v), 実際: %v (%v)", tt.expected, tt.ok, actual, ok)
+			}
+		})
+	}
+}